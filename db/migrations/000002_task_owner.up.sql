@@ -0,0 +1,20 @@
+ALTER TABLE task
+  ADD COLUMN owner VARCHAR(255) REFERENCES "user" (login) ON DELETE CASCADE;
+
+-- Tasks created before the owners were introduced are attributed to the user
+-- from the migrate.legacy_task_owner setting, it can be set for the database
+-- with ALTER DATABASE ... SET migrate.legacy_task_owner = '<login>'
+UPDATE task SET owner = nullif(current_setting('migrate.legacy_task_owner', true), '')
+WHERE owner IS NULL;
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM task WHERE owner IS NULL) THEN
+    RAISE EXCEPTION 'tasks without owner are found, set migrate.legacy_task_owner to the login of their owner';
+  END IF;
+END
+$$;
+
+ALTER TABLE task ALTER COLUMN owner SET NOT NULL;
+
+CREATE INDEX idx_task_owner ON task (owner);
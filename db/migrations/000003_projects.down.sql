@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_task_project_id;
+
+ALTER TABLE task DROP COLUMN IF EXISTS project_id;
+
+DROP INDEX IF EXISTS idx_project_owner;
+
+DROP TABLE IF EXISTS project;
@@ -0,0 +1,16 @@
+CREATE TABLE
+  project (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+  );
+
+CREATE INDEX idx_project_owner ON project (owner);
+
+ALTER TABLE task
+  ADD COLUMN project_id UUID REFERENCES project (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_task_project_id ON task (project_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_project_member_login;
+
+DROP TABLE IF EXISTS project_member;
+
+DROP TYPE IF EXISTS project_role;
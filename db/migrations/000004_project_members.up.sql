@@ -0,0 +1,14 @@
+CREATE TYPE project_role AS ENUM ('owner', 'editor', 'viewer');
+
+CREATE TABLE
+  project_member (
+    project_id UUID NOT NULL REFERENCES project (id) ON DELETE CASCADE,
+    login VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    role project_role NOT NULL,
+    PRIMARY KEY (project_id, login)
+  );
+
+CREATE INDEX idx_project_member_login ON project_member (login);
+
+INSERT INTO project_member (project_id, login, role)
+SELECT id, owner, 'owner' FROM project;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_task_label_label_id;
+
+DROP TABLE IF EXISTS task_label;
+
+DROP TABLE IF EXISTS label;
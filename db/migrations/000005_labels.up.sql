@@ -0,0 +1,18 @@
+CREATE TABLE
+  label (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (owner, name)
+  );
+
+CREATE TABLE
+  task_label (
+    task_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES label (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+  );
+
+CREATE INDEX idx_task_label_label_id ON task_label (label_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_parent_id;
+
+ALTER TABLE task DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,4 @@
+ALTER TABLE task
+  ADD COLUMN parent_id UUID REFERENCES task (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_task_parent_id ON task (parent_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_dependency_blocked_by_id;
+
+DROP TABLE IF EXISTS task_dependency;
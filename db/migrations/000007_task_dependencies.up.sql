@@ -0,0 +1,9 @@
+CREATE TABLE
+  task_dependency (
+    task_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    blocked_by_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id != blocked_by_id)
+  );
+
+CREATE INDEX idx_task_dependency_blocked_by_id ON task_dependency (blocked_by_id);
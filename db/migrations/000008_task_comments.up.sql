@@ -0,0 +1,11 @@
+CREATE TABLE
+  task_comment (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+  );
+
+CREATE INDEX idx_task_comment_task_id ON task_comment (task_id, created_at);
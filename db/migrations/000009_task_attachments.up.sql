@@ -0,0 +1,12 @@
+CREATE TABLE
+  task_attachment (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    uploader VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL
+  );
+
+CREATE INDEX idx_task_attachment_task_id ON task_attachment (task_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_reminder_task_id;
+
+DROP TABLE IF EXISTS task_reminder;
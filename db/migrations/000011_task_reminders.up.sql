@@ -0,0 +1,13 @@
+CREATE TABLE
+  task_reminder (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES task (id) ON DELETE CASCADE,
+    login VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    before_seconds BIGINT NOT NULL CHECK (before_seconds >= 0),
+    -- Due date of the task at the moment the reminder was sent,
+    -- the reminder fires again when the due date is changed
+    sent_for DATE,
+    created_at TIMESTAMP NOT NULL
+  );
+
+CREATE INDEX idx_task_reminder_task_id ON task_reminder (task_id);
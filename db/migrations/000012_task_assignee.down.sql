@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_assignee;
+
+ALTER TABLE task DROP COLUMN IF EXISTS assignee;
@@ -0,0 +1,4 @@
+ALTER TABLE task
+  ADD COLUMN assignee VARCHAR(255) REFERENCES "user" (login) ON DELETE SET NULL;
+
+CREATE INDEX idx_task_assignee ON task (assignee);
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS task_event_append_only ON task_event;
+
+DROP FUNCTION IF EXISTS task_event_append_only;
+
+DROP INDEX IF EXISTS idx_task_event_task_id;
+
+DROP TABLE IF EXISTS task_event;
+
+DROP TYPE IF EXISTS task_event_kind;
@@ -0,0 +1,25 @@
+CREATE TYPE task_event_kind AS ENUM ('created', 'updated', 'deleted');
+
+-- History of the task changes, the task id is not a foreign key
+-- since events should outlive the task
+CREATE TABLE
+  task_event (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    kind task_event_kind NOT NULL,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+  );
+
+CREATE INDEX idx_task_event_task_id ON task_event (task_id, created_at);
+
+CREATE FUNCTION task_event_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'task_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_event_append_only
+  BEFORE UPDATE OR DELETE ON task_event
+  FOR EACH ROW EXECUTE FUNCTION task_event_append_only();
@@ -0,0 +1 @@
+ALTER TABLE task ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
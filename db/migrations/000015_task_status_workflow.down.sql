@@ -0,0 +1,6 @@
+CREATE TYPE task_status AS ENUM ('pending', 'in_progress', 'done');
+
+UPDATE task SET status = 'in_progress'
+WHERE status NOT IN ('pending', 'in_progress', 'done');
+
+ALTER TABLE task ALTER COLUMN status TYPE task_status USING status::task_status;
@@ -0,0 +1,4 @@
+-- Task statuses are defined by the configurable workflow
+ALTER TABLE task ALTER COLUMN status TYPE VARCHAR(64) USING status::text;
+
+DROP TYPE IF EXISTS task_status;
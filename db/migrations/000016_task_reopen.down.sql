@@ -0,0 +1,10 @@
+ALTER TABLE task_event DROP COLUMN IF EXISTS reason;
+
+ALTER TABLE task_event ALTER COLUMN kind TYPE VARCHAR(64) USING kind::text;
+
+DROP TYPE IF EXISTS task_event_kind;
+
+CREATE TYPE task_event_kind AS ENUM ('created', 'updated', 'deleted');
+
+ALTER TABLE task_event ALTER COLUMN kind TYPE task_event_kind
+  USING (CASE WHEN kind = 'reopened' THEN 'updated' ELSE kind END)::task_event_kind;
@@ -0,0 +1,3 @@
+ALTER TYPE task_event_kind ADD VALUE IF NOT EXISTS 'reopened';
+
+ALTER TABLE task_event ADD COLUMN reason TEXT;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_updated_at;
+
+DROP INDEX IF EXISTS idx_task_created_at;
@@ -0,0 +1,3 @@
+CREATE INDEX idx_task_created_at ON task (created_at, id);
+
+CREATE INDEX idx_task_updated_at ON task (updated_at, id);
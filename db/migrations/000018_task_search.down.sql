@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_search;
+
+DROP FUNCTION IF EXISTS task_search_vector;
@@ -0,0 +1,6 @@
+CREATE FUNCTION task_search_vector(title TEXT, description TEXT) RETURNS tsvector AS $$
+  SELECT setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B');
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE INDEX idx_task_search ON task USING gin (task_search_vector(title, description));
@@ -0,0 +1,11 @@
+CREATE TABLE
+  task_view (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL REFERENCES "user" (login) ON DELETE CASCADE,
+    filter JSONB NOT NULL,
+    sort VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (owner, name)
+  );
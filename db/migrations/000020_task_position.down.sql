@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_status_position;
+
+ALTER TABLE task DROP COLUMN IF EXISTS position;
@@ -0,0 +1,14 @@
+ALTER TABLE task ADD COLUMN position TEXT COLLATE "C";
+
+-- Positions of existing tasks follow the creation order within the status,
+-- odd numbers never end with the zero digit which keeps room between them
+UPDATE task SET position = ranked.position
+FROM (
+  SELECT id, lpad((row_number() OVER (PARTITION BY status ORDER BY created_at, id) * 2 - 1)::text, 10, '0') AS position
+  FROM task
+) AS ranked
+WHERE task.id = ranked.id;
+
+ALTER TABLE task ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX idx_task_status_position ON task (status, position, id);
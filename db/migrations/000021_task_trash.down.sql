@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_task_deleted_at;
+
+DELETE FROM task WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE task DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE task_event ALTER COLUMN kind TYPE VARCHAR(64) USING kind::text;
+
+DROP TYPE IF EXISTS task_event_kind;
+
+CREATE TYPE task_event_kind AS ENUM ('created', 'updated', 'deleted', 'reopened');
+
+ALTER TABLE task_event ALTER COLUMN kind TYPE task_event_kind
+  USING (CASE WHEN kind = 'restored' THEN 'created' ELSE kind END)::task_event_kind;
@@ -0,0 +1,5 @@
+ALTER TYPE task_event_kind ADD VALUE IF NOT EXISTS 'restored';
+
+ALTER TABLE task ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_task_deleted_at ON task (deleted_at) WHERE deleted_at IS NOT NULL;
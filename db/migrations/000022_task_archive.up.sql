@@ -0,0 +1,5 @@
+ALTER TYPE task_event_kind ADD VALUE IF NOT EXISTS 'archived';
+
+ALTER TABLE task ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX idx_task_archived_at ON task (archived_at) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE task_reminder ALTER COLUMN sent_for TYPE DATE USING sent_for::date;
+
+DROP FUNCTION IF EXISTS task_deadline;
+
+DROP FUNCTION IF EXISTS task_due_at;
+
+ALTER TABLE task DROP COLUMN IF EXISTS time_zone;
+
+ALTER TABLE task DROP COLUMN IF EXISTS due_time;
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS time_zone;
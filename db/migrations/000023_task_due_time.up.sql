@@ -0,0 +1,19 @@
+ALTER TABLE "user" ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE task ADD COLUMN due_time TIME;
+
+ALTER TABLE task ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- The moment in UTC when the task is due, tasks without the due time
+-- are due at the start of the due date
+CREATE FUNCTION task_due_at(due_date DATE, due_time TIME, time_zone TEXT) RETURNS TIMESTAMP AS $$
+  SELECT (due_date + coalesce(due_time, TIME '00:00')) AT TIME ZONE time_zone AT TIME ZONE 'UTC';
+$$ LANGUAGE SQL STABLE;
+
+-- The moment in UTC after which the not completed task is overdue,
+-- tasks without the due time are overdue after the end of the due date
+CREATE FUNCTION task_deadline(due_date DATE, due_time TIME, time_zone TEXT) RETURNS TIMESTAMP AS $$
+  SELECT (due_date + coalesce(due_time, TIME '24:00')) AT TIME ZONE time_zone AT TIME ZONE 'UTC';
+$$ LANGUAGE SQL STABLE;
+
+ALTER TABLE task_reminder ALTER COLUMN sent_for TYPE TIMESTAMP USING sent_for::timestamp;
@@ -4,14 +4,26 @@ SELECT * FROM "user" WHERE login = $1;
 -- name: InsertUser :exec
 INSERT INTO "user" (login, password_hash) VALUES ($1, $2);
 
+-- name: UpdateUserTimeZone :execrows
+UPDATE "user" SET time_zone = $2 WHERE login = $1;
+
 -- name: AllTasks :many
-SELECT * FROM task;
+SELECT * FROM task
+WHERE
+  deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $1 OR assignee = $1 OR project_id IN (SELECT project_id FROM project_member WHERE login = $1));
+
+-- name: TaskById :one
+SELECT * FROM task
+WHERE
+  id = $1 AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $2 OR assignee = $2 OR project_id IN (SELECT project_id FROM project_member WHERE login = $2));
 
 -- name: InsertTask :exec
 INSERT INTO task
-  (id, title, description, status, priority, due_date, created_at, updated_at)
+  (id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, position, due_time, time_zone)
 VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8);
+  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, coalesce($16::text, (SELECT "user".time_zone FROM "user" WHERE login = $9)));
 
 -- name: UpdateTask :execrows
 UPDATE task SET
@@ -20,18 +32,128 @@ UPDATE task SET
   status = $4,
   priority = $5,
   due_date = $6,
-  updated_at = CURRENT_DATE
+  project_id = $7,
+  parent_id = $8,
+  recurrence = $9,
+  assignee = $10,
+  due_time = $12,
+  time_zone = coalesce($13::text, time_zone),
+  position = coalesce($15::text, position),
+  version = version + 1,
+  updated_at = $14
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $11 OR task.assignee = $11 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $11));
+
+-- name: ReopenTask :execrows
+UPDATE task SET
+  status = @status,
+  position = @position,
+  version = version + 1,
+  updated_at = @updated_at
+WHERE
+  task.id = @id AND task.status = @done_status AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = @owner OR task.assignee = @owner OR task.project_id IN (SELECT project_id FROM project_member WHERE login = @owner));
+
+-- name: MoveTask :execrows
+UPDATE task SET
+  status = $2,
+  position = $3,
+  version = version + 1,
+  updated_at = CASE WHEN status = $2 THEN updated_at ELSE $5 END
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $4 OR task.assignee = $4 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $4));
+
+-- name: PrevTaskPosition :one
+SELECT position FROM task
 WHERE
-  task.id = $1 AND task.status != 'done';
+  status = @status AND id != @id AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = @login OR assignee = @login OR project_id IN (SELECT project_id FROM project_member WHERE login = @login)) AND
+  (sqlc.narg(before)::text IS NULL OR position < sqlc.narg(before))
+ORDER BY position DESC
+LIMIT 1;
+
+-- name: NextTaskPosition :one
+SELECT position FROM task
+WHERE
+  status = @status AND id != @id AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = @login OR assignee = @login OR project_id IN (SELECT project_id FROM project_member WHERE login = @login)) AND
+  position > @after
+ORDER BY position
+LIMIT 1;
 
 -- name: DeleteTask :execrows
-DELETE FROM task WHERE task.id = $1;
+UPDATE task SET deleted_at = $3
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $2 OR task.assignee = $2 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $2));
+
+-- name: DeleteTasks :exec
+UPDATE task SET deleted_at = @deleted_at
+WHERE id = ANY(@task_ids::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL;
+
+-- name: DeletedTasks :many
+SELECT * FROM task
+WHERE
+  deleted_at IS NOT NULL AND
+  (owner = $1 OR assignee = $1 OR project_id IN (SELECT project_id FROM project_member WHERE login = $1))
+ORDER BY deleted_at DESC, id;
+
+-- name: DeletedTaskById :one
+SELECT * FROM task
+WHERE
+  id = $1 AND deleted_at IS NOT NULL AND
+  (owner = $2 OR assignee = $2 OR project_id IN (SELECT project_id FROM project_member WHERE login = $2));
+
+-- name: DeletedTasksTree :many
+WITH RECURSIVE tree AS (
+  SELECT id, deleted_at FROM task WHERE task.id = $1 AND task.deleted_at IS NOT NULL
+  UNION
+  SELECT task.id, task.deleted_at FROM task
+  JOIN tree ON task.parent_id = tree.id AND task.deleted_at = tree.deleted_at
+)
+SELECT task.* FROM task WHERE task.id IN (SELECT id FROM tree);
+
+-- name: IsTaskDeleted :one
+SELECT deleted_at IS NOT NULL FROM task WHERE id = $1;
+
+-- name: RestoreTasks :exec
+UPDATE task SET deleted_at = NULL WHERE id = ANY(@task_ids::uuid[]);
 
--- name: DeleteOverdueTasks :exec
-DELETE FROM task WHERE status != 'done' and due_date < $1;
+-- name: DeletedTasksIds :many
+SELECT id FROM task WHERE deleted_at < $1;
+
+-- name: PurgeDeletedTasks :exec
+DELETE FROM task WHERE deleted_at < $1;
+
+-- name: DetachArchivedSubtasks :exec
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY(@task_ids::uuid[])
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id WHERE task.archived_at IS NULL
+)
+UPDATE task SET parent_id = NULL
+WHERE archived_at IS NOT NULL AND parent_id IN (SELECT id FROM tree);
+
+-- name: ArchiveTasks :exec
+UPDATE task SET archived_at = @archived_at
+WHERE id = ANY(@task_ids::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL;
+
+-- name: CountOpenSubtasks :one
+SELECT count(*) FROM task WHERE parent_id = $1 AND status != $2 AND deleted_at IS NULL AND archived_at IS NULL;
+
+-- name: IsTaskDescendant :one
+WITH RECURSIVE ancestor AS (
+  SELECT task.id, task.parent_id FROM task WHERE task.id = @id
+  UNION
+  SELECT task.id, task.parent_id FROM task
+  JOIN ancestor ON task.id = ancestor.parent_id
+)
+SELECT EXISTS (SELECT 1 FROM ancestor WHERE ancestor.id = @ancestor_id);
 
 -- name: CountTasksByStatus :many
-SELECT count(*) AS tasks_count, status FROM task GROUP BY status;
+SELECT count(*) AS tasks_count, status FROM task WHERE deleted_at IS NULL AND archived_at IS NULL GROUP BY status;
 
 -- name: AverageTaskCompletionTime :one
 SELECT
@@ -39,14 +161,250 @@ SELECT
 FROM
     task
 WHERE
-    task.status = 'done';
+    task.status = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL;
 
 -- name: CountCompletedAndOverdueTasks :one
 WITH last_week_task AS (
   SELECT *
   FROM task
-  WHERE updated_at >= $1
+  WHERE updated_at >= @updated_at AND deleted_at IS NULL AND archived_at IS NULL
 )
 SELECT
-  (SELECT count(*) FROM last_week_task WHERE status = 'done') AS completed_count,
-  (SELECT count(*) FROM last_week_task WHERE status != 'done' AND due_date < CURRENT_DATE) AS overdue_count;
+  (SELECT count(*) FROM last_week_task WHERE status = @done_status) AS completed_count,
+  (SELECT count(*) FROM last_week_task WHERE status != @done_status AND task_deadline(due_date, due_time, time_zone) < now() AT TIME ZONE 'UTC') AS overdue_count;
+
+-- name: InsertProject :exec
+INSERT INTO project
+  (id, name, description, owner, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6);
+
+-- name: ProjectById :one
+SELECT project.* FROM project
+JOIN project_member ON project_member.project_id = project.id
+WHERE project.id = $1 AND project_member.login = $2;
+
+-- name: ProjectsByMember :many
+SELECT project.* FROM project
+JOIN project_member ON project_member.project_id = project.id
+WHERE project_member.login = $1
+ORDER BY project.created_at;
+
+-- name: UpdateProject :execrows
+UPDATE project SET
+  name = $2,
+  description = $3,
+  updated_at = $4
+WHERE
+  project.id = $1;
+
+-- name: DeleteProject :execrows
+DELETE FROM project WHERE project.id = $1;
+
+-- name: ProjectMemberRole :one
+SELECT role FROM project_member WHERE project_id = $1 AND login = $2;
+
+-- name: ProjectMembers :many
+SELECT * FROM project_member WHERE project_id = $1 ORDER BY login;
+
+-- name: UpsertProjectMember :exec
+INSERT INTO project_member (project_id, login, role) VALUES ($1, $2, $3)
+ON CONFLICT (project_id, login) DO UPDATE SET role = EXCLUDED.role;
+
+-- name: DeleteProjectMember :execrows
+DELETE FROM project_member WHERE project_id = $1 AND login = $2;
+
+-- name: InsertLabel :exec
+INSERT INTO label
+  (id, name, owner, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5);
+
+-- name: LabelById :one
+SELECT * FROM label WHERE id = $1 AND owner = $2;
+
+-- name: LabelsByOwner :many
+SELECT * FROM label WHERE owner = $1 ORDER BY name;
+
+-- name: UpdateLabel :execrows
+UPDATE label SET
+  name = $3,
+  updated_at = $4
+WHERE
+  id = $1 AND owner = $2;
+
+-- name: DeleteLabel :execrows
+DELETE FROM label WHERE id = $1 AND owner = $2;
+
+-- name: TaskLabels :many
+SELECT task_label.task_id, label.* FROM task_label
+JOIN label ON label.id = task_label.label_id
+WHERE task_label.task_id = ANY(@task_ids::uuid[])
+ORDER BY label.name;
+
+-- name: DeleteTaskLabelsExcept :exec
+DELETE FROM task_label
+WHERE task_id = @task_id AND NOT (label_id = ANY(@label_ids::uuid[]));
+
+-- name: InsertTaskLabels :exec
+INSERT INTO task_label (task_id, label_id)
+SELECT @task_id::uuid, label.id FROM label
+WHERE label.id = ANY(@label_ids::uuid[]) AND label.owner = @owner
+ON CONFLICT DO NOTHING;
+
+-- name: CountTaskLabels :one
+SELECT count(*) FROM task_label WHERE task_id = $1;
+
+-- name: InsertTaskDependency :exec
+INSERT INTO task_dependency (task_id, blocked_by_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependency WHERE task_id = $1 AND blocked_by_id = $2;
+
+-- name: TasksBlockersIds :many
+SELECT DISTINCT blocked_by_id FROM task_dependency
+WHERE task_id = ANY(@task_ids::uuid[]);
+
+-- name: TaskBlockers :many
+SELECT task_dependency.task_id, task.id, task.status FROM task_dependency
+JOIN task ON task.id = task_dependency.blocked_by_id
+WHERE task_dependency.task_id = ANY(@task_ids::uuid[]) AND task.deleted_at IS NULL AND task.archived_at IS NULL
+ORDER BY task.due_date;
+
+-- name: CountOpenBlockers :one
+SELECT count(*) FROM task_dependency
+JOIN task ON task.id = task_dependency.blocked_by_id
+WHERE task_dependency.task_id = $1 AND task.status != $2 AND task.deleted_at IS NULL AND task.archived_at IS NULL;
+
+-- name: InsertTaskComment :exec
+INSERT INTO task_comment
+  (id, task_id, author, body, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6);
+
+-- name: TaskComments :many
+SELECT * FROM task_comment WHERE task_id = $1 ORDER BY created_at;
+
+-- name: TaskCommentById :one
+SELECT * FROM task_comment WHERE id = $1 AND task_id = $2;
+
+-- name: UpdateTaskComment :execrows
+UPDATE task_comment SET
+  body = $2,
+  updated_at = $3
+WHERE
+  id = $1;
+
+-- name: DeleteTaskComment :execrows
+DELETE FROM task_comment WHERE id = $1;
+
+-- name: InsertTaskAttachment :exec
+INSERT INTO task_attachment
+  (id, task_id, name, content_type, size, uploader, created_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: TaskAttachments :many
+SELECT * FROM task_attachment
+WHERE task_id = ANY(@task_ids::uuid[])
+ORDER BY created_at;
+
+-- name: TaskAttachmentById :one
+SELECT * FROM task_attachment WHERE id = $1 AND task_id = $2;
+
+-- name: DeleteTaskAttachment :execrows
+DELETE FROM task_attachment WHERE id = $1 AND task_id = $2;
+
+-- name: TasksTreeAttachmentsIds :many
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY(@task_ids::uuid[])
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id
+)
+SELECT task_attachment.id FROM task_attachment
+WHERE task_attachment.task_id IN (SELECT id FROM tree);
+
+-- name: OverdueTasksIds :many
+SELECT id FROM task
+WHERE
+  status != @done_status AND task_deadline(due_date, due_time, time_zone) < @due_before::timestamp AND deleted_at IS NULL AND archived_at IS NULL AND
+  (cardinality(@priorities::text[]) = 0 OR priority::text = ANY(@priorities::text[])) AND
+  (NOT @skip_with_comments::boolean OR NOT EXISTS (SELECT 1 FROM task_comment WHERE task_comment.task_id = task.id)) AND
+  (NOT @skip_with_labels::boolean OR NOT EXISTS (SELECT 1 FROM task_label WHERE task_label.task_id = task.id));
+
+-- name: InsertTaskReminder :exec
+INSERT INTO task_reminder
+  (id, task_id, login, before_seconds, created_at)
+VALUES
+  ($1, $2, $3, $4, $5);
+
+-- name: TaskReminders :many
+SELECT * FROM task_reminder
+WHERE task_id = $1 AND login = $2
+ORDER BY before_seconds DESC;
+
+-- name: DeleteTaskReminder :execrows
+DELETE FROM task_reminder WHERE id = $1 AND task_id = $2 AND login = $3;
+
+-- name: DueTaskReminders :many
+SELECT task_reminder.id, task_reminder.login, task_reminder.before_seconds, task.id AS task_id, task.title, task.due_date,
+  task_due_at(task.due_date, task.due_time, task.time_zone) AS due_at
+FROM task_reminder
+JOIN task ON task.id = task_reminder.task_id
+WHERE
+  task.status != @done_status AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  task_reminder.sent_for IS DISTINCT FROM task_due_at(task.due_date, task.due_time, task.time_zone) AND
+  task_due_at(task.due_date, task.due_time, task.time_zone) - make_interval(secs => task_reminder.before_seconds) <= @now::timestamp AND
+  (task.owner = task_reminder.login OR task.assignee = task_reminder.login OR task.project_id IN (SELECT project_id FROM project_member WHERE login = task_reminder.login))
+ORDER BY due_at;
+
+-- name: MarkTaskReminderSent :exec
+UPDATE task_reminder SET sent_for = $2 WHERE id = $1;
+
+-- name: TasksByIds :many
+SELECT * FROM task WHERE id = ANY(@task_ids::uuid[]);
+
+-- name: TasksTree :many
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY(@task_ids::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id WHERE task.deleted_at IS NULL AND task.archived_at IS NULL
+)
+SELECT task.* FROM task WHERE task.id IN (SELECT id FROM tree);
+
+-- name: InsertTaskEvent :exec
+INSERT INTO task_event
+  (id, task_id, actor, kind, changes, created_at, reason)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: TaskEvents :many
+SELECT * FROM task_event WHERE task_id = $1 ORDER BY created_at, id;
+
+-- name: TaskVersion :one
+SELECT version FROM task WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NULL FOR UPDATE;
+
+-- name: InsertTaskView :exec
+INSERT INTO task_view
+  (id, name, owner, filter, sort, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: TaskViewById :one
+SELECT * FROM task_view WHERE id = $1 AND owner = $2;
+
+-- name: TaskViewsByOwner :many
+SELECT * FROM task_view WHERE owner = $1 ORDER BY name;
+
+-- name: UpdateTaskView :execrows
+UPDATE task_view SET
+  name = $3,
+  filter = $4,
+  sort = $5,
+  updated_at = $6
+WHERE
+  id = $1 AND owner = $2;
+
+-- name: DeleteTaskView :execrows
+DELETE FROM task_view WHERE id = $1 AND owner = $2;
@@ -0,0 +1,39 @@
+package fiber_adapter
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// UserLogin extracts the login (`sub` claim) from the token
+// stored by the jwt middleware.
+func UserLogin(c *fiber.Ctx) (string, error) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return "", fiber.ErrUnauthorized
+	}
+	login, err := token.Claims.GetSubject()
+	if err != nil || login == "" {
+		return "", fiber.ErrUnauthorized
+	}
+	return login, nil
+}
+
+// RequireLogins allows requests only from the users with the given
+// logins, it should be used after the jwt middleware.
+func RequireLogins(logins []string) fiber.Handler {
+	allowed := make(map[string]struct{}, len(logins))
+	for _, l := range logins {
+		allowed[l] = struct{}{}
+	}
+	return func(c *fiber.Ctx) error {
+		login, err := UserLogin(c)
+		if err != nil {
+			return err
+		}
+		if _, ok := allowed[login]; !ok {
+			return fiber.ErrForbidden
+		}
+		return c.Next()
+	}
+}
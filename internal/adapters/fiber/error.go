@@ -1,6 +1,8 @@
 package fiber_adapter
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/x0k/skillrock-tasks-service/internal/shared"
 )
@@ -13,6 +15,12 @@ func BadRequest(err error) error {
 }
 
 func ServiceError(err *shared.ServiceError) error {
+	if errors.Is(err.Err, shared.ErrForbidden) {
+		return &fiber.Error{
+			Code:    fiber.StatusForbidden,
+			Message: err.Msg,
+		}
+	}
 	if err.Expected {
 		return &fiber.Error{
 			Code:    fiber.StatusBadRequest,
@@ -53,18 +53,25 @@ type ReportDTO struct {
 	AverageCompletionTimeInDays string `json:"average_completion_time_in_days"`
 	AmountOfCompletedTasks      int64  `json:"amount_of_completed_tasks"`
 	AmountOfOverdueTasks        int64  `json:"amount_of_overdue_tasks"`
+	// TasksCountByStatus includes statuses of the configured workflow
+	TasksCountByStatus map[string]int64 `json:"tasks_count_by_status"`
 }
 
 const dayInSeconds = 24 * 60 * 60
 
 func reportToDTO(r Report) ReportDTO {
+	countByStatus := make(map[string]int64, len(r.TasksCountByStatus))
+	for status, count := range r.TasksCountByStatus {
+		countByStatus[status.String()] = count
+	}
 	return ReportDTO{
 		PendingTasksCount:           r.TasksCountByStatus[tasks.Pending],
 		InProgressTasksCount:        r.TasksCountByStatus[tasks.InProgress],
 		DoneTasksCount:              r.TasksCountByStatus[tasks.Done],
 		AverageCompletionTimeInDays: fmt.Sprintf("%.2f", r.AverageTaskCompletionTime/dayInSeconds),
 		AmountOfCompletedTasks:      r.AmountOfCompletedTasks,
-		AmountOfOverdueTasks:        r.AmountOfCompletedTasks,
+		AmountOfOverdueTasks:        r.AmountOfOverdueTasks,
+		TasksCountByStatus:          countByStatus,
 	}
 }
 
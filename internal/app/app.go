@@ -18,14 +18,21 @@ import (
 	"github.com/redis/go-redis/v9"
 	slogfiber "github.com/samber/slog-fiber"
 
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
 	"github.com/x0k/skillrock-tasks-service/internal/analytics"
 	"github.com/x0k/skillrock-tasks-service/internal/auth"
+	"github.com/x0k/skillrock-tasks-service/internal/comments"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/blob"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/migrator"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+	"github.com/x0k/skillrock-tasks-service/internal/reminders"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 	tasks_controller "github.com/x0k/skillrock-tasks-service/internal/tasks/controller"
+	"github.com/x0k/skillrock-tasks-service/internal/views"
 
 	// migration tools
 	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
@@ -68,39 +75,142 @@ func Run(ctx context.Context, cfg *Config, log *logger.Logger) error {
 	app.Use(slogfiber.New(log.Logger))
 	app.Use(recover.New())
 
+	usersRepo := auth.NewRepo(
+		log.With(sl.Component("auth_repo")),
+		queries,
+	)
+	authService := auth.NewService(
+		log.With(sl.Component("auth_service")),
+		[]byte(cfg.Auth.Secret),
+		cfg.Auth.TokenLifetime,
+		usersRepo,
+	)
 	auth.NewController(
 		app.Group("/auth"),
 		log.With(sl.Component("auth_controller")),
-		auth.NewService(
-			log.With(sl.Component("auth_service")),
-			[]byte(cfg.Auth.Secret),
-			cfg.Auth.TokenLifetime,
-			auth.NewRepo(
-				log.With(sl.Component("auth_repo")),
-				queries,
-			),
-		),
+		authService,
 	)
 
 	authMiddleware := jwtware.New(jwtware.Config{
 		SigningKey: jwtware.SigningKey{Key: []byte(cfg.Auth.Secret)},
 	})
 
+	auth.NewProfileController(
+		app.Group("/users").Use(authMiddleware),
+		log.With(sl.Component("profile_controller")),
+		authService,
+	)
+
+	projectsRepo := projects.NewRepo(
+		log.With(sl.Component("projects_repo")),
+		pgxPool,
+		queries,
+	)
+	projects.NewController(
+		app.Group("/projects").Use(authMiddleware),
+		log.With(sl.Component("projects_controller")),
+		projects.NewService(
+			log.With(sl.Component("projects_service")),
+			projectsRepo,
+		),
+	)
+
+	labels.NewController(
+		app.Group("/labels").Use(authMiddleware),
+		log.With(sl.Component("labels_controller")),
+		labels.NewService(
+			log.With(sl.Component("labels_service")),
+			labels.NewRepo(
+				log.With(sl.Component("labels_repo")),
+				queries,
+			),
+		),
+	)
+
+	workflow, err := newWorkflow(cfg.Workflow)
+	if err != nil {
+		return err
+	}
+	prunePolicy, err := newPrunePolicy(cfg.Prune)
+	if err != nil {
+		return err
+	}
 	tasksRepo := tasks.NewRepo(
 		log.With(sl.Component("tasks_repo")),
 		pgxPool,
 		queries,
 	)
+	tasksService := tasks.NewService(
+		log.With(sl.Component("tasks_service")),
+		tasksRepo,
+		projectsRepo,
+		usersRepo,
+		blob.NewFsStore(cfg.BlobStore.Path),
+		workflow,
+		prunePolicy,
+		cfg.Trash.Retention,
+	)
 	tasksGroup := app.Group("/tasks").Use(authMiddleware)
 	tasksController := tasks_controller.New(
 		tasksGroup,
 		log.With(sl.Component("tasks_controller")),
-		tasks.NewService(
-			log.With(sl.Component("tasks_service")),
+		tasksService,
+	)
+	tasks_controller.NewAdmin(
+		app.Group("/admin/tasks").Use(authMiddleware, fiber_adapter.RequireLogins(cfg.Admin.Logins)),
+		log.With(sl.Component("tasks_admin_controller")),
+		tasksService,
+	)
+
+	views.NewController(
+		app.Group("/views").Use(authMiddleware),
+		log.With(sl.Component("views_controller")),
+		views.NewService(
+			log.With(sl.Component("views_service")),
+			views.NewRepo(
+				log.With(sl.Component("views_repo")),
+				queries,
+			),
+			tasksService,
+		),
+	)
+
+	comments.NewController(
+		tasksGroup,
+		log.With(sl.Component("comments_controller")),
+		comments.NewService(
+			log.With(sl.Component("comments_service")),
+			comments.NewRepo(
+				log.With(sl.Component("comments_repo")),
+				queries,
+			),
 			tasksRepo,
 		),
 	)
 
+	var notifier reminders.Notifier = reminders.NewLogNotifier(
+		log.With(sl.Component("reminders_notifier")),
+	)
+	if cfg.Reminders.WebhookURL != "" {
+		notifier = reminders.NewWebhookNotifier(
+			&http.Client{Timeout: cfg.Reminders.WebhookTimeout},
+			cfg.Reminders.WebhookURL,
+		)
+	}
+	remindersController := reminders.NewController(
+		tasksGroup,
+		log.With(sl.Component("reminders_controller")),
+		reminders.NewService(
+			log.With(sl.Component("reminders_service")),
+			reminders.NewRepo(
+				log.With(sl.Component("reminders_repo")),
+				queries,
+			),
+			tasksRepo,
+			notifier,
+		),
+	)
+
 	analyticsGroup := app.Group("/analytics").Use(authMiddleware)
 	analyticsController := analytics.NewController(
 		analyticsGroup,
@@ -117,6 +227,23 @@ func Run(ctx context.Context, cfg *Config, log *logger.Logger) error {
 
 	var wg sync.WaitGroup
 
+	if cfg.Prune.Interval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(cfg.Prune.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					tasksController.PruneOverdueTasks(ctx)
+				}
+			}
+		}()
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -127,7 +254,7 @@ func Run(ctx context.Context, cfg *Config, log *logger.Logger) error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				tasksController.PruneOverdueTasks(ctx)
+				tasksController.PurgeDeletedTasks(ctx)
 			}
 		}
 	}()
@@ -147,6 +274,23 @@ func Run(ctx context.Context, cfg *Config, log *logger.Logger) error {
 		}
 	}()
 
+	if cfg.Reminders.Interval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(cfg.Reminders.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					remindersController.SendDueReminders(ctx)
+				}
+			}
+		}()
+	}
+
 	context.AfterFunc(ctx, func() {
 		if err := app.Shutdown(); err != nil {
 			log.Error(ctx, "shutdown failed", sl.Err(err))
@@ -185,3 +329,41 @@ func Run(ctx context.Context, cfg *Config, log *logger.Logger) error {
 	wg.Wait()
 	return err
 }
+
+func newPrunePolicy(cfg PruneConfig) (tasks.PrunePolicy, error) {
+	priorities := make([]tasks.Priority, len(cfg.Priorities))
+	for i, p := range cfg.Priorities {
+		priorities[i] = tasks.Priority(p)
+	}
+	policy, err := tasks.NewPrunePolicy(
+		tasks.PruneMode(cfg.Mode),
+		cfg.Retention,
+		priorities,
+		cfg.SkipWithComments,
+		cfg.SkipWithLabels,
+		cfg.DryRun,
+	)
+	if err != nil {
+		return policy, fmt.Errorf("failed to create prune policy: %w", err)
+	}
+	return policy, nil
+}
+
+func newWorkflow(cfg WorkflowConfig) (*tasks.Workflow, error) {
+	if len(cfg.Transitions) == 0 {
+		return tasks.DefaultWorkflow(), nil
+	}
+	transitions := make(map[tasks.Status][]tasks.Status, len(cfg.Transitions))
+	for from, targets := range cfg.Transitions {
+		to := make([]tasks.Status, len(targets))
+		for i, t := range targets {
+			to[i] = tasks.Status(t)
+		}
+		transitions[tasks.Status(from)] = to
+	}
+	workflow, err := tasks.NewWorkflow(transitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tasks workflow: %w", err)
+	}
+	return workflow, nil
+}
@@ -36,13 +36,62 @@ type MetricsConfig struct {
 	Address string `yaml:"address" env:"METRICS_ADDRESS" env-default:"0.0.0.0:9099"`
 }
 
+type BlobStoreConfig struct {
+	Path string `yaml:"path" env:"BLOB_STORE_PATH" env-default:"data/blobs"`
+}
+
+// RemindersConfig defines how often due reminders are sent,
+// the zero interval disables sending of reminders
+type RemindersConfig struct {
+	Interval       time.Duration `yaml:"interval" env:"REMINDERS_INTERVAL" env-default:"1m"`
+	WebhookURL     string        `yaml:"webhook_url" env:"REMINDERS_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" env:"REMINDERS_WEBHOOK_TIMEOUT" env-default:"10s"`
+}
+
+// PruneConfig defines which overdue tasks are pruned and how often, the zero
+// interval disables the pruning. Tasks are moved to the trash or to the
+// archive depending on the mode. Tasks of any priority are pruned when
+// the priorities are not specified.
+type PruneConfig struct {
+	Mode             string        `yaml:"mode" env:"PRUNE_MODE" env-default:"trash"`
+	Interval         time.Duration `yaml:"interval" env:"PRUNE_INTERVAL" env-default:"24h"`
+	Retention        time.Duration `yaml:"retention" env:"PRUNE_RETENTION" env-default:"168h"`
+	Priorities       []string      `yaml:"priorities" env:"PRUNE_PRIORITIES" env-separator:","`
+	SkipWithComments bool          `yaml:"skip_with_comments" env:"PRUNE_SKIP_WITH_COMMENTS"`
+	SkipWithLabels   bool          `yaml:"skip_with_labels" env:"PRUNE_SKIP_WITH_LABELS"`
+	DryRun           bool          `yaml:"dry_run" env:"PRUNE_DRY_RUN"`
+}
+
+// AdminConfig lists logins of the users allowed to use the admin endpoints
+type AdminConfig struct {
+	Logins []string `yaml:"logins" env:"ADMIN_LOGINS" env-separator:","`
+}
+
+// TrashConfig controls how long deleted tasks can be restored
+// before they are purged permanently
+type TrashConfig struct {
+	Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION" env-default:"720h"`
+}
+
+// WorkflowConfig maps task statuses to the statuses they can be changed to,
+// the default workflow is used when transitions are not specified
+type WorkflowConfig struct {
+	Transitions map[string][]string `yaml:"transitions"`
+}
+
 type Config struct {
-	Logger   LoggerConfig  `yaml:"logger"`
-	Postgres PgConfig      `yaml:"postgres"`
-	Redis    RedisConfig   `yaml:"redis"`
-	Server   ServerConfig  `yaml:"server"`
-	Auth     AuthConfig    `yaml:"auth"`
-	Metrics  MetricsConfig `yaml:"metrics"`
+	Logger    LoggerConfig    `yaml:"logger"`
+	Postgres  PgConfig        `yaml:"postgres"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Server    ServerConfig    `yaml:"server"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	BlobStore BlobStoreConfig `yaml:"blob_store"`
+	Reminders RemindersConfig `yaml:"reminders"`
+	Prune     PruneConfig     `yaml:"prune"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Admin     AdminConfig     `yaml:"admin"`
+	Workflow  WorkflowConfig  `yaml:"workflow"`
 }
 
 func MustLoadConfig(configPath string) *Config {
@@ -1,6 +1,10 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/tz"
+)
 
 var ErrLoginIsTaken = errors.New("this login is already taken")
 var ErrUserNotFound = errors.New("user not found")
@@ -9,11 +13,18 @@ var ErrPasswordsMismatch = errors.New("passwords mismatch")
 type User struct {
 	Login        string
 	PasswordHash []byte
+	// TimeZone is the IANA time zone applied to the new tasks of the user
+	// when they are created without a time zone
+	TimeZone string
 }
 
 func NewUser(
 	login string,
 	passwordHash []byte,
 ) *User {
-	return &User{login, passwordHash}
+	return &User{
+		Login:        login,
+		PasswordHash: passwordHash,
+		TimeZone:     tz.Default,
+	}
 }
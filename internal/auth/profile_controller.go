@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+type ProfileService interface {
+	User(ctx context.Context, login string) (*User, *shared.ServiceError)
+	UpdateTimeZone(ctx context.Context, login string, timeZone string) *shared.ServiceError
+}
+
+// ProfileController serves the settings of the authenticated user
+type ProfileController struct {
+	log            *logger.Logger
+	profileService ProfileService
+}
+
+func NewProfileController(
+	router fiber.Router,
+	log *logger.Logger,
+	service ProfileService,
+) *ProfileController {
+	c := &ProfileController{log, service}
+	router.Get("/me", c.me)
+	router.Put("/me", c.updateMe)
+	return c
+}
+
+type ProfileDTO struct {
+	Login    string `json:"login"`
+	TimeZone string `json:"time_zone"`
+}
+
+type UpdateProfileDTO struct {
+	TimeZone string `json:"time_zone" validate:"required"`
+}
+
+func (pc *ProfileController) me(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	user, sErr := pc.profileService.User(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrUserNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(ProfileDTO{
+		Login:    user.Login,
+		TimeZone: user.TimeZone,
+	})
+}
+
+func (pc *ProfileController) updateMe(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	var dto UpdateProfileDTO
+	if err := c.BodyParser(&dto); err != nil {
+		pc.log.Debug(c.Context(), "failed to decode body")
+		return err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		pc.log.Debug(c.Context(), "invalid update profile dto struct", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	if sErr := pc.profileService.UpdateTimeZone(c.Context(), login, dto.TimeZone); sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrUserNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (pc *ProfileController) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		pc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
@@ -41,5 +41,21 @@ func (r *Repo) UserByLogin(ctx context.Context, login string) (*User, error) {
 		}
 		return nil, err
 	}
-	return NewUser(login, u.PasswordHash), nil
+	user := NewUser(login, u.PasswordHash)
+	user.TimeZone = u.TimeZone
+	return user, nil
+}
+
+func (r *Repo) UpdateUserTimeZone(ctx context.Context, login string, timeZone string) error {
+	rows, err := r.queries.UpdateUserTimeZone(ctx, db.UpdateUserTimeZoneParams{
+		Login:    login,
+		TimeZone: timeZone,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/tz"
 	"github.com/x0k/skillrock-tasks-service/internal/shared"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -15,6 +16,7 @@ import (
 type UsersRepo interface {
 	SaveUser(ctx context.Context, user *User) error
 	UserByLogin(ctx context.Context, login string) (*User, error)
+	UpdateUserTimeZone(ctx context.Context, login string, timeZone string) error
 }
 
 type Service struct {
@@ -60,6 +62,32 @@ func (s *Service) Login(ctx context.Context, login string, password string) (str
 	return s.issueAccessToken(login)
 }
 
+func (s *Service) User(ctx context.Context, login string) (*User, *shared.ServiceError) {
+	user, err := s.repo.UserByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, shared.NewServiceError(err, "user not found")
+		}
+		return nil, shared.NewUnexpectedError(err, "failed to find user")
+	}
+	return user, nil
+}
+
+// UpdateTimeZone changes the default time zone of the user,
+// existing tasks keep their time zones
+func (s *Service) UpdateTimeZone(ctx context.Context, login string, timeZone string) *shared.ServiceError {
+	if err := tz.Validate(timeZone); err != nil {
+		return shared.NewServiceError(err, fmt.Sprintf("unknown time zone %q", timeZone))
+	}
+	if err := s.repo.UpdateUserTimeZone(ctx, login, timeZone); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return shared.NewServiceError(err, "user not found")
+		}
+		return shared.NewUnexpectedError(err, "failed to update time zone")
+	}
+	return nil
+}
+
 func (s *Service) issueAccessToken(login string) (string, *shared.ServiceError) {
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub": login,
@@ -0,0 +1,207 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type CommentsService interface {
+	Comments(ctx context.Context, login string, taskId tasks.TaskId) ([]Comment, *shared.ServiceError)
+	CreateComment(ctx context.Context, author string, taskId tasks.TaskId, params CommentParams) (Comment, *shared.ServiceError)
+	UpdateCommentById(ctx context.Context, login string, taskId tasks.TaskId, id CommentId, params CommentParams) *shared.ServiceError
+	RemoveCommentById(ctx context.Context, login string, taskId tasks.TaskId, id CommentId) *shared.ServiceError
+}
+
+type Controller struct {
+	log             *logger.Logger
+	commentsService CommentsService
+}
+
+// NewController registers comments routes on the tasks router
+func NewController(
+	router fiber.Router,
+	log *logger.Logger,
+	commentsService CommentsService,
+) *Controller {
+	c := &Controller{log, commentsService}
+	router.Get("/:id/comments", c.comments)
+	router.Post("/:id/comments", c.createComment)
+	router.Put("/:id/comments/:comment_id", c.updateCommentById)
+	router.Delete("/:id/comments/:comment_id", c.removeCommentById)
+	return c
+}
+
+type CreateCommentDTO struct {
+	Body string `json:"body" validate:"required"`
+}
+
+type CommentDTO struct {
+	Id        string `json:"id"`
+	TaskId    string `json:"task_id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func commentToDTO(c Comment) CommentDTO {
+	return CommentDTO{
+		Id:        c.Id.String(),
+		TaskId:    c.TaskId.String(),
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (cc *Controller) comments(c *fiber.Ctx) error {
+	login, err := cc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := cc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	comments, sErr := cc.commentsService.Comments(c.Context(), login, taskId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(cc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	commentsDto := make([]CommentDTO, len(comments))
+	for i, comment := range comments {
+		commentsDto[i] = commentToDTO(comment)
+	}
+	return c.JSON(commentsDto)
+}
+
+func (cc *Controller) createComment(c *fiber.Ctx) error {
+	login, err := cc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := cc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	params, err := cc.commentParams(c)
+	if err != nil {
+		return err
+	}
+	comment, sErr := cc.commentsService.CreateComment(c.Context(), login, taskId, params)
+	if sErr != nil {
+		logger_adapter.LogServiceError(cc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(commentToDTO(comment))
+}
+
+func (cc *Controller) updateCommentById(c *fiber.Ctx) error {
+	login, err := cc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := cc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	commentId, err := cc.commentId(c, c.Params("comment_id"))
+	if err != nil {
+		return err
+	}
+	params, err := cc.commentParams(c)
+	if err != nil {
+		return err
+	}
+	if sErr := cc.commentsService.UpdateCommentById(c.Context(), login, taskId, commentId, params); sErr != nil {
+		logger_adapter.LogServiceError(cc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) || errors.Is(sErr.Err, ErrCommentNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (cc *Controller) removeCommentById(c *fiber.Ctx) error {
+	login, err := cc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := cc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	commentId, err := cc.commentId(c, c.Params("comment_id"))
+	if err != nil {
+		return err
+	}
+	if sErr := cc.commentsService.RemoveCommentById(c.Context(), login, taskId, commentId); sErr != nil {
+		logger_adapter.LogServiceError(cc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) || errors.Is(sErr.Err, ErrCommentNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (cc *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		cc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
+func (cc *Controller) commentParams(c *fiber.Ctx) (CommentParams, error) {
+	var dto CreateCommentDTO
+	if err := c.BodyParser(&dto); err != nil {
+		cc.log.Debug(c.Context(), "failed to decode body")
+		return CommentParams{}, err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		cc.log.Debug(c.Context(), "invalid create comment dto struct", sl.Err(err))
+		return CommentParams{}, fiber_adapter.BadRequest(err)
+	}
+	return CommentParams{
+		Body: dto.Body,
+	}, nil
+}
+
+func (cc *Controller) taskId(c *fiber.Ctx, value string) (tasks.TaskId, error) {
+	taskId, err := tasks.ParseTaskId(value)
+	if err != nil {
+		cc.log.Debug(c.Context(), "invalid task id value", slog.String("task_id", value))
+		return taskId, fiber_adapter.BadRequest(err)
+	}
+	return taskId, nil
+}
+
+func (cc *Controller) commentId(c *fiber.Ctx, value string) (CommentId, error) {
+	commentId, err := ParseCommentId(value)
+	if err != nil {
+		cc.log.Debug(c.Context(), "invalid comment id value", slog.String("comment_id", value))
+		return commentId, fiber_adapter.BadRequest(err)
+	}
+	return commentId, nil
+}
@@ -0,0 +1,299 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package comments
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	tasks "github.com/x0k/skillrock-tasks-service/internal/tasks"
+
+	time "time"
+)
+
+// MockCommentsRepo is an autogenerated mock type for the CommentsRepo type
+type MockCommentsRepo struct {
+	mock.Mock
+}
+
+type MockCommentsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommentsRepo) EXPECT() *MockCommentsRepo_Expecter {
+	return &MockCommentsRepo_Expecter{mock: &_m.Mock}
+}
+
+// CommentById provides a mock function with given fields: ctx, taskId, id
+func (_m *MockCommentsRepo) CommentById(ctx context.Context, taskId tasks.TaskId, id CommentId) (Comment, error) {
+	ret := _m.Called(ctx, taskId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommentById")
+	}
+
+	var r0 Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tasks.TaskId, CommentId) (Comment, error)); ok {
+		return rf(ctx, taskId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tasks.TaskId, CommentId) Comment); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		r0 = ret.Get(0).(Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tasks.TaskId, CommentId) error); ok {
+		r1 = rf(ctx, taskId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCommentsRepo_CommentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommentById'
+type MockCommentsRepo_CommentById_Call struct {
+	*mock.Call
+}
+
+// CommentById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId tasks.TaskId
+//   - id CommentId
+func (_e *MockCommentsRepo_Expecter) CommentById(ctx interface{}, taskId interface{}, id interface{}) *MockCommentsRepo_CommentById_Call {
+	return &MockCommentsRepo_CommentById_Call{Call: _e.mock.On("CommentById", ctx, taskId, id)}
+}
+
+func (_c *MockCommentsRepo_CommentById_Call) Run(run func(ctx context.Context, taskId tasks.TaskId, id CommentId)) *MockCommentsRepo_CommentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tasks.TaskId), args[2].(CommentId))
+	})
+	return _c
+}
+
+func (_c *MockCommentsRepo_CommentById_Call) Return(_a0 Comment, _a1 error) *MockCommentsRepo_CommentById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCommentsRepo_CommentById_Call) RunAndReturn(run func(context.Context, tasks.TaskId, CommentId) (Comment, error)) *MockCommentsRepo_CommentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommentsByTask provides a mock function with given fields: ctx, taskId
+func (_m *MockCommentsRepo) CommentsByTask(ctx context.Context, taskId tasks.TaskId) ([]Comment, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommentsByTask")
+	}
+
+	var r0 []Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tasks.TaskId) ([]Comment, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tasks.TaskId) []Comment); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tasks.TaskId) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCommentsRepo_CommentsByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommentsByTask'
+type MockCommentsRepo_CommentsByTask_Call struct {
+	*mock.Call
+}
+
+// CommentsByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId tasks.TaskId
+func (_e *MockCommentsRepo_Expecter) CommentsByTask(ctx interface{}, taskId interface{}) *MockCommentsRepo_CommentsByTask_Call {
+	return &MockCommentsRepo_CommentsByTask_Call{Call: _e.mock.On("CommentsByTask", ctx, taskId)}
+}
+
+func (_c *MockCommentsRepo_CommentsByTask_Call) Run(run func(ctx context.Context, taskId tasks.TaskId)) *MockCommentsRepo_CommentsByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tasks.TaskId))
+	})
+	return _c
+}
+
+func (_c *MockCommentsRepo_CommentsByTask_Call) Return(_a0 []Comment, _a1 error) *MockCommentsRepo_CommentsByTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCommentsRepo_CommentsByTask_Call) RunAndReturn(run func(context.Context, tasks.TaskId) ([]Comment, error)) *MockCommentsRepo_CommentsByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveCommentById provides a mock function with given fields: ctx, id
+func (_m *MockCommentsRepo) RemoveCommentById(ctx context.Context, id CommentId) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCommentById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, CommentId) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCommentsRepo_RemoveCommentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveCommentById'
+type MockCommentsRepo_RemoveCommentById_Call struct {
+	*mock.Call
+}
+
+// RemoveCommentById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id CommentId
+func (_e *MockCommentsRepo_Expecter) RemoveCommentById(ctx interface{}, id interface{}) *MockCommentsRepo_RemoveCommentById_Call {
+	return &MockCommentsRepo_RemoveCommentById_Call{Call: _e.mock.On("RemoveCommentById", ctx, id)}
+}
+
+func (_c *MockCommentsRepo_RemoveCommentById_Call) Run(run func(ctx context.Context, id CommentId)) *MockCommentsRepo_RemoveCommentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(CommentId))
+	})
+	return _c
+}
+
+func (_c *MockCommentsRepo_RemoveCommentById_Call) Return(_a0 error) *MockCommentsRepo_RemoveCommentById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCommentsRepo_RemoveCommentById_Call) RunAndReturn(run func(context.Context, CommentId) error) *MockCommentsRepo_RemoveCommentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveComment provides a mock function with given fields: ctx, comment
+func (_m *MockCommentsRepo) SaveComment(ctx context.Context, comment Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCommentsRepo_SaveComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveComment'
+type MockCommentsRepo_SaveComment_Call struct {
+	*mock.Call
+}
+
+// SaveComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment Comment
+func (_e *MockCommentsRepo_Expecter) SaveComment(ctx interface{}, comment interface{}) *MockCommentsRepo_SaveComment_Call {
+	return &MockCommentsRepo_SaveComment_Call{Call: _e.mock.On("SaveComment", ctx, comment)}
+}
+
+func (_c *MockCommentsRepo_SaveComment_Call) Run(run func(ctx context.Context, comment Comment)) *MockCommentsRepo_SaveComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Comment))
+	})
+	return _c
+}
+
+func (_c *MockCommentsRepo_SaveComment_Call) Return(_a0 error) *MockCommentsRepo_SaveComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCommentsRepo_SaveComment_Call) RunAndReturn(run func(context.Context, Comment) error) *MockCommentsRepo_SaveComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCommentById provides a mock function with given fields: ctx, id, params, updatedAt
+func (_m *MockCommentsRepo) UpdateCommentById(ctx context.Context, id CommentId, params CommentParams, updatedAt time.Time) error {
+	ret := _m.Called(ctx, id, params, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommentById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, CommentId, CommentParams, time.Time) error); ok {
+		r0 = rf(ctx, id, params, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCommentsRepo_UpdateCommentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentById'
+type MockCommentsRepo_UpdateCommentById_Call struct {
+	*mock.Call
+}
+
+// UpdateCommentById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id CommentId
+//   - params CommentParams
+//   - updatedAt time.Time
+func (_e *MockCommentsRepo_Expecter) UpdateCommentById(ctx interface{}, id interface{}, params interface{}, updatedAt interface{}) *MockCommentsRepo_UpdateCommentById_Call {
+	return &MockCommentsRepo_UpdateCommentById_Call{Call: _e.mock.On("UpdateCommentById", ctx, id, params, updatedAt)}
+}
+
+func (_c *MockCommentsRepo_UpdateCommentById_Call) Run(run func(ctx context.Context, id CommentId, params CommentParams, updatedAt time.Time)) *MockCommentsRepo_UpdateCommentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(CommentId), args[2].(CommentParams), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockCommentsRepo_UpdateCommentById_Call) Return(_a0 error) *MockCommentsRepo_UpdateCommentById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCommentsRepo_UpdateCommentById_Call) RunAndReturn(run func(context.Context, CommentId, CommentParams, time.Time) error) *MockCommentsRepo_UpdateCommentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCommentsRepo creates a new instance of MockCommentsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommentsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommentsRepo {
+	mock := &MockCommentsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package comments
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	tasks "github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+// MockTasksRepo is an autogenerated mock type for the TasksRepo type
+type MockTasksRepo struct {
+	mock.Mock
+}
+
+type MockTasksRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTasksRepo) EXPECT() *MockTasksRepo_Expecter {
+	return &MockTasksRepo_Expecter{mock: &_m.Mock}
+}
+
+// TaskById provides a mock function with given fields: ctx, login, id
+func (_m *MockTasksRepo) TaskById(ctx context.Context, login string, id tasks.TaskId) (tasks.Task, error) {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskById")
+	}
+
+	var r0 tasks.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TaskId) (tasks.Task, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TaskId) tasks.Task); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Get(0).(tasks.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, tasks.TaskId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_TaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskById'
+type MockTasksRepo_TaskById_Call struct {
+	*mock.Call
+}
+
+// TaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id tasks.TaskId
+func (_e *MockTasksRepo_Expecter) TaskById(ctx interface{}, login interface{}, id interface{}) *MockTasksRepo_TaskById_Call {
+	return &MockTasksRepo_TaskById_Call{Call: _e.mock.On("TaskById", ctx, login, id)}
+}
+
+func (_c *MockTasksRepo_TaskById_Call) Run(run func(ctx context.Context, login string, id tasks.TaskId)) *MockTasksRepo_TaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(tasks.TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskById_Call) Return(_a0 tasks.Task, _a1 error) *MockTasksRepo_TaskById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskById_Call) RunAndReturn(run func(context.Context, string, tasks.TaskId) (tasks.Task, error)) *MockTasksRepo_TaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTasksRepo creates a new instance of MockTasksRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTasksRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTasksRepo {
+	mock := &MockTasksRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,64 @@
+package comments
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+var ErrInvalidCommentBody = errors.New("invalid comment body")
+
+type CommentId uuid.UUID
+
+func (id CommentId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewCommentId() CommentId {
+	return CommentId(uuid.New())
+}
+
+func ParseCommentId(id string) (CommentId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return CommentId(uuid.Nil), err
+	}
+	return CommentId(uid), nil
+}
+
+type Comment struct {
+	Id        CommentId
+	TaskId    tasks.TaskId
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CommentParams struct {
+	Body string
+}
+
+func NewComment(
+	commentId CommentId,
+	taskId tasks.TaskId,
+	author string,
+	body string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (Comment, error) {
+	if len(body) == 0 {
+		return Comment{}, ErrInvalidCommentBody
+	}
+	return Comment{
+		Id:        commentId,
+		TaskId:    taskId,
+		Author:    author,
+		Body:      body,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type Repo struct {
+	log     *logger.Logger
+	queries *db.Queries
+}
+
+func NewRepo(
+	log *logger.Logger,
+	queries *db.Queries,
+) *Repo {
+	return &Repo{log, queries}
+}
+
+func (r *Repo) SaveComment(ctx context.Context, comment Comment) error {
+	return r.queries.InsertTaskComment(ctx, db.InsertTaskCommentParams{
+		ID:     r.commentIdToPg(comment.Id),
+		TaskID: r.taskIdToPg(comment.TaskId),
+		Author: comment.Author,
+		Body:   comment.Body,
+		CreatedAt: pgtype.Timestamp{
+			Time:  comment.CreatedAt.UTC(),
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamp{
+			Time:  comment.UpdatedAt.UTC(),
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) CommentsByTask(ctx context.Context, taskId tasks.TaskId) ([]Comment, error) {
+	rows, err := r.queries.TaskComments(ctx, r.taskIdToPg(taskId))
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]Comment, len(rows))
+	for i, row := range rows {
+		if comments[i], err = r.commentFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	return comments, nil
+}
+
+func (r *Repo) CommentById(ctx context.Context, taskId tasks.TaskId, id CommentId) (Comment, error) {
+	row, err := r.queries.TaskCommentById(ctx, db.TaskCommentByIdParams{
+		ID:     r.commentIdToPg(id),
+		TaskID: r.taskIdToPg(taskId),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Comment{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return Comment{}, err
+	}
+	return r.commentFromPg(row)
+}
+
+func (r *Repo) UpdateCommentById(ctx context.Context, id CommentId, params CommentParams, updatedAt time.Time) error {
+	rowsAffected, err := r.queries.UpdateTaskComment(ctx, db.UpdateTaskCommentParams{
+		ID:   r.commentIdToPg(id),
+		Body: params.Body,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  updatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+func (r *Repo) RemoveCommentById(ctx context.Context, id CommentId) error {
+	rowsAffected, err := r.queries.DeleteTaskComment(ctx, r.commentIdToPg(id))
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+func (r *Repo) commentFromPg(row db.TaskComment) (Comment, error) {
+	return NewComment(
+		row.ID.Bytes,
+		row.TaskID.Bytes,
+		row.Author,
+		row.Body,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func (r *Repo) commentIdToPg(id CommentId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func (r *Repo) taskIdToPg(id tasks.TaskId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
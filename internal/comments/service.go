@@ -0,0 +1,144 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type CommentsRepo interface {
+	SaveComment(ctx context.Context, comment Comment) error
+	CommentsByTask(ctx context.Context, taskId tasks.TaskId) ([]Comment, error)
+	CommentById(ctx context.Context, taskId tasks.TaskId, id CommentId) (Comment, error)
+	UpdateCommentById(ctx context.Context, id CommentId, params CommentParams, updatedAt time.Time) error
+	RemoveCommentById(ctx context.Context, id CommentId) error
+}
+
+type TasksRepo interface {
+	TaskById(ctx context.Context, login string, id tasks.TaskId) (tasks.Task, error)
+}
+
+type Service struct {
+	log          *logger.Logger
+	commentsRepo CommentsRepo
+	tasksRepo    TasksRepo
+}
+
+func NewService(
+	log *logger.Logger,
+	commentsRepo CommentsRepo,
+	tasksRepo TasksRepo,
+) *Service {
+	return &Service{log, commentsRepo, tasksRepo}
+}
+
+func (s *Service) Comments(ctx context.Context, login string, taskId tasks.TaskId) ([]Comment, *shared.ServiceError) {
+	if err := s.checkTask(ctx, login, taskId); err != nil {
+		return nil, err
+	}
+	comments, err := s.commentsRepo.CommentsByTask(ctx, taskId)
+	if err != nil {
+		return comments, shared.NewUnexpectedError(err, "failed to load comments")
+	}
+	return comments, nil
+}
+
+func (s *Service) CreateComment(
+	ctx context.Context,
+	author string,
+	taskId tasks.TaskId,
+	params CommentParams,
+) (Comment, *shared.ServiceError) {
+	now := time.Now()
+	comment, err := NewComment(
+		NewCommentId(),
+		taskId,
+		author,
+		params.Body,
+		now,
+		now,
+	)
+	if err != nil {
+		return comment, shared.NewServiceError(err, "failed to create comment")
+	}
+	if err := s.checkTask(ctx, author, taskId); err != nil {
+		return comment, err
+	}
+	if err := s.commentsRepo.SaveComment(ctx, comment); err != nil {
+		return comment, shared.NewUnexpectedError(err, "failed to save comment")
+	}
+	return comment, nil
+}
+
+func (s *Service) UpdateCommentById(
+	ctx context.Context,
+	login string,
+	taskId tasks.TaskId,
+	id CommentId,
+	params CommentParams,
+) *shared.ServiceError {
+	if len(params.Body) == 0 {
+		return shared.NewServiceError(ErrInvalidCommentBody, "failed to update comment")
+	}
+	if err := s.checkAuthor(ctx, login, taskId, id); err != nil {
+		return err
+	}
+	err := s.commentsRepo.UpdateCommentById(ctx, id, params, time.Now())
+	if errors.Is(err, ErrCommentNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("comment with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to update comment")
+	}
+	return nil
+}
+
+func (s *Service) RemoveCommentById(ctx context.Context, login string, taskId tasks.TaskId, id CommentId) *shared.ServiceError {
+	if err := s.checkAuthor(ctx, login, taskId, id); err != nil {
+		return err
+	}
+	err := s.commentsRepo.RemoveCommentById(ctx, id)
+	if errors.Is(err, ErrCommentNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("comment with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove comment")
+	}
+	return nil
+}
+
+// checkTask ensures that the task is visible to the user
+func (s *Service) checkTask(ctx context.Context, login string, taskId tasks.TaskId) *shared.ServiceError {
+	_, err := s.tasksRepo.TaskById(ctx, login, taskId)
+	if errors.Is(err, tasks.ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", taskId.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load task")
+	}
+	return nil
+}
+
+// checkAuthor ensures that the comment can be modified by the user,
+// only the author is allowed to edit or remove the comment.
+func (s *Service) checkAuthor(ctx context.Context, login string, taskId tasks.TaskId, id CommentId) *shared.ServiceError {
+	if err := s.checkTask(ctx, login, taskId); err != nil {
+		return err
+	}
+	comment, err := s.commentsRepo.CommentById(ctx, taskId, id)
+	if errors.Is(err, ErrCommentNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("comment with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load comment")
+	}
+	if comment.Author != login {
+		return shared.NewServiceError(shared.ErrForbidden, "only the author can modify the comment")
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package comments_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/comments"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+const author = "author"
+
+type serviceMocks struct {
+	commentsRepo *comments.MockCommentsRepo
+	tasksRepo    *comments.MockTasksRepo
+}
+
+func newTestService(t *testing.T, setup func(sm serviceMocks)) *comments.Service {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	commentsRepo := comments.NewMockCommentsRepo(t)
+	tasksRepo := comments.NewMockTasksRepo(t)
+	if setup != nil {
+		setup(serviceMocks{
+			commentsRepo: commentsRepo,
+			tasksRepo:    tasksRepo,
+		})
+	}
+	return comments.NewService(
+		log,
+		commentsRepo,
+		tasksRepo,
+	)
+}
+
+func TestServiceCreateComment(t *testing.T) {
+	taskId := tasks.NewTaskId()
+	params := comments.CommentParams{
+		Body: "body",
+	}
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *comments.Service
+		params  comments.CommentParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				commentMatcher := mock.MatchedBy(func(c comments.Comment) bool {
+					return c.Body == params.Body && c.Author == author && c.TaskId == taskId
+				})
+				sm.commentsRepo.EXPECT().SaveComment(mock.Anything, commentMatcher).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "empty body",
+			service: newTestService(t, nil),
+			params:  comments.CommentParams{},
+			err:     shared.NewServiceError(comments.ErrInvalidCommentBody, ""),
+		},
+		{
+			name: "task not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).
+					Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			params: params,
+			err:    shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().SaveComment(mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := c.service.CreateComment(t.Context(), author, taskId, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceUpdateCommentById(t *testing.T) {
+	now := time.Now()
+	taskId := tasks.NewTaskId()
+	comment, cErr := comments.NewComment(
+		comments.NewCommentId(),
+		taskId,
+		author,
+		"body",
+		now,
+		now,
+	)
+	if cErr != nil {
+		t.Fatal("failed to prepare comment")
+	}
+	params := comments.CommentParams{
+		Body: "new body",
+	}
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *comments.Service
+		login   string
+		params  comments.CommentParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).Return(comment, nil)
+				sm.commentsRepo.EXPECT().
+					UpdateCommentById(mock.Anything, comment.Id, params, mock.AnythingOfType("time.Time")).
+					Return(nil)
+			}),
+			login:  author,
+			params: params,
+		},
+		{
+			name:    "empty body",
+			service: newTestService(t, nil),
+			login:   author,
+			params:  comments.CommentParams{},
+			err:     shared.NewServiceError(comments.ErrInvalidCommentBody, ""),
+		},
+		{
+			name: "not an author",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, "other", taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).Return(comment, nil)
+			}),
+			login:  "other",
+			params: params,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "comment not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).
+					Return(comments.Comment{}, comments.ErrCommentNotFound)
+			}),
+			login:  author,
+			params: params,
+			err:    shared.NewServiceError(comments.ErrCommentNotFound, ""),
+		},
+		{
+			name: "task not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).
+					Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			login:  author,
+			params: params,
+			err:    shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).Return(comment, nil)
+				sm.commentsRepo.EXPECT().
+					UpdateCommentById(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			login:  author,
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.UpdateCommentById(t.Context(), c.login, taskId, comment.Id, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceRemoveCommentById(t *testing.T) {
+	now := time.Now()
+	taskId := tasks.NewTaskId()
+	comment, cErr := comments.NewComment(
+		comments.NewCommentId(),
+		taskId,
+		author,
+		"body",
+		now,
+		now,
+	)
+	if cErr != nil {
+		t.Fatal("failed to prepare comment")
+	}
+	cases := []struct {
+		name    string
+		service *comments.Service
+		login   string
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, author, taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).Return(comment, nil)
+				sm.commentsRepo.EXPECT().RemoveCommentById(mock.Anything, comment.Id).Return(nil)
+			}),
+			login: author,
+		},
+		{
+			name: "not an author",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, "other", taskId).Return(tasks.Task{}, nil)
+				sm.commentsRepo.EXPECT().CommentById(mock.Anything, taskId, comment.Id).Return(comment, nil)
+			}),
+			login: "other",
+			err:   shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RemoveCommentById(t.Context(), c.login, taskId, comment.Id); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+type LabelsService interface {
+	CreateLabel(ctx context.Context, owner string, params LabelParams) (Label, *shared.ServiceError)
+	Labels(ctx context.Context, owner string) ([]Label, *shared.ServiceError)
+	LabelById(ctx context.Context, owner string, id LabelId) (Label, *shared.ServiceError)
+	UpdateLabelById(ctx context.Context, owner string, id LabelId, params LabelParams) *shared.ServiceError
+	RemoveLabelById(ctx context.Context, owner string, id LabelId) *shared.ServiceError
+}
+
+type Controller struct {
+	log           *logger.Logger
+	labelsService LabelsService
+}
+
+func NewController(
+	router fiber.Router,
+	log *logger.Logger,
+	labelsService LabelsService,
+) *Controller {
+	c := &Controller{log, labelsService}
+	router.Get("/", c.labels)
+	router.Post("/", c.createLabel)
+	router.Get("/:id", c.labelById)
+	router.Put("/:id", c.updateLabelById)
+	router.Delete("/:id", c.removeLabelById)
+	return c
+}
+
+type CreateLabelDTO struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type LabelDTO struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func labelToDTO(l Label) LabelDTO {
+	return LabelDTO{
+		Id:        l.Id.String(),
+		Name:      l.Name,
+		CreatedAt: l.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (lc *Controller) labels(c *fiber.Ctx) error {
+	login, err := lc.login(c)
+	if err != nil {
+		return err
+	}
+	labels, sErr := lc.labelsService.Labels(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(lc.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	labelsDto := make([]LabelDTO, len(labels))
+	for i, l := range labels {
+		labelsDto[i] = labelToDTO(l)
+	}
+	return c.JSON(labelsDto)
+}
+
+func (lc *Controller) createLabel(c *fiber.Ctx) error {
+	login, err := lc.login(c)
+	if err != nil {
+		return err
+	}
+	params, err := lc.labelParams(c)
+	if err != nil {
+		return err
+	}
+	label, sErr := lc.labelsService.CreateLabel(c.Context(), login, params)
+	if sErr != nil {
+		logger_adapter.LogServiceError(lc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrLabelNameConflict) {
+			return fiber_adapter.SpecificServiceError(sErr, fiber.StatusConflict)
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(labelToDTO(label))
+}
+
+func (lc *Controller) labelById(c *fiber.Ctx) error {
+	login, err := lc.login(c)
+	if err != nil {
+		return err
+	}
+	labelId, err := lc.labelId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	label, sErr := lc.labelsService.LabelById(c.Context(), login, labelId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(lc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrLabelNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(labelToDTO(label))
+}
+
+func (lc *Controller) updateLabelById(c *fiber.Ctx) error {
+	login, err := lc.login(c)
+	if err != nil {
+		return err
+	}
+	labelId, err := lc.labelId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	params, err := lc.labelParams(c)
+	if err != nil {
+		return err
+	}
+	if sErr := lc.labelsService.UpdateLabelById(c.Context(), login, labelId, params); sErr != nil {
+		logger_adapter.LogServiceError(lc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrLabelNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(sErr.Err, ErrLabelNameConflict) {
+			return fiber_adapter.SpecificServiceError(sErr, fiber.StatusConflict)
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (lc *Controller) removeLabelById(c *fiber.Ctx) error {
+	login, err := lc.login(c)
+	if err != nil {
+		return err
+	}
+	labelId, err := lc.labelId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if sErr := lc.labelsService.RemoveLabelById(c.Context(), login, labelId); sErr != nil {
+		logger_adapter.LogServiceError(lc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrLabelNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (lc *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		lc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
+func (lc *Controller) labelParams(c *fiber.Ctx) (LabelParams, error) {
+	var dto CreateLabelDTO
+	if err := c.BodyParser(&dto); err != nil {
+		lc.log.Debug(c.Context(), "failed to decode body")
+		return LabelParams{}, err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		lc.log.Debug(c.Context(), "invalid create label dto struct", sl.Err(err))
+		return LabelParams{}, fiber_adapter.BadRequest(err)
+	}
+	return LabelParams{
+		Name: dto.Name,
+	}, nil
+}
+
+func (lc *Controller) labelId(c *fiber.Ctx, value string) (LabelId, error) {
+	labelId, err := ParseLabelId(value)
+	if err != nil {
+		lc.log.Debug(c.Context(), "invalid label id value", slog.String("label_id", value))
+		return labelId, fiber_adapter.BadRequest(err)
+	}
+	return labelId, nil
+}
@@ -0,0 +1,299 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package labels
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLabelsRepo is an autogenerated mock type for the LabelsRepo type
+type MockLabelsRepo struct {
+	mock.Mock
+}
+
+type MockLabelsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLabelsRepo) EXPECT() *MockLabelsRepo_Expecter {
+	return &MockLabelsRepo_Expecter{mock: &_m.Mock}
+}
+
+// LabelById provides a mock function with given fields: ctx, owner, id
+func (_m *MockLabelsRepo) LabelById(ctx context.Context, owner string, id LabelId) (Label, error) {
+	ret := _m.Called(ctx, owner, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LabelById")
+	}
+
+	var r0 Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, LabelId) (Label, error)); ok {
+		return rf(ctx, owner, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, LabelId) Label); ok {
+		r0 = rf(ctx, owner, id)
+	} else {
+		r0 = ret.Get(0).(Label)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, LabelId) error); ok {
+		r1 = rf(ctx, owner, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLabelsRepo_LabelById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LabelById'
+type MockLabelsRepo_LabelById_Call struct {
+	*mock.Call
+}
+
+// LabelById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id LabelId
+func (_e *MockLabelsRepo_Expecter) LabelById(ctx interface{}, owner interface{}, id interface{}) *MockLabelsRepo_LabelById_Call {
+	return &MockLabelsRepo_LabelById_Call{Call: _e.mock.On("LabelById", ctx, owner, id)}
+}
+
+func (_c *MockLabelsRepo_LabelById_Call) Run(run func(ctx context.Context, owner string, id LabelId)) *MockLabelsRepo_LabelById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(LabelId))
+	})
+	return _c
+}
+
+func (_c *MockLabelsRepo_LabelById_Call) Return(_a0 Label, _a1 error) *MockLabelsRepo_LabelById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLabelsRepo_LabelById_Call) RunAndReturn(run func(context.Context, string, LabelId) (Label, error)) *MockLabelsRepo_LabelById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LabelsByOwner provides a mock function with given fields: ctx, owner
+func (_m *MockLabelsRepo) LabelsByOwner(ctx context.Context, owner string) ([]Label, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LabelsByOwner")
+	}
+
+	var r0 []Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Label, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Label); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLabelsRepo_LabelsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LabelsByOwner'
+type MockLabelsRepo_LabelsByOwner_Call struct {
+	*mock.Call
+}
+
+// LabelsByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *MockLabelsRepo_Expecter) LabelsByOwner(ctx interface{}, owner interface{}) *MockLabelsRepo_LabelsByOwner_Call {
+	return &MockLabelsRepo_LabelsByOwner_Call{Call: _e.mock.On("LabelsByOwner", ctx, owner)}
+}
+
+func (_c *MockLabelsRepo_LabelsByOwner_Call) Run(run func(ctx context.Context, owner string)) *MockLabelsRepo_LabelsByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLabelsRepo_LabelsByOwner_Call) Return(_a0 []Label, _a1 error) *MockLabelsRepo_LabelsByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLabelsRepo_LabelsByOwner_Call) RunAndReturn(run func(context.Context, string) ([]Label, error)) *MockLabelsRepo_LabelsByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveLabelById provides a mock function with given fields: ctx, owner, id
+func (_m *MockLabelsRepo) RemoveLabelById(ctx context.Context, owner string, id LabelId) error {
+	ret := _m.Called(ctx, owner, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLabelById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, LabelId) error); ok {
+		r0 = rf(ctx, owner, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLabelsRepo_RemoveLabelById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLabelById'
+type MockLabelsRepo_RemoveLabelById_Call struct {
+	*mock.Call
+}
+
+// RemoveLabelById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id LabelId
+func (_e *MockLabelsRepo_Expecter) RemoveLabelById(ctx interface{}, owner interface{}, id interface{}) *MockLabelsRepo_RemoveLabelById_Call {
+	return &MockLabelsRepo_RemoveLabelById_Call{Call: _e.mock.On("RemoveLabelById", ctx, owner, id)}
+}
+
+func (_c *MockLabelsRepo_RemoveLabelById_Call) Run(run func(ctx context.Context, owner string, id LabelId)) *MockLabelsRepo_RemoveLabelById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(LabelId))
+	})
+	return _c
+}
+
+func (_c *MockLabelsRepo_RemoveLabelById_Call) Return(_a0 error) *MockLabelsRepo_RemoveLabelById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLabelsRepo_RemoveLabelById_Call) RunAndReturn(run func(context.Context, string, LabelId) error) *MockLabelsRepo_RemoveLabelById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveLabel provides a mock function with given fields: ctx, label
+func (_m *MockLabelsRepo) SaveLabel(ctx context.Context, label Label) error {
+	ret := _m.Called(ctx, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Label) error); ok {
+		r0 = rf(ctx, label)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLabelsRepo_SaveLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveLabel'
+type MockLabelsRepo_SaveLabel_Call struct {
+	*mock.Call
+}
+
+// SaveLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - label Label
+func (_e *MockLabelsRepo_Expecter) SaveLabel(ctx interface{}, label interface{}) *MockLabelsRepo_SaveLabel_Call {
+	return &MockLabelsRepo_SaveLabel_Call{Call: _e.mock.On("SaveLabel", ctx, label)}
+}
+
+func (_c *MockLabelsRepo_SaveLabel_Call) Run(run func(ctx context.Context, label Label)) *MockLabelsRepo_SaveLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Label))
+	})
+	return _c
+}
+
+func (_c *MockLabelsRepo_SaveLabel_Call) Return(_a0 error) *MockLabelsRepo_SaveLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLabelsRepo_SaveLabel_Call) RunAndReturn(run func(context.Context, Label) error) *MockLabelsRepo_SaveLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLabelById provides a mock function with given fields: ctx, owner, id, params, updatedAt
+func (_m *MockLabelsRepo) UpdateLabelById(ctx context.Context, owner string, id LabelId, params LabelParams, updatedAt time.Time) error {
+	ret := _m.Called(ctx, owner, id, params, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLabelById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, LabelId, LabelParams, time.Time) error); ok {
+		r0 = rf(ctx, owner, id, params, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLabelsRepo_UpdateLabelById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLabelById'
+type MockLabelsRepo_UpdateLabelById_Call struct {
+	*mock.Call
+}
+
+// UpdateLabelById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id LabelId
+//   - params LabelParams
+//   - updatedAt time.Time
+func (_e *MockLabelsRepo_Expecter) UpdateLabelById(ctx interface{}, owner interface{}, id interface{}, params interface{}, updatedAt interface{}) *MockLabelsRepo_UpdateLabelById_Call {
+	return &MockLabelsRepo_UpdateLabelById_Call{Call: _e.mock.On("UpdateLabelById", ctx, owner, id, params, updatedAt)}
+}
+
+func (_c *MockLabelsRepo_UpdateLabelById_Call) Run(run func(ctx context.Context, owner string, id LabelId, params LabelParams, updatedAt time.Time)) *MockLabelsRepo_UpdateLabelById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(LabelId), args[3].(LabelParams), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockLabelsRepo_UpdateLabelById_Call) Return(_a0 error) *MockLabelsRepo_UpdateLabelById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLabelsRepo_UpdateLabelById_Call) RunAndReturn(run func(context.Context, string, LabelId, LabelParams, time.Time) error) *MockLabelsRepo_UpdateLabelById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLabelsRepo creates a new instance of MockLabelsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLabelsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLabelsRepo {
+	mock := &MockLabelsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+package labels
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrLabelNotFound = errors.New("label not found")
+var ErrInvalidLabelName = errors.New("invalid label name")
+var ErrLabelNameConflict = errors.New("label name conflict")
+
+type LabelId uuid.UUID
+
+func (id LabelId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewLabelId() LabelId {
+	return LabelId(uuid.New())
+}
+
+func ParseLabelId(id string) (LabelId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return LabelId(uuid.Nil), err
+	}
+	return LabelId(uid), nil
+}
+
+type Label struct {
+	Id        LabelId
+	Name      string
+	Owner     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type LabelParams struct {
+	Name string
+}
+
+func NewLabel(
+	labelId LabelId,
+	name string,
+	owner string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (Label, error) {
+	if len(name) == 0 {
+		return Label{}, ErrInvalidLabelName
+	}
+	return Label{
+		Id:        labelId,
+		Name:      name,
+		Owner:     owner,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
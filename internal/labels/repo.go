@@ -0,0 +1,137 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+)
+
+type Repo struct {
+	log     *logger.Logger
+	queries *db.Queries
+}
+
+func NewRepo(
+	log *logger.Logger,
+	queries *db.Queries,
+) *Repo {
+	return &Repo{log, queries}
+}
+
+func (r *Repo) SaveLabel(ctx context.Context, label Label) error {
+	err := r.queries.InsertLabel(ctx, db.InsertLabelParams{
+		ID:    r.labelIdToPg(label.Id),
+		Name:  label.Name,
+		Owner: label.Owner,
+		CreatedAt: pgtype.Timestamp{
+			Time:  label.CreatedAt.UTC(),
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamp{
+			Time:  label.UpdatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if isUniqueViolation(err) {
+		return ErrLabelNameConflict
+	}
+	return err
+}
+
+func (r *Repo) LabelById(ctx context.Context, owner string, id LabelId) (Label, error) {
+	row, err := r.queries.LabelById(ctx, db.LabelByIdParams{
+		ID:    r.labelIdToPg(id),
+		Owner: owner,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Label{}, ErrLabelNotFound
+	}
+	if err != nil {
+		return Label{}, err
+	}
+	return r.labelFromPg(row)
+}
+
+func (r *Repo) LabelsByOwner(ctx context.Context, owner string) ([]Label, error) {
+	rows, err := r.queries.LabelsByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]Label, len(rows))
+	for i, row := range rows {
+		if labels[i], err = r.labelFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	return labels, nil
+}
+
+func (r *Repo) UpdateLabelById(
+	ctx context.Context,
+	owner string,
+	id LabelId,
+	params LabelParams,
+	updatedAt time.Time,
+) error {
+	rowsAffected, err := r.queries.UpdateLabel(ctx, db.UpdateLabelParams{
+		ID:    r.labelIdToPg(id),
+		Owner: owner,
+		Name:  params.Name,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  updatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if isUniqueViolation(err) {
+		return ErrLabelNameConflict
+	}
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
+
+func (r *Repo) RemoveLabelById(ctx context.Context, owner string, id LabelId) error {
+	rowsAffected, err := r.queries.DeleteLabel(ctx, db.DeleteLabelParams{
+		ID:    r.labelIdToPg(id),
+		Owner: owner,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
+
+func (r *Repo) labelIdToPg(id LabelId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func (r *Repo) labelFromPg(row db.Label) (Label, error) {
+	return NewLabel(
+		row.ID.Bytes,
+		row.Name,
+		row.Owner,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
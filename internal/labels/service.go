@@ -0,0 +1,100 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+type LabelsRepo interface {
+	SaveLabel(ctx context.Context, label Label) error
+	LabelById(ctx context.Context, owner string, id LabelId) (Label, error)
+	LabelsByOwner(ctx context.Context, owner string) ([]Label, error)
+	UpdateLabelById(ctx context.Context, owner string, id LabelId, params LabelParams, updatedAt time.Time) error
+	RemoveLabelById(ctx context.Context, owner string, id LabelId) error
+}
+
+type Service struct {
+	log        *logger.Logger
+	labelsRepo LabelsRepo
+}
+
+func NewService(
+	log *logger.Logger,
+	repo LabelsRepo,
+) *Service {
+	return &Service{log, repo}
+}
+
+func (s *Service) CreateLabel(ctx context.Context, owner string, params LabelParams) (Label, *shared.ServiceError) {
+	now := time.Now()
+	label, err := NewLabel(
+		NewLabelId(),
+		params.Name,
+		owner,
+		now,
+		now,
+	)
+	if err != nil {
+		return label, shared.NewServiceError(err, "failed to create label")
+	}
+	err = s.labelsRepo.SaveLabel(ctx, label)
+	if errors.Is(err, ErrLabelNameConflict) {
+		return label, shared.NewServiceError(err, fmt.Sprintf("label with name %q already exists", label.Name))
+	}
+	if err != nil {
+		return label, shared.NewUnexpectedError(err, "failed to save label")
+	}
+	return label, nil
+}
+
+func (s *Service) Labels(ctx context.Context, owner string) ([]Label, *shared.ServiceError) {
+	labels, err := s.labelsRepo.LabelsByOwner(ctx, owner)
+	if err != nil {
+		return labels, shared.NewUnexpectedError(err, "failed to load labels")
+	}
+	return labels, nil
+}
+
+func (s *Service) LabelById(ctx context.Context, owner string, id LabelId) (Label, *shared.ServiceError) {
+	label, err := s.labelsRepo.LabelById(ctx, owner, id)
+	if errors.Is(err, ErrLabelNotFound) {
+		return label, shared.NewServiceError(err, fmt.Sprintf("label with id %q not found", id.String()))
+	}
+	if err != nil {
+		return label, shared.NewUnexpectedError(err, "failed to load label")
+	}
+	return label, nil
+}
+
+func (s *Service) UpdateLabelById(ctx context.Context, owner string, id LabelId, params LabelParams) *shared.ServiceError {
+	if len(params.Name) == 0 {
+		return shared.NewServiceError(ErrInvalidLabelName, "failed to update label")
+	}
+	err := s.labelsRepo.UpdateLabelById(ctx, owner, id, params, time.Now())
+	if errors.Is(err, ErrLabelNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("label with id %q not found", id.String()))
+	}
+	if errors.Is(err, ErrLabelNameConflict) {
+		return shared.NewServiceError(err, fmt.Sprintf("label with name %q already exists", params.Name))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to update label")
+	}
+	return nil
+}
+
+func (s *Service) RemoveLabelById(ctx context.Context, owner string, id LabelId) *shared.ServiceError {
+	err := s.labelsRepo.RemoveLabelById(ctx, owner, id)
+	if errors.Is(err, ErrLabelNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("label with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove label")
+	}
+	return nil
+}
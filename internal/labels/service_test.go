@@ -0,0 +1,208 @@
+package labels_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+const owner = "owner"
+
+func newTestService(t *testing.T, setup func(repo *labels.MockLabelsRepo)) *labels.Service {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	repo := labels.NewMockLabelsRepo(t)
+	if setup != nil {
+		setup(repo)
+	}
+	return labels.NewService(
+		log,
+		repo,
+	)
+}
+
+func TestServiceCreateLabel(t *testing.T) {
+	params := labels.LabelParams{
+		Name: "bug",
+	}
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *labels.Service
+		params  labels.LabelParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				labelMatcher := mock.MatchedBy(func(l labels.Label) bool {
+					return l.Name == params.Name && l.Owner == owner
+				})
+				repo.EXPECT().SaveLabel(mock.Anything, labelMatcher).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  labels.LabelParams{},
+			err:     shared.NewServiceError(labels.ErrInvalidLabelName, ""),
+		},
+		{
+			name: "name conflict",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().SaveLabel(mock.Anything, mock.Anything).Return(labels.ErrLabelNameConflict)
+			}),
+			params: params,
+			err:    shared.NewServiceError(labels.ErrLabelNameConflict, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().SaveLabel(mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := c.service.CreateLabel(t.Context(), owner, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceUpdateLabelById(t *testing.T) {
+	labelId := labels.NewLabelId()
+	params := labels.LabelParams{
+		Name: "feature",
+	}
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *labels.Service
+		params  labels.LabelParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().
+					UpdateLabelById(mock.Anything, owner, labelId, params, mock.AnythingOfType("time.Time")).
+					Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  labels.LabelParams{},
+			err:     shared.NewServiceError(labels.ErrInvalidLabelName, ""),
+		},
+		{
+			name: "label not found",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().
+					UpdateLabelById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(labels.ErrLabelNotFound)
+			}),
+			params: params,
+			err:    shared.NewServiceError(labels.ErrLabelNotFound, ""),
+		},
+		{
+			name: "name conflict",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().
+					UpdateLabelById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(labels.ErrLabelNameConflict)
+			}),
+			params: params,
+			err:    shared.NewServiceError(labels.ErrLabelNameConflict, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().
+					UpdateLabelById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.UpdateLabelById(t.Context(), owner, labelId, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceRemoveLabelById(t *testing.T) {
+	labelId := labels.NewLabelId()
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *labels.Service
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().RemoveLabelById(mock.Anything, owner, labelId).Return(nil)
+			}),
+		},
+		{
+			name: "label not found",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().RemoveLabelById(mock.Anything, mock.Anything, mock.Anything).
+					Return(labels.ErrLabelNotFound)
+			}),
+			err: shared.NewServiceError(labels.ErrLabelNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *labels.MockLabelsRepo) {
+				repo.EXPECT().RemoveLabelById(mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RemoveLabelById(t.Context(), owner, labelId); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// FsStore keeps blobs as files in the root directory,
+// the blob key is used as a file name
+type FsStore struct {
+	root string
+}
+
+func NewFsStore(root string) *FsStore {
+	return &FsStore{root}
+}
+
+func (s *FsStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.root, 0o755); err != nil {
+		return err
+	}
+	// The content is written to a temporary file first so readers
+	// never observe a partially written blob
+	f, err := os.CreateTemp(s.root, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func (s *FsStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return f, err
+}
+
+// Remove deletes the blob, missing blobs are ignored
+func (s *FsStore) Remove(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FsStore) path(key string) (string, error) {
+	if key == "" || filepath.Base(key) != key || !filepath.IsLocal(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, key), nil
+}
@@ -11,104 +11,243 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type TaskPriority string
+type ProjectRole string
 
 const (
-	TaskPriorityLow    TaskPriority = "low"
-	TaskPriorityMedium TaskPriority = "medium"
-	TaskPriorityHigh   TaskPriority = "high"
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleViewer ProjectRole = "viewer"
 )
 
-func (e *TaskPriority) Scan(src interface{}) error {
+func (e *ProjectRole) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = TaskPriority(s)
+		*e = ProjectRole(s)
 	case string:
-		*e = TaskPriority(s)
+		*e = ProjectRole(s)
 	default:
-		return fmt.Errorf("unsupported scan type for TaskPriority: %T", src)
+		return fmt.Errorf("unsupported scan type for ProjectRole: %T", src)
 	}
 	return nil
 }
 
-type NullTaskPriority struct {
-	TaskPriority TaskPriority
-	Valid        bool // Valid is true if TaskPriority is not NULL
+type NullProjectRole struct {
+	ProjectRole ProjectRole
+	Valid       bool // Valid is true if ProjectRole is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullTaskPriority) Scan(value interface{}) error {
+func (ns *NullProjectRole) Scan(value interface{}) error {
 	if value == nil {
-		ns.TaskPriority, ns.Valid = "", false
+		ns.ProjectRole, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.TaskPriority.Scan(value)
+	return ns.ProjectRole.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullTaskPriority) Value() (driver.Value, error) {
+func (ns NullProjectRole) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.TaskPriority), nil
+	return string(ns.ProjectRole), nil
 }
 
-type TaskStatus string
+type TaskEventKind string
 
 const (
-	TaskStatusPending    TaskStatus = "pending"
-	TaskStatusInProgress TaskStatus = "in_progress"
-	TaskStatusDone       TaskStatus = "done"
+	TaskEventKindCreated  TaskEventKind = "created"
+	TaskEventKindUpdated  TaskEventKind = "updated"
+	TaskEventKindDeleted  TaskEventKind = "deleted"
+	TaskEventKindReopened TaskEventKind = "reopened"
+	TaskEventKindRestored TaskEventKind = "restored"
+	TaskEventKindArchived TaskEventKind = "archived"
 )
 
-func (e *TaskStatus) Scan(src interface{}) error {
+func (e *TaskEventKind) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = TaskStatus(s)
+		*e = TaskEventKind(s)
 	case string:
-		*e = TaskStatus(s)
+		*e = TaskEventKind(s)
 	default:
-		return fmt.Errorf("unsupported scan type for TaskStatus: %T", src)
+		return fmt.Errorf("unsupported scan type for TaskEventKind: %T", src)
 	}
 	return nil
 }
 
-type NullTaskStatus struct {
-	TaskStatus TaskStatus
-	Valid      bool // Valid is true if TaskStatus is not NULL
+type NullTaskEventKind struct {
+	TaskEventKind TaskEventKind
+	Valid         bool // Valid is true if TaskEventKind is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullTaskStatus) Scan(value interface{}) error {
+func (ns *NullTaskEventKind) Scan(value interface{}) error {
 	if value == nil {
-		ns.TaskStatus, ns.Valid = "", false
+		ns.TaskEventKind, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.TaskStatus.Scan(value)
+	return ns.TaskEventKind.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullTaskStatus) Value() (driver.Value, error) {
+func (ns NullTaskEventKind) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.TaskStatus), nil
+	return string(ns.TaskEventKind), nil
+}
+
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+)
+
+func (e *TaskPriority) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskPriority(s)
+	case string:
+		*e = TaskPriority(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskPriority: %T", src)
+	}
+	return nil
+}
+
+type NullTaskPriority struct {
+	TaskPriority TaskPriority
+	Valid        bool // Valid is true if TaskPriority is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskPriority) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskPriority, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskPriority.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskPriority) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskPriority), nil
+}
+
+type Label struct {
+	ID        pgtype.UUID
+	Name      string
+	Owner     string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type Project struct {
+	ID          pgtype.UUID
+	Name        string
+	Description pgtype.Text
+	Owner       string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type ProjectMember struct {
+	ProjectID pgtype.UUID
+	Login     string
+	Role      ProjectRole
 }
 
 type Task struct {
 	ID          pgtype.UUID
 	Title       string
 	Description pgtype.Text
-	Status      TaskStatus
+	Status      string
 	Priority    TaskPriority
 	DueDate     pgtype.Date
 	CreatedAt   pgtype.Timestamp
 	UpdatedAt   pgtype.Timestamp
+	Owner       string
+	ProjectID   pgtype.UUID
+	ParentID    pgtype.UUID
+	Recurrence  pgtype.Text
+	Assignee    pgtype.Text
+	Version     int64
+	Position    string
+	DeletedAt   pgtype.Timestamp
+	ArchivedAt  pgtype.Timestamp
+	DueTime     pgtype.Time
+	TimeZone    string
+}
+
+type TaskAttachment struct {
+	ID          pgtype.UUID
+	TaskID      pgtype.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Uploader    string
+	CreatedAt   pgtype.Timestamp
+}
+
+type TaskComment struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	Author    string
+	Body      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type TaskDependency struct {
+	TaskID      pgtype.UUID
+	BlockedByID pgtype.UUID
+}
+
+type TaskEvent struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	Actor     string
+	Kind      TaskEventKind
+	Changes   []byte
+	CreatedAt pgtype.Timestamp
+	Reason    pgtype.Text
+}
+
+type TaskLabel struct {
+	TaskID  pgtype.UUID
+	LabelID pgtype.UUID
+}
+
+type TaskReminder struct {
+	ID            pgtype.UUID
+	TaskID        pgtype.UUID
+	Login         string
+	BeforeSeconds int64
+	SentFor       pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+}
+
+type TaskView struct {
+	ID        pgtype.UUID
+	Name      string
+	Owner     string
+	Filter    []byte
+	Sort      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
 }
 
 type User struct {
 	Login        string
 	PasswordHash []byte
+	TimeZone     string
 }
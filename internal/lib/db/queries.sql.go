@@ -12,11 +12,14 @@ import (
 )
 
 const allTasks = `-- name: AllTasks :many
-SELECT id, title, description, status, priority, due_date, created_at, updated_at FROM task
+SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone FROM task
+WHERE
+  deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $1 OR assignee = $1 OR project_id IN (SELECT project_id FROM project_member WHERE login = $1))
 `
 
-func (q *Queries) AllTasks(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.Query(ctx, allTasks)
+func (q *Queries) AllTasks(ctx context.Context, owner string) ([]Task, error) {
+	rows, err := q.db.Query(ctx, allTasks, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +36,17 @@ func (q *Queries) AllTasks(ctx context.Context) ([]Task, error) {
 			&i.DueDate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Owner,
+			&i.ProjectID,
+			&i.ParentID,
+			&i.Recurrence,
+			&i.Assignee,
+			&i.Version,
+			&i.Position,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.DueTime,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -44,17 +58,32 @@ func (q *Queries) AllTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
+const archiveTasks = `-- name: ArchiveTasks :exec
+UPDATE task SET archived_at = $1
+WHERE id = ANY($2::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL
+`
+
+type ArchiveTasksParams struct {
+	ArchivedAt pgtype.Timestamp
+	TaskIds    []pgtype.UUID
+}
+
+func (q *Queries) ArchiveTasks(ctx context.Context, arg ArchiveTasksParams) error {
+	_, err := q.db.Exec(ctx, archiveTasks, arg.ArchivedAt, arg.TaskIds)
+	return err
+}
+
 const averageTaskCompletionTime = `-- name: AverageTaskCompletionTime :one
 SELECT
   AVG(EXTRACT(EPOCH FROM (updated_at - created_at))) AS average_completion_time
 FROM
     task
 WHERE
-    task.status = 'done'
+    task.status = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL
 `
 
-func (q *Queries) AverageTaskCompletionTime(ctx context.Context) (float64, error) {
-	row := q.db.QueryRow(ctx, averageTaskCompletionTime)
+func (q *Queries) AverageTaskCompletionTime(ctx context.Context, status string) (float64, error) {
+	row := q.db.QueryRow(ctx, averageTaskCompletionTime, status)
 	var average_completion_time float64
 	err := row.Scan(&average_completion_time)
 	return average_completion_time, err
@@ -62,34 +91,84 @@ func (q *Queries) AverageTaskCompletionTime(ctx context.Context) (float64, error
 
 const countCompletedAndOverdueTasks = `-- name: CountCompletedAndOverdueTasks :one
 WITH last_week_task AS (
-  SELECT id, title, description, status, priority, due_date, created_at, updated_at
+  SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone
   FROM task
-  WHERE updated_at >= $1
+  WHERE updated_at >= $1 AND deleted_at IS NULL AND archived_at IS NULL
 )
 SELECT
-  (SELECT count(*) FROM last_week_task WHERE status = 'done') AS completed_count,
-  (SELECT count(*) FROM last_week_task WHERE status != 'done' AND due_date < CURRENT_DATE) AS overdue_count
+  (SELECT count(*) FROM last_week_task WHERE status = $2) AS completed_count,
+  (SELECT count(*) FROM last_week_task WHERE status != $2 AND task_deadline(due_date, due_time, time_zone) < now() AT TIME ZONE 'UTC') AS overdue_count
 `
 
+type CountCompletedAndOverdueTasksParams struct {
+	UpdatedAt  pgtype.Timestamp
+	DoneStatus string
+}
+
 type CountCompletedAndOverdueTasksRow struct {
 	CompletedCount int64
 	OverdueCount   int64
 }
 
-func (q *Queries) CountCompletedAndOverdueTasks(ctx context.Context, updatedAt pgtype.Timestamp) (CountCompletedAndOverdueTasksRow, error) {
-	row := q.db.QueryRow(ctx, countCompletedAndOverdueTasks, updatedAt)
+func (q *Queries) CountCompletedAndOverdueTasks(ctx context.Context, arg CountCompletedAndOverdueTasksParams) (CountCompletedAndOverdueTasksRow, error) {
+	row := q.db.QueryRow(ctx, countCompletedAndOverdueTasks, arg.UpdatedAt, arg.DoneStatus)
 	var i CountCompletedAndOverdueTasksRow
 	err := row.Scan(&i.CompletedCount, &i.OverdueCount)
 	return i, err
 }
 
+const countOpenBlockers = `-- name: CountOpenBlockers :one
+SELECT count(*) FROM task_dependency
+JOIN task ON task.id = task_dependency.blocked_by_id
+WHERE task_dependency.task_id = $1 AND task.status != $2 AND task.deleted_at IS NULL AND task.archived_at IS NULL
+`
+
+type CountOpenBlockersParams struct {
+	TaskID pgtype.UUID
+	Status string
+}
+
+func (q *Queries) CountOpenBlockers(ctx context.Context, arg CountOpenBlockersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOpenBlockers, arg.TaskID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOpenSubtasks = `-- name: CountOpenSubtasks :one
+SELECT count(*) FROM task WHERE parent_id = $1 AND status != $2 AND deleted_at IS NULL AND archived_at IS NULL
+`
+
+type CountOpenSubtasksParams struct {
+	ParentID pgtype.UUID
+	Status   string
+}
+
+func (q *Queries) CountOpenSubtasks(ctx context.Context, arg CountOpenSubtasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOpenSubtasks, arg.ParentID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTaskLabels = `-- name: CountTaskLabels :one
+SELECT count(*) FROM task_label WHERE task_id = $1
+`
+
+func (q *Queries) CountTaskLabels(ctx context.Context, taskID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countTaskLabels, taskID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countTasksByStatus = `-- name: CountTasksByStatus :many
-SELECT count(*) AS tasks_count, status FROM task GROUP BY status
+SELECT count(*) AS tasks_count, status FROM task WHERE deleted_at IS NULL AND archived_at IS NULL GROUP BY status
 `
 
 type CountTasksByStatusRow struct {
 	TasksCount int64
-	Status     TaskStatus
+	Status     string
 }
 
 func (q *Queries) CountTasksByStatus(ctx context.Context) ([]CountTasksByStatusRow, error) {
@@ -112,116 +191,1822 @@ func (q *Queries) CountTasksByStatus(ctx context.Context) ([]CountTasksByStatusR
 	return items, nil
 }
 
-const deleteOverdueTasks = `-- name: DeleteOverdueTasks :exec
-DELETE FROM task WHERE status != 'done' and due_date < $1
+const deletedTaskById = `-- name: DeletedTaskById :one
+SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone FROM task
+WHERE
+  id = $1 AND deleted_at IS NOT NULL AND
+  (owner = $2 OR assignee = $2 OR project_id IN (SELECT project_id FROM project_member WHERE login = $2))
 `
 
-func (q *Queries) DeleteOverdueTasks(ctx context.Context, dueDate pgtype.Date) error {
-	_, err := q.db.Exec(ctx, deleteOverdueTasks, dueDate)
-	return err
+type DeletedTaskByIdParams struct {
+	ID    pgtype.UUID
+	Owner string
+}
+
+func (q *Queries) DeletedTaskById(ctx context.Context, arg DeletedTaskByIdParams) (Task, error) {
+	row := q.db.QueryRow(ctx, deletedTaskById, arg.ID, arg.Owner)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Owner,
+		&i.ProjectID,
+		&i.ParentID,
+		&i.Recurrence,
+		&i.Assignee,
+		&i.Version,
+		&i.Position,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.DueTime,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const deletedTasks = `-- name: DeletedTasks :many
+SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone FROM task
+WHERE
+  deleted_at IS NOT NULL AND
+  (owner = $1 OR assignee = $1 OR project_id IN (SELECT project_id FROM project_member WHERE login = $1))
+ORDER BY deleted_at DESC, id
+`
+
+func (q *Queries) DeletedTasks(ctx context.Context, owner string) ([]Task, error) {
+	rows, err := q.db.Query(ctx, deletedTasks, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Owner,
+			&i.ProjectID,
+			&i.ParentID,
+			&i.Recurrence,
+			&i.Assignee,
+			&i.Version,
+			&i.Position,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.DueTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deletedTasksIds = `-- name: DeletedTasksIds :many
+SELECT id FROM task WHERE deleted_at < $1
+`
+
+func (q *Queries) DeletedTasksIds(ctx context.Context, deletedAt pgtype.Timestamp) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, deletedTasksIds, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deletedTasksTree = `-- name: DeletedTasksTree :many
+WITH RECURSIVE tree AS (
+  SELECT id, deleted_at FROM task WHERE task.id = $1 AND task.deleted_at IS NOT NULL
+  UNION
+  SELECT task.id, task.deleted_at FROM task
+  JOIN tree ON task.parent_id = tree.id AND task.deleted_at = tree.deleted_at
+)
+SELECT task.id, task.title, task.description, task.status, task.priority, task.due_date, task.created_at, task.updated_at, task.owner, task.project_id, task.parent_id, task.recurrence, task.assignee, task.version, task.position, task.deleted_at, task.archived_at, task.due_time, task.time_zone FROM task WHERE task.id IN (SELECT id FROM tree)
+`
+
+func (q *Queries) DeletedTasksTree(ctx context.Context, id pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, deletedTasksTree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Owner,
+			&i.ProjectID,
+			&i.ParentID,
+			&i.Recurrence,
+			&i.Assignee,
+			&i.Version,
+			&i.Position,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.DueTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteLabel = `-- name: DeleteLabel :execrows
+DELETE FROM label WHERE id = $1 AND owner = $2
+`
+
+type DeleteLabelParams struct {
+	ID    pgtype.UUID
+	Owner string
+}
+
+func (q *Queries) DeleteLabel(ctx context.Context, arg DeleteLabelParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteLabel, arg.ID, arg.Owner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProject = `-- name: DeleteProject :execrows
+DELETE FROM project WHERE project.id = $1
+`
+
+func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProject, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProjectMember = `-- name: DeleteProjectMember :execrows
+DELETE FROM project_member WHERE project_id = $1 AND login = $2
+`
+
+type DeleteProjectMemberParams struct {
+	ProjectID pgtype.UUID
+	Login     string
+}
+
+func (q *Queries) DeleteProjectMember(ctx context.Context, arg DeleteProjectMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProjectMember, arg.ProjectID, arg.Login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteTask = `-- name: DeleteTask :execrows
-DELETE FROM task WHERE task.id = $1
+UPDATE task SET deleted_at = $3
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $2 OR task.assignee = $2 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $2))
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id pgtype.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteTask, id)
+type DeleteTaskParams struct {
+	ID        pgtype.UUID
+	Owner     string
+	DeletedAt pgtype.Timestamp
+}
+
+func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTask, arg.ID, arg.Owner, arg.DeletedAt)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const insertTask = `-- name: InsertTask :exec
-INSERT INTO task
-  (id, title, description, status, priority, due_date, created_at, updated_at)
-VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8)
+const deleteTaskAttachment = `-- name: DeleteTaskAttachment :execrows
+DELETE FROM task_attachment WHERE id = $1 AND task_id = $2
 `
 
-type InsertTaskParams struct {
-	ID          pgtype.UUID
-	Title       string
-	Description pgtype.Text
-	Status      TaskStatus
-	Priority    TaskPriority
-	DueDate     pgtype.Date
-	CreatedAt   pgtype.Timestamp
-	UpdatedAt   pgtype.Timestamp
+type DeleteTaskAttachmentParams struct {
+	ID     pgtype.UUID
+	TaskID pgtype.UUID
 }
 
-func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) error {
-	_, err := q.db.Exec(ctx, insertTask,
-		arg.ID,
-		arg.Title,
-		arg.Description,
-		arg.Status,
-		arg.Priority,
-		arg.DueDate,
-		arg.CreatedAt,
-		arg.UpdatedAt,
-	)
+func (q *Queries) DeleteTaskAttachment(ctx context.Context, arg DeleteTaskAttachmentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskAttachment, arg.ID, arg.TaskID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTaskComment = `-- name: DeleteTaskComment :execrows
+DELETE FROM task_comment WHERE id = $1
+`
+
+func (q *Queries) DeleteTaskComment(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskComment, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTaskDependency = `-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependency WHERE task_id = $1 AND blocked_by_id = $2
+`
+
+type DeleteTaskDependencyParams struct {
+	TaskID      pgtype.UUID
+	BlockedByID pgtype.UUID
+}
+
+func (q *Queries) DeleteTaskDependency(ctx context.Context, arg DeleteTaskDependencyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskDependency, arg.TaskID, arg.BlockedByID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTaskLabelsExcept = `-- name: DeleteTaskLabelsExcept :exec
+DELETE FROM task_label
+WHERE task_id = $1 AND NOT (label_id = ANY($2::uuid[]))
+`
+
+type DeleteTaskLabelsExceptParams struct {
+	TaskID   pgtype.UUID
+	LabelIds []pgtype.UUID
+}
+
+func (q *Queries) DeleteTaskLabelsExcept(ctx context.Context, arg DeleteTaskLabelsExceptParams) error {
+	_, err := q.db.Exec(ctx, deleteTaskLabelsExcept, arg.TaskID, arg.LabelIds)
 	return err
 }
 
-const insertUser = `-- name: InsertUser :exec
-INSERT INTO "user" (login, password_hash) VALUES ($1, $2)
+const deleteTaskReminder = `-- name: DeleteTaskReminder :execrows
+DELETE FROM task_reminder WHERE id = $1 AND task_id = $2 AND login = $3
 `
 
-type InsertUserParams struct {
-	Login        string
-	PasswordHash []byte
+type DeleteTaskReminderParams struct {
+	ID     pgtype.UUID
+	TaskID pgtype.UUID
+	Login  string
 }
 
-func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) error {
-	_, err := q.db.Exec(ctx, insertUser, arg.Login, arg.PasswordHash)
+func (q *Queries) DeleteTaskReminder(ctx context.Context, arg DeleteTaskReminderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskReminder, arg.ID, arg.TaskID, arg.Login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTasks = `-- name: DeleteTasks :exec
+UPDATE task SET deleted_at = $1
+WHERE id = ANY($2::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL
+`
+
+type DeleteTasksParams struct {
+	DeletedAt pgtype.Timestamp
+	TaskIds   []pgtype.UUID
+}
+
+func (q *Queries) DeleteTasks(ctx context.Context, arg DeleteTasksParams) error {
+	_, err := q.db.Exec(ctx, deleteTasks, arg.DeletedAt, arg.TaskIds)
 	return err
 }
 
-const updateTask = `-- name: UpdateTask :execrows
-UPDATE task SET
-  title = $2,
-  description = $3,
-  status = $4,
-  priority = $5,
-  due_date = $6,
-  updated_at = CURRENT_DATE
-WHERE
-  task.id = $1 AND task.status != 'done'
+const deleteTaskView = `-- name: DeleteTaskView :execrows
+DELETE FROM task_view WHERE id = $1 AND owner = $2
 `
 
-type UpdateTaskParams struct {
-	ID          pgtype.UUID
-	Title       string
-	Description pgtype.Text
-	Status      TaskStatus
-	Priority    TaskPriority
-	DueDate     pgtype.Date
+type DeleteTaskViewParams struct {
+	ID    pgtype.UUID
+	Owner string
 }
 
-func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (int64, error) {
-	result, err := q.db.Exec(ctx, updateTask,
-		arg.ID,
-		arg.Title,
-		arg.Description,
-		arg.Status,
-		arg.Priority,
-		arg.DueDate,
-	)
+func (q *Queries) DeleteTaskView(ctx context.Context, arg DeleteTaskViewParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskView, arg.ID, arg.Owner)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
+const detachArchivedSubtasks = `-- name: DetachArchivedSubtasks :exec
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY($1::uuid[])
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id WHERE task.archived_at IS NULL
+)
+UPDATE task SET parent_id = NULL
+WHERE archived_at IS NOT NULL AND parent_id IN (SELECT id FROM tree)
+`
+
+func (q *Queries) DetachArchivedSubtasks(ctx context.Context, taskIds []pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, detachArchivedSubtasks, taskIds)
+	return err
+}
+
+const dueTaskReminders = `-- name: DueTaskReminders :many
+SELECT task_reminder.id, task_reminder.login, task_reminder.before_seconds, task.id AS task_id, task.title, task.due_date,
+  task_due_at(task.due_date, task.due_time, task.time_zone) AS due_at
+FROM task_reminder
+JOIN task ON task.id = task_reminder.task_id
+WHERE
+  task.status != $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  task_reminder.sent_for IS DISTINCT FROM task_due_at(task.due_date, task.due_time, task.time_zone) AND
+  task_due_at(task.due_date, task.due_time, task.time_zone) - make_interval(secs => task_reminder.before_seconds) <= $2::timestamp AND
+  (task.owner = task_reminder.login OR task.assignee = task_reminder.login OR task.project_id IN (SELECT project_id FROM project_member WHERE login = task_reminder.login))
+ORDER BY due_at
+`
+
+type DueTaskRemindersParams struct {
+	DoneStatus string
+	Now        pgtype.Timestamp
+}
+
+type DueTaskRemindersRow struct {
+	ID            pgtype.UUID
+	Login         string
+	BeforeSeconds int64
+	TaskID        pgtype.UUID
+	Title         string
+	DueDate       pgtype.Date
+	DueAt         pgtype.Timestamp
+}
+
+func (q *Queries) DueTaskReminders(ctx context.Context, arg DueTaskRemindersParams) ([]DueTaskRemindersRow, error) {
+	rows, err := q.db.Query(ctx, dueTaskReminders, arg.DoneStatus, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DueTaskRemindersRow
+	for rows.Next() {
+		var i DueTaskRemindersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Login,
+			&i.BeforeSeconds,
+			&i.TaskID,
+			&i.Title,
+			&i.DueDate,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertLabel = `-- name: InsertLabel :exec
+INSERT INTO label
+  (id, name, owner, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5)
+`
+
+type InsertLabelParams struct {
+	ID        pgtype.UUID
+	Name      string
+	Owner     string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) InsertLabel(ctx context.Context, arg InsertLabelParams) error {
+	_, err := q.db.Exec(ctx, insertLabel,
+		arg.ID,
+		arg.Name,
+		arg.Owner,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const insertProject = `-- name: InsertProject :exec
+INSERT INTO project
+  (id, name, description, owner, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertProjectParams struct {
+	ID          pgtype.UUID
+	Name        string
+	Description pgtype.Text
+	Owner       string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) InsertProject(ctx context.Context, arg InsertProjectParams) error {
+	_, err := q.db.Exec(ctx, insertProject,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.Owner,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const insertTask = `-- name: InsertTask :exec
+INSERT INTO task
+  (id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, position, due_time, time_zone)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, coalesce($16::text, (SELECT "user".time_zone FROM "user" WHERE login = $9)))
+`
+
+type InsertTaskParams struct {
+	ID          pgtype.UUID
+	Title       string
+	Description pgtype.Text
+	Status      string
+	Priority    TaskPriority
+	DueDate     pgtype.Date
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	Owner       string
+	ProjectID   pgtype.UUID
+	ParentID    pgtype.UUID
+	Recurrence  pgtype.Text
+	Assignee    pgtype.Text
+	Position    string
+	DueTime     pgtype.Time
+	TimeZone    pgtype.Text
+}
+
+func (q *Queries) InsertTask(ctx context.Context, arg InsertTaskParams) error {
+	_, err := q.db.Exec(ctx, insertTask,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Status,
+		arg.Priority,
+		arg.DueDate,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Owner,
+		arg.ProjectID,
+		arg.ParentID,
+		arg.Recurrence,
+		arg.Assignee,
+		arg.Position,
+		arg.DueTime,
+		arg.TimeZone,
+	)
+	return err
+}
+
+const insertTaskAttachment = `-- name: InsertTaskAttachment :exec
+INSERT INTO task_attachment
+  (id, task_id, name, content_type, size, uploader, created_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type InsertTaskAttachmentParams struct {
+	ID          pgtype.UUID
+	TaskID      pgtype.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Uploader    string
+	CreatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) InsertTaskAttachment(ctx context.Context, arg InsertTaskAttachmentParams) error {
+	_, err := q.db.Exec(ctx, insertTaskAttachment,
+		arg.ID,
+		arg.TaskID,
+		arg.Name,
+		arg.ContentType,
+		arg.Size,
+		arg.Uploader,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertTaskComment = `-- name: InsertTaskComment :exec
+INSERT INTO task_comment
+  (id, task_id, author, body, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertTaskCommentParams struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	Author    string
+	Body      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) InsertTaskComment(ctx context.Context, arg InsertTaskCommentParams) error {
+	_, err := q.db.Exec(ctx, insertTaskComment,
+		arg.ID,
+		arg.TaskID,
+		arg.Author,
+		arg.Body,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const insertTaskDependency = `-- name: InsertTaskDependency :exec
+INSERT INTO task_dependency (task_id, blocked_by_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type InsertTaskDependencyParams struct {
+	TaskID      pgtype.UUID
+	BlockedByID pgtype.UUID
+}
+
+func (q *Queries) InsertTaskDependency(ctx context.Context, arg InsertTaskDependencyParams) error {
+	_, err := q.db.Exec(ctx, insertTaskDependency, arg.TaskID, arg.BlockedByID)
+	return err
+}
+
+const insertTaskEvent = `-- name: InsertTaskEvent :exec
+INSERT INTO task_event
+  (id, task_id, actor, kind, changes, created_at, reason)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type InsertTaskEventParams struct {
+	ID        pgtype.UUID
+	TaskID    pgtype.UUID
+	Actor     string
+	Kind      TaskEventKind
+	Changes   []byte
+	CreatedAt pgtype.Timestamp
+	Reason    pgtype.Text
+}
+
+func (q *Queries) InsertTaskEvent(ctx context.Context, arg InsertTaskEventParams) error {
+	_, err := q.db.Exec(ctx, insertTaskEvent,
+		arg.ID,
+		arg.TaskID,
+		arg.Actor,
+		arg.Kind,
+		arg.Changes,
+		arg.CreatedAt,
+		arg.Reason,
+	)
+	return err
+}
+
+const insertTaskLabels = `-- name: InsertTaskLabels :exec
+INSERT INTO task_label (task_id, label_id)
+SELECT $1::uuid, label.id FROM label
+WHERE label.id = ANY($2::uuid[]) AND label.owner = $3
+ON CONFLICT DO NOTHING
+`
+
+type InsertTaskLabelsParams struct {
+	TaskID   pgtype.UUID
+	LabelIds []pgtype.UUID
+	Owner    string
+}
+
+func (q *Queries) InsertTaskLabels(ctx context.Context, arg InsertTaskLabelsParams) error {
+	_, err := q.db.Exec(ctx, insertTaskLabels, arg.TaskID, arg.LabelIds, arg.Owner)
+	return err
+}
+
+const insertTaskReminder = `-- name: InsertTaskReminder :exec
+INSERT INTO task_reminder
+  (id, task_id, login, before_seconds, created_at)
+VALUES
+  ($1, $2, $3, $4, $5)
+`
+
+type InsertTaskReminderParams struct {
+	ID            pgtype.UUID
+	TaskID        pgtype.UUID
+	Login         string
+	BeforeSeconds int64
+	CreatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) InsertTaskReminder(ctx context.Context, arg InsertTaskReminderParams) error {
+	_, err := q.db.Exec(ctx, insertTaskReminder,
+		arg.ID,
+		arg.TaskID,
+		arg.Login,
+		arg.BeforeSeconds,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertTaskView = `-- name: InsertTaskView :exec
+INSERT INTO task_view
+  (id, name, owner, filter, sort, created_at, updated_at)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type InsertTaskViewParams struct {
+	ID        pgtype.UUID
+	Name      string
+	Owner     string
+	Filter    []byte
+	Sort      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) InsertTaskView(ctx context.Context, arg InsertTaskViewParams) error {
+	_, err := q.db.Exec(ctx, insertTaskView,
+		arg.ID,
+		arg.Name,
+		arg.Owner,
+		arg.Filter,
+		arg.Sort,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const insertUser = `-- name: InsertUser :exec
+INSERT INTO "user" (login, password_hash) VALUES ($1, $2)
+`
+
+type InsertUserParams struct {
+	Login        string
+	PasswordHash []byte
+}
+
+func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) error {
+	_, err := q.db.Exec(ctx, insertUser, arg.Login, arg.PasswordHash)
+	return err
+}
+
+const isTaskDeleted = `-- name: IsTaskDeleted :one
+SELECT deleted_at IS NOT NULL FROM task WHERE id = $1
+`
+
+func (q *Queries) IsTaskDeleted(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isTaskDeleted, id)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const isTaskDescendant = `-- name: IsTaskDescendant :one
+WITH RECURSIVE ancestor AS (
+  SELECT task.id, task.parent_id FROM task WHERE task.id = $1
+  UNION
+  SELECT task.id, task.parent_id FROM task
+  JOIN ancestor ON task.id = ancestor.parent_id
+)
+SELECT EXISTS (SELECT 1 FROM ancestor WHERE ancestor.id = $2)
+`
+
+type IsTaskDescendantParams struct {
+	ID         pgtype.UUID
+	AncestorID pgtype.UUID
+}
+
+func (q *Queries) IsTaskDescendant(ctx context.Context, arg IsTaskDescendantParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isTaskDescendant, arg.ID, arg.AncestorID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const labelById = `-- name: LabelById :one
+SELECT id, name, owner, created_at, updated_at FROM label WHERE id = $1 AND owner = $2
+`
+
+type LabelByIdParams struct {
+	ID    pgtype.UUID
+	Owner string
+}
+
+func (q *Queries) LabelById(ctx context.Context, arg LabelByIdParams) (Label, error) {
+	row := q.db.QueryRow(ctx, labelById, arg.ID, arg.Owner)
+	var i Label
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Owner,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const labelsByOwner = `-- name: LabelsByOwner :many
+SELECT id, name, owner, created_at, updated_at FROM label WHERE owner = $1 ORDER BY name
+`
+
+func (q *Queries) LabelsByOwner(ctx context.Context, owner string) ([]Label, error) {
+	rows, err := q.db.Query(ctx, labelsByOwner, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Label
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Owner,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markTaskReminderSent = `-- name: MarkTaskReminderSent :exec
+UPDATE task_reminder SET sent_for = $2 WHERE id = $1
+`
+
+type MarkTaskReminderSentParams struct {
+	ID      pgtype.UUID
+	SentFor pgtype.Timestamp
+}
+
+func (q *Queries) MarkTaskReminderSent(ctx context.Context, arg MarkTaskReminderSentParams) error {
+	_, err := q.db.Exec(ctx, markTaskReminderSent, arg.ID, arg.SentFor)
+	return err
+}
+
+const moveTask = `-- name: MoveTask :execrows
+UPDATE task SET
+  status = $2,
+  position = $3,
+  version = version + 1,
+  updated_at = CASE WHEN status = $2 THEN updated_at ELSE $5 END
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $4 OR task.assignee = $4 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $4))
+`
+
+type MoveTaskParams struct {
+	ID        pgtype.UUID
+	Status    string
+	Position  string
+	Owner     string
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) MoveTask(ctx context.Context, arg MoveTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveTask,
+		arg.ID,
+		arg.Status,
+		arg.Position,
+		arg.Owner,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const nextTaskPosition = `-- name: NextTaskPosition :one
+SELECT position FROM task
+WHERE
+  status = $1 AND id != $2 AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $3 OR assignee = $3 OR project_id IN (SELECT project_id FROM project_member WHERE login = $3)) AND
+  position > $4
+ORDER BY position
+LIMIT 1
+`
+
+type NextTaskPositionParams struct {
+	Status string
+	ID     pgtype.UUID
+	Login  string
+	After  string
+}
+
+func (q *Queries) NextTaskPosition(ctx context.Context, arg NextTaskPositionParams) (string, error) {
+	row := q.db.QueryRow(ctx, nextTaskPosition,
+		arg.Status,
+		arg.ID,
+		arg.Login,
+		arg.After,
+	)
+	var position string
+	err := row.Scan(&position)
+	return position, err
+}
+
+const overdueTasksIds = `-- name: OverdueTasksIds :many
+SELECT id FROM task
+WHERE
+  status != $1 AND task_deadline(due_date, due_time, time_zone) < $2::timestamp AND deleted_at IS NULL AND archived_at IS NULL AND
+  (cardinality($3::text[]) = 0 OR priority::text = ANY($3::text[])) AND
+  (NOT $4::boolean OR NOT EXISTS (SELECT 1 FROM task_comment WHERE task_comment.task_id = task.id)) AND
+  (NOT $5::boolean OR NOT EXISTS (SELECT 1 FROM task_label WHERE task_label.task_id = task.id))
+`
+
+type OverdueTasksIdsParams struct {
+	DoneStatus       string
+	DueBefore        pgtype.Timestamp
+	Priorities       []string
+	SkipWithComments bool
+	SkipWithLabels   bool
+}
+
+func (q *Queries) OverdueTasksIds(ctx context.Context, arg OverdueTasksIdsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, overdueTasksIds,
+		arg.DoneStatus,
+		arg.DueBefore,
+		arg.Priorities,
+		arg.SkipWithComments,
+		arg.SkipWithLabels,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const prevTaskPosition = `-- name: PrevTaskPosition :one
+SELECT position FROM task
+WHERE
+  status = $1 AND id != $2 AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $3 OR assignee = $3 OR project_id IN (SELECT project_id FROM project_member WHERE login = $3)) AND
+  ($4::text IS NULL OR position < $4)
+ORDER BY position DESC
+LIMIT 1
+`
+
+type PrevTaskPositionParams struct {
+	Status string
+	ID     pgtype.UUID
+	Login  string
+	Before pgtype.Text
+}
+
+func (q *Queries) PrevTaskPosition(ctx context.Context, arg PrevTaskPositionParams) (string, error) {
+	row := q.db.QueryRow(ctx, prevTaskPosition,
+		arg.Status,
+		arg.ID,
+		arg.Login,
+		arg.Before,
+	)
+	var position string
+	err := row.Scan(&position)
+	return position, err
+}
+
+const projectById = `-- name: ProjectById :one
+SELECT project.id, project.name, project.description, project.owner, project.created_at, project.updated_at FROM project
+JOIN project_member ON project_member.project_id = project.id
+WHERE project.id = $1 AND project_member.login = $2
+`
+
+type ProjectByIdParams struct {
+	ID    pgtype.UUID
+	Login string
+}
+
+func (q *Queries) ProjectById(ctx context.Context, arg ProjectByIdParams) (Project, error) {
+	row := q.db.QueryRow(ctx, projectById, arg.ID, arg.Login)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Owner,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const projectMemberRole = `-- name: ProjectMemberRole :one
+SELECT role FROM project_member WHERE project_id = $1 AND login = $2
+`
+
+type ProjectMemberRoleParams struct {
+	ProjectID pgtype.UUID
+	Login     string
+}
+
+func (q *Queries) ProjectMemberRole(ctx context.Context, arg ProjectMemberRoleParams) (ProjectRole, error) {
+	row := q.db.QueryRow(ctx, projectMemberRole, arg.ProjectID, arg.Login)
+	var role ProjectRole
+	err := row.Scan(&role)
+	return role, err
+}
+
+const projectMembers = `-- name: ProjectMembers :many
+SELECT project_id, login, role FROM project_member WHERE project_id = $1 ORDER BY login
+`
+
+func (q *Queries) ProjectMembers(ctx context.Context, projectID pgtype.UUID) ([]ProjectMember, error) {
+	rows, err := q.db.Query(ctx, projectMembers, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProjectMember
+	for rows.Next() {
+		var i ProjectMember
+		if err := rows.Scan(&i.ProjectID, &i.Login, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const projectsByMember = `-- name: ProjectsByMember :many
+SELECT project.id, project.name, project.description, project.owner, project.created_at, project.updated_at FROM project
+JOIN project_member ON project_member.project_id = project.id
+WHERE project_member.login = $1
+ORDER BY project.created_at
+`
+
+func (q *Queries) ProjectsByMember(ctx context.Context, login string) ([]Project, error) {
+	rows, err := q.db.Query(ctx, projectsByMember, login)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Owner,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedTasks = `-- name: PurgeDeletedTasks :exec
+DELETE FROM task WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedTasks(ctx context.Context, deletedAt pgtype.Timestamp) error {
+	_, err := q.db.Exec(ctx, purgeDeletedTasks, deletedAt)
+	return err
+}
+
+const reopenTask = `-- name: ReopenTask :execrows
+UPDATE task SET
+  status = $1,
+  position = $2,
+  version = version + 1,
+  updated_at = $3
+WHERE
+  task.id = $4 AND task.status = $5 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $6 OR task.assignee = $6 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $6))
+`
+
+type ReopenTaskParams struct {
+	Status     string
+	Position   string
+	UpdatedAt  pgtype.Timestamp
+	ID         pgtype.UUID
+	DoneStatus string
+	Owner      string
+}
+
+func (q *Queries) ReopenTask(ctx context.Context, arg ReopenTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reopenTask,
+		arg.Status,
+		arg.Position,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.DoneStatus,
+		arg.Owner,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreTasks = `-- name: RestoreTasks :exec
+UPDATE task SET deleted_at = NULL WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) RestoreTasks(ctx context.Context, taskIds []pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, restoreTasks, taskIds)
+	return err
+}
+
+const taskAttachmentById = `-- name: TaskAttachmentById :one
+SELECT id, task_id, name, content_type, size, uploader, created_at FROM task_attachment WHERE id = $1 AND task_id = $2
+`
+
+type TaskAttachmentByIdParams struct {
+	ID     pgtype.UUID
+	TaskID pgtype.UUID
+}
+
+func (q *Queries) TaskAttachmentById(ctx context.Context, arg TaskAttachmentByIdParams) (TaskAttachment, error) {
+	row := q.db.QueryRow(ctx, taskAttachmentById, arg.ID, arg.TaskID)
+	var i TaskAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.Uploader,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const taskAttachments = `-- name: TaskAttachments :many
+SELECT id, task_id, name, content_type, size, uploader, created_at FROM task_attachment
+WHERE task_id = ANY($1::uuid[])
+ORDER BY created_at
+`
+
+func (q *Queries) TaskAttachments(ctx context.Context, taskIds []pgtype.UUID) ([]TaskAttachment, error) {
+	rows, err := q.db.Query(ctx, taskAttachments, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskAttachment
+	for rows.Next() {
+		var i TaskAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.Uploader,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskBlockers = `-- name: TaskBlockers :many
+SELECT task_dependency.task_id, task.id, task.status FROM task_dependency
+JOIN task ON task.id = task_dependency.blocked_by_id
+WHERE task_dependency.task_id = ANY($1::uuid[]) AND task.deleted_at IS NULL AND task.archived_at IS NULL
+ORDER BY task.due_date
+`
+
+type TaskBlockersRow struct {
+	TaskID pgtype.UUID
+	ID     pgtype.UUID
+	Status string
+}
+
+func (q *Queries) TaskBlockers(ctx context.Context, taskIds []pgtype.UUID) ([]TaskBlockersRow, error) {
+	rows, err := q.db.Query(ctx, taskBlockers, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskBlockersRow
+	for rows.Next() {
+		var i TaskBlockersRow
+		if err := rows.Scan(&i.TaskID, &i.ID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskById = `-- name: TaskById :one
+SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone FROM task
+WHERE
+  id = $1 AND deleted_at IS NULL AND archived_at IS NULL AND
+  (owner = $2 OR assignee = $2 OR project_id IN (SELECT project_id FROM project_member WHERE login = $2))
+`
+
+type TaskByIdParams struct {
+	ID    pgtype.UUID
+	Owner string
+}
+
+func (q *Queries) TaskById(ctx context.Context, arg TaskByIdParams) (Task, error) {
+	row := q.db.QueryRow(ctx, taskById, arg.ID, arg.Owner)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Owner,
+		&i.ProjectID,
+		&i.ParentID,
+		&i.Recurrence,
+		&i.Assignee,
+		&i.Version,
+		&i.Position,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.DueTime,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const taskCommentById = `-- name: TaskCommentById :one
+SELECT id, task_id, author, body, created_at, updated_at FROM task_comment WHERE id = $1 AND task_id = $2
+`
+
+type TaskCommentByIdParams struct {
+	ID     pgtype.UUID
+	TaskID pgtype.UUID
+}
+
+func (q *Queries) TaskCommentById(ctx context.Context, arg TaskCommentByIdParams) (TaskComment, error) {
+	row := q.db.QueryRow(ctx, taskCommentById, arg.ID, arg.TaskID)
+	var i TaskComment
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Author,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const taskComments = `-- name: TaskComments :many
+SELECT id, task_id, author, body, created_at, updated_at FROM task_comment WHERE task_id = $1 ORDER BY created_at
+`
+
+func (q *Queries) TaskComments(ctx context.Context, taskID pgtype.UUID) ([]TaskComment, error) {
+	rows, err := q.db.Query(ctx, taskComments, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskComment
+	for rows.Next() {
+		var i TaskComment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Author,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskEvents = `-- name: TaskEvents :many
+SELECT id, task_id, actor, kind, changes, created_at, reason FROM task_event WHERE task_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) TaskEvents(ctx context.Context, taskID pgtype.UUID) ([]TaskEvent, error) {
+	rows, err := q.db.Query(ctx, taskEvents, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskEvent
+	for rows.Next() {
+		var i TaskEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Actor,
+			&i.Kind,
+			&i.Changes,
+			&i.CreatedAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskLabels = `-- name: TaskLabels :many
+SELECT task_label.task_id, label.id, label.name, label.owner, label.created_at, label.updated_at FROM task_label
+JOIN label ON label.id = task_label.label_id
+WHERE task_label.task_id = ANY($1::uuid[])
+ORDER BY label.name
+`
+
+type TaskLabelsRow struct {
+	TaskID    pgtype.UUID
+	ID        pgtype.UUID
+	Name      string
+	Owner     string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) TaskLabels(ctx context.Context, taskIds []pgtype.UUID) ([]TaskLabelsRow, error) {
+	rows, err := q.db.Query(ctx, taskLabels, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskLabelsRow
+	for rows.Next() {
+		var i TaskLabelsRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.ID,
+			&i.Name,
+			&i.Owner,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskReminders = `-- name: TaskReminders :many
+SELECT id, task_id, login, before_seconds, sent_for, created_at FROM task_reminder
+WHERE task_id = $1 AND login = $2
+ORDER BY before_seconds DESC
+`
+
+type TaskRemindersParams struct {
+	TaskID pgtype.UUID
+	Login  string
+}
+
+func (q *Queries) TaskReminders(ctx context.Context, arg TaskRemindersParams) ([]TaskReminder, error) {
+	rows, err := q.db.Query(ctx, taskReminders, arg.TaskID, arg.Login)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskReminder
+	for rows.Next() {
+		var i TaskReminder
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Login,
+			&i.BeforeSeconds,
+			&i.SentFor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tasksBlockersIds = `-- name: TasksBlockersIds :many
+SELECT DISTINCT blocked_by_id FROM task_dependency
+WHERE task_id = ANY($1::uuid[])
+`
+
+func (q *Queries) TasksBlockersIds(ctx context.Context, taskIds []pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, tasksBlockersIds, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var blocked_by_id pgtype.UUID
+		if err := rows.Scan(&blocked_by_id); err != nil {
+			return nil, err
+		}
+		items = append(items, blocked_by_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tasksByIds = `-- name: TasksByIds :many
+SELECT id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, version, position, deleted_at, archived_at, due_time, time_zone FROM task WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) TasksByIds(ctx context.Context, taskIds []pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, tasksByIds, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Owner,
+			&i.ProjectID,
+			&i.ParentID,
+			&i.Recurrence,
+			&i.Assignee,
+			&i.Version,
+			&i.Position,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.DueTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tasksTree = `-- name: TasksTree :many
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL AND archived_at IS NULL
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id WHERE task.deleted_at IS NULL AND task.archived_at IS NULL
+)
+SELECT task.id, task.title, task.description, task.status, task.priority, task.due_date, task.created_at, task.updated_at, task.owner, task.project_id, task.parent_id, task.recurrence, task.assignee, task.version, task.position, task.deleted_at, task.archived_at, task.due_time, task.time_zone FROM task WHERE task.id IN (SELECT id FROM tree)
+`
+
+func (q *Queries) TasksTree(ctx context.Context, taskIds []pgtype.UUID) ([]Task, error) {
+	rows, err := q.db.Query(ctx, tasksTree, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Owner,
+			&i.ProjectID,
+			&i.ParentID,
+			&i.Recurrence,
+			&i.Assignee,
+			&i.Version,
+			&i.Position,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.DueTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tasksTreeAttachmentsIds = `-- name: TasksTreeAttachmentsIds :many
+WITH RECURSIVE tree AS (
+  SELECT id FROM task WHERE id = ANY($1::uuid[])
+  UNION
+  SELECT task.id FROM task JOIN tree ON task.parent_id = tree.id
+)
+SELECT task_attachment.id FROM task_attachment
+WHERE task_attachment.task_id IN (SELECT id FROM tree)
+`
+
+func (q *Queries) TasksTreeAttachmentsIds(ctx context.Context, taskIds []pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, tasksTreeAttachmentsIds, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const taskVersion = `-- name: TaskVersion :one
+SELECT version FROM task WHERE id = $1 AND deleted_at IS NULL AND archived_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) TaskVersion(ctx context.Context, id pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, taskVersion, id)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const taskViewById = `-- name: TaskViewById :one
+SELECT id, name, owner, filter, sort, created_at, updated_at FROM task_view WHERE id = $1 AND owner = $2
+`
+
+type TaskViewByIdParams struct {
+	ID    pgtype.UUID
+	Owner string
+}
+
+func (q *Queries) TaskViewById(ctx context.Context, arg TaskViewByIdParams) (TaskView, error) {
+	row := q.db.QueryRow(ctx, taskViewById, arg.ID, arg.Owner)
+	var i TaskView
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Owner,
+		&i.Filter,
+		&i.Sort,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const taskViewsByOwner = `-- name: TaskViewsByOwner :many
+SELECT id, name, owner, filter, sort, created_at, updated_at FROM task_view WHERE owner = $1 ORDER BY name
+`
+
+func (q *Queries) TaskViewsByOwner(ctx context.Context, owner string) ([]TaskView, error) {
+	rows, err := q.db.Query(ctx, taskViewsByOwner, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskView
+	for rows.Next() {
+		var i TaskView
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Owner,
+			&i.Filter,
+			&i.Sort,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateLabel = `-- name: UpdateLabel :execrows
+UPDATE label SET
+  name = $3,
+  updated_at = $4
+WHERE
+  id = $1 AND owner = $2
+`
+
+type UpdateLabelParams struct {
+	ID        pgtype.UUID
+	Owner     string
+	Name      string
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpdateLabel(ctx context.Context, arg UpdateLabelParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLabel,
+		arg.ID,
+		arg.Owner,
+		arg.Name,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateProject = `-- name: UpdateProject :execrows
+UPDATE project SET
+  name = $2,
+  description = $3,
+  updated_at = $4
+WHERE
+  project.id = $1
+`
+
+type UpdateProjectParams struct {
+	ID          pgtype.UUID
+	Name        string
+	Description pgtype.Text
+	UpdatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateProject,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateTask = `-- name: UpdateTask :execrows
+UPDATE task SET
+  title = $2,
+  description = $3,
+  status = $4,
+  priority = $5,
+  due_date = $6,
+  project_id = $7,
+  parent_id = $8,
+  recurrence = $9,
+  assignee = $10,
+  due_time = $12,
+  time_zone = coalesce($13::text, time_zone),
+  position = coalesce($15::text, position),
+  version = version + 1,
+  updated_at = $14
+WHERE
+  task.id = $1 AND task.deleted_at IS NULL AND task.archived_at IS NULL AND
+  (task.owner = $11 OR task.assignee = $11 OR task.project_id IN (SELECT project_id FROM project_member WHERE login = $11))
+`
+
+type UpdateTaskParams struct {
+	ID          pgtype.UUID
+	Title       string
+	Description pgtype.Text
+	Status      string
+	Priority    TaskPriority
+	DueDate     pgtype.Date
+	ProjectID   pgtype.UUID
+	ParentID    pgtype.UUID
+	Recurrence  pgtype.Text
+	Assignee    pgtype.Text
+	Owner       string
+	DueTime     pgtype.Time
+	TimeZone    pgtype.Text
+	UpdatedAt   pgtype.Timestamp
+	Position    pgtype.Text
+}
+
+func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTask,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Status,
+		arg.Priority,
+		arg.DueDate,
+		arg.ProjectID,
+		arg.ParentID,
+		arg.Recurrence,
+		arg.Assignee,
+		arg.Owner,
+		arg.DueTime,
+		arg.TimeZone,
+		arg.UpdatedAt,
+		arg.Position,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateTaskComment = `-- name: UpdateTaskComment :execrows
+UPDATE task_comment SET
+  body = $2,
+  updated_at = $3
+WHERE
+  id = $1
+`
+
+type UpdateTaskCommentParams struct {
+	ID        pgtype.UUID
+	Body      string
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpdateTaskComment(ctx context.Context, arg UpdateTaskCommentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTaskComment, arg.ID, arg.Body, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateTaskView = `-- name: UpdateTaskView :execrows
+UPDATE task_view SET
+  name = $3,
+  filter = $4,
+  sort = $5,
+  updated_at = $6
+WHERE
+  id = $1 AND owner = $2
+`
+
+type UpdateTaskViewParams struct {
+	ID        pgtype.UUID
+	Owner     string
+	Name      string
+	Filter    []byte
+	Sort      string
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpdateTaskView(ctx context.Context, arg UpdateTaskViewParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTaskView,
+		arg.ID,
+		arg.Owner,
+		arg.Name,
+		arg.Filter,
+		arg.Sort,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserTimeZone = `-- name: UpdateUserTimeZone :execrows
+UPDATE "user" SET time_zone = $2 WHERE login = $1
+`
+
+type UpdateUserTimeZoneParams struct {
+	Login    string
+	TimeZone string
+}
+
+func (q *Queries) UpdateUserTimeZone(ctx context.Context, arg UpdateUserTimeZoneParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserTimeZone, arg.Login, arg.TimeZone)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertProjectMember = `-- name: UpsertProjectMember :exec
+INSERT INTO project_member (project_id, login, role) VALUES ($1, $2, $3)
+ON CONFLICT (project_id, login) DO UPDATE SET role = EXCLUDED.role
+`
+
+type UpsertProjectMemberParams struct {
+	ProjectID pgtype.UUID
+	Login     string
+	Role      ProjectRole
+}
+
+func (q *Queries) UpsertProjectMember(ctx context.Context, arg UpsertProjectMemberParams) error {
+	_, err := q.db.Exec(ctx, upsertProjectMember, arg.ProjectID, arg.Login, arg.Role)
+	return err
+}
+
 const userById = `-- name: UserById :one
-SELECT login, password_hash FROM "user" WHERE login = $1
+SELECT login, password_hash, time_zone FROM "user" WHERE login = $1
 `
 
 func (q *Queries) UserById(ctx context.Context, login string) (User, error) {
 	row := q.db.QueryRow(ctx, userById, login)
 	var i User
-	err := row.Scan(&i.Login, &i.PasswordHash)
+	err := row.Scan(&i.Login, &i.PasswordHash, &i.TimeZone)
 	return i, err
 }
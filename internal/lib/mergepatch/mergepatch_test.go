@@ -0,0 +1,43 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		err      bool
+	}{
+		{name: "replace", doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "add", doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "remove", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "nested", doc: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":null,"f":"g"}}`, expected: `{"a":{"b":"c","f":"g"}}`},
+		{name: "array", doc: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, expected: `{"a":["c","d"]}`},
+		{name: "non object patch", doc: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		{name: "non object target", doc: `["a"]`, patch: `{"a":"b"}`, expected: `{"a":"b"}`},
+		{name: "invalid patch", doc: `{}`, patch: `{`, err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := mergepatch.Apply([]byte(c.doc), []byte(c.patch))
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result) != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Package tz validates names of the IANA time zones, the time zone
+// database is embedded so the result does not depend on the host
+package tz
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata"
+)
+
+// Default is the time zone of users and tasks without the explicit one
+const Default = "UTC"
+
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// Validate checks that the name is a known IANA time zone name,
+// the local time zone of the host is not accepted
+func Validate(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package tz_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/tz"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		timeZone string
+		err      error
+	}{
+		{name: "utc", timeZone: "UTC"},
+		{name: "region", timeZone: "Europe/Berlin"},
+		{name: "empty", timeZone: "", err: tz.ErrInvalidTimeZone},
+		{name: "local", timeZone: "Local", err: tz.ErrInvalidTimeZone},
+		{name: "unknown", timeZone: "Mars/Olympus", err: tz.ErrInvalidTimeZone},
+		{name: "offset", timeZone: "+03:00", err: tz.ErrInvalidTimeZone},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := tz.Validate(c.timeZone); !errors.Is(err, c.err) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
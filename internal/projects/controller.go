@@ -0,0 +1,296 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+type ProjectsService interface {
+	CreateProject(ctx context.Context, owner string, params ProjectParams) (Project, *shared.ServiceError)
+	Projects(ctx context.Context, login string) ([]Project, *shared.ServiceError)
+	ProjectById(ctx context.Context, login string, id ProjectId) (Project, *shared.ServiceError)
+	UpdateProjectById(ctx context.Context, login string, id ProjectId, params ProjectParams) *shared.ServiceError
+	RemoveProjectById(ctx context.Context, login string, id ProjectId) *shared.ServiceError
+	ProjectMembers(ctx context.Context, login string, id ProjectId) ([]Member, *shared.ServiceError)
+	SetProjectMember(ctx context.Context, login string, id ProjectId, member Member) *shared.ServiceError
+	RemoveProjectMember(ctx context.Context, login string, id ProjectId, member string) *shared.ServiceError
+}
+
+type Controller struct {
+	log             *logger.Logger
+	projectsService ProjectsService
+}
+
+func NewController(
+	router fiber.Router,
+	log *logger.Logger,
+	projectsService ProjectsService,
+) *Controller {
+	c := &Controller{log, projectsService}
+	router.Get("/", c.projects)
+	router.Post("/", c.createProject)
+	router.Get("/:id", c.projectById)
+	router.Put("/:id", c.updateProjectById)
+	router.Delete("/:id", c.removeProjectById)
+	router.Get("/:id/members", c.projectMembers)
+	router.Put("/:id/members/:login", c.setProjectMember)
+	router.Delete("/:id/members/:login", c.removeProjectMember)
+	return c
+}
+
+type CreateProjectDTO struct {
+	Name        string  `json:"name" validate:"required"`
+	Description *string `json:"description,omitempty"`
+}
+
+type ProjectDTO struct {
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Owner       string  `json:"owner"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type MemberDTO struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+}
+
+type SetMemberDTO struct {
+	Role string `json:"role" validate:"required"`
+}
+
+func projectToDTO(p Project) ProjectDTO {
+	return ProjectDTO{
+		Id:          p.Id.String(),
+		Name:        p.Name,
+		Description: p.Description,
+		Owner:       p.Owner,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (pc *Controller) projects(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projects, sErr := pc.projectsService.Projects(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	projectsDto := make([]ProjectDTO, len(projects))
+	for i, p := range projects {
+		projectsDto[i] = projectToDTO(p)
+	}
+	return c.JSON(projectsDto)
+}
+
+func (pc *Controller) createProject(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	params, err := pc.projectParams(c)
+	if err != nil {
+		return err
+	}
+	project, sErr := pc.projectsService.CreateProject(c.Context(), login, params)
+	if sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(projectToDTO(project))
+}
+
+func (pc *Controller) projectById(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	project, sErr := pc.projectsService.ProjectById(c.Context(), login, projectId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(projectToDTO(project))
+}
+
+func (pc *Controller) updateProjectById(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	params, err := pc.projectParams(c)
+	if err != nil {
+		return err
+	}
+	if sErr := pc.projectsService.UpdateProjectById(c.Context(), login, projectId, params); sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (pc *Controller) removeProjectById(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if sErr := pc.projectsService.RemoveProjectById(c.Context(), login, projectId); sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (pc *Controller) projectMembers(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	members, sErr := pc.projectsService.ProjectMembers(c.Context(), login, projectId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	membersDto := make([]MemberDTO, len(members))
+	for i, m := range members {
+		membersDto[i] = MemberDTO{
+			Login: m.Login,
+			Role:  m.Role.String(),
+		}
+	}
+	return c.JSON(membersDto)
+}
+
+func (pc *Controller) setProjectMember(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	var dto SetMemberDTO
+	if err := c.BodyParser(&dto); err != nil {
+		pc.log.Debug(c.Context(), "failed to decode body")
+		return err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		pc.log.Debug(c.Context(), "invalid set member dto struct", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	role, err := ParseRole(dto.Role)
+	if err != nil {
+		pc.log.Debug(c.Context(), "invalid role value", slog.String("role", dto.Role))
+		return fiber_adapter.BadRequest(err)
+	}
+	member := Member{
+		Login: c.Params("login"),
+		Role:  role,
+	}
+	if sErr := pc.projectsService.SetProjectMember(c.Context(), login, projectId, member); sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (pc *Controller) removeProjectMember(c *fiber.Ctx) error {
+	login, err := pc.login(c)
+	if err != nil {
+		return err
+	}
+	projectId, err := pc.projectId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if sErr := pc.projectsService.RemoveProjectMember(c.Context(), login, projectId, c.Params("login")); sErr != nil {
+		logger_adapter.LogServiceError(pc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrProjectNotFound) || errors.Is(sErr.Err, ErrMemberNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (pc *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		pc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
+func (pc *Controller) projectId(c *fiber.Ctx, value string) (ProjectId, error) {
+	projectId, err := ParseProjectId(value)
+	if err != nil {
+		pc.log.Debug(c.Context(), "invalid project id value", slog.String("project_id", value))
+		return projectId, fiber_adapter.BadRequest(err)
+	}
+	return projectId, nil
+}
+
+func (pc *Controller) projectParams(c *fiber.Ctx) (ProjectParams, error) {
+	var dto CreateProjectDTO
+	if err := c.BodyParser(&dto); err != nil {
+		pc.log.Debug(c.Context(), "failed to decode body")
+		return ProjectParams{}, err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		pc.log.Debug(c.Context(), "invalid create project dto struct", sl.Err(err))
+		return ProjectParams{}, fiber_adapter.BadRequest(err)
+	}
+	return ProjectParams{
+		Name:        dto.Name,
+		Description: dto.Description,
+	}, nil
+}
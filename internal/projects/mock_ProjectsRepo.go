@@ -0,0 +1,510 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package projects
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockProjectsRepo is an autogenerated mock type for the ProjectsRepo type
+type MockProjectsRepo struct {
+	mock.Mock
+}
+
+type MockProjectsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProjectsRepo) EXPECT() *MockProjectsRepo_Expecter {
+	return &MockProjectsRepo_Expecter{mock: &_m.Mock}
+}
+
+// ProjectById provides a mock function with given fields: ctx, login, id
+func (_m *MockProjectsRepo) ProjectById(ctx context.Context, login string, id ProjectId) (Project, error) {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectById")
+	}
+
+	var r0 Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ProjectId) (Project, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ProjectId) Project); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Get(0).(Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ProjectId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectsRepo_ProjectById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectById'
+type MockProjectsRepo_ProjectById_Call struct {
+	*mock.Call
+}
+
+// ProjectById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id ProjectId
+func (_e *MockProjectsRepo_Expecter) ProjectById(ctx interface{}, login interface{}, id interface{}) *MockProjectsRepo_ProjectById_Call {
+	return &MockProjectsRepo_ProjectById_Call{Call: _e.mock.On("ProjectById", ctx, login, id)}
+}
+
+func (_c *MockProjectsRepo_ProjectById_Call) Run(run func(ctx context.Context, login string, id ProjectId)) *MockProjectsRepo_ProjectById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ProjectId))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectById_Call) Return(_a0 Project, _a1 error) *MockProjectsRepo_ProjectById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectById_Call) RunAndReturn(run func(context.Context, string, ProjectId) (Project, error)) *MockProjectsRepo_ProjectById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProjectMembers provides a mock function with given fields: ctx, id
+func (_m *MockProjectsRepo) ProjectMembers(ctx context.Context, id ProjectId) ([]Member, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectMembers")
+	}
+
+	var r0 []Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId) ([]Member, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId) []Member); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ProjectId) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectsRepo_ProjectMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectMembers'
+type MockProjectsRepo_ProjectMembers_Call struct {
+	*mock.Call
+}
+
+// ProjectMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ProjectId
+func (_e *MockProjectsRepo_Expecter) ProjectMembers(ctx interface{}, id interface{}) *MockProjectsRepo_ProjectMembers_Call {
+	return &MockProjectsRepo_ProjectMembers_Call{Call: _e.mock.On("ProjectMembers", ctx, id)}
+}
+
+func (_c *MockProjectsRepo_ProjectMembers_Call) Run(run func(ctx context.Context, id ProjectId)) *MockProjectsRepo_ProjectMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProjectId))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectMembers_Call) Return(_a0 []Member, _a1 error) *MockProjectsRepo_ProjectMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectMembers_Call) RunAndReturn(run func(context.Context, ProjectId) ([]Member, error)) *MockProjectsRepo_ProjectMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProjectRole provides a mock function with given fields: ctx, login, id
+func (_m *MockProjectsRepo) ProjectRole(ctx context.Context, login string, id ProjectId) (Role, error) {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectRole")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ProjectId) (Role, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ProjectId) Role); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ProjectId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectsRepo_ProjectRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectRole'
+type MockProjectsRepo_ProjectRole_Call struct {
+	*mock.Call
+}
+
+// ProjectRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id ProjectId
+func (_e *MockProjectsRepo_Expecter) ProjectRole(ctx interface{}, login interface{}, id interface{}) *MockProjectsRepo_ProjectRole_Call {
+	return &MockProjectsRepo_ProjectRole_Call{Call: _e.mock.On("ProjectRole", ctx, login, id)}
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) Run(run func(ctx context.Context, login string, id ProjectId)) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ProjectId))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) Return(_a0 Role, _a1 error) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) RunAndReturn(run func(context.Context, string, ProjectId) (Role, error)) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProjectsByMember provides a mock function with given fields: ctx, login
+func (_m *MockProjectsRepo) ProjectsByMember(ctx context.Context, login string) ([]Project, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectsByMember")
+	}
+
+	var r0 []Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Project, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Project); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectsRepo_ProjectsByMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectsByMember'
+type MockProjectsRepo_ProjectsByMember_Call struct {
+	*mock.Call
+}
+
+// ProjectsByMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *MockProjectsRepo_Expecter) ProjectsByMember(ctx interface{}, login interface{}) *MockProjectsRepo_ProjectsByMember_Call {
+	return &MockProjectsRepo_ProjectsByMember_Call{Call: _e.mock.On("ProjectsByMember", ctx, login)}
+}
+
+func (_c *MockProjectsRepo_ProjectsByMember_Call) Run(run func(ctx context.Context, login string)) *MockProjectsRepo_ProjectsByMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectsByMember_Call) Return(_a0 []Project, _a1 error) *MockProjectsRepo_ProjectsByMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectsByMember_Call) RunAndReturn(run func(context.Context, string) ([]Project, error)) *MockProjectsRepo_ProjectsByMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveProjectById provides a mock function with given fields: ctx, id
+func (_m *MockProjectsRepo) RemoveProjectById(ctx context.Context, id ProjectId) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveProjectById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectsRepo_RemoveProjectById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveProjectById'
+type MockProjectsRepo_RemoveProjectById_Call struct {
+	*mock.Call
+}
+
+// RemoveProjectById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ProjectId
+func (_e *MockProjectsRepo_Expecter) RemoveProjectById(ctx interface{}, id interface{}) *MockProjectsRepo_RemoveProjectById_Call {
+	return &MockProjectsRepo_RemoveProjectById_Call{Call: _e.mock.On("RemoveProjectById", ctx, id)}
+}
+
+func (_c *MockProjectsRepo_RemoveProjectById_Call) Run(run func(ctx context.Context, id ProjectId)) *MockProjectsRepo_RemoveProjectById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProjectId))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_RemoveProjectById_Call) Return(_a0 error) *MockProjectsRepo_RemoveProjectById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectsRepo_RemoveProjectById_Call) RunAndReturn(run func(context.Context, ProjectId) error) *MockProjectsRepo_RemoveProjectById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveProjectMember provides a mock function with given fields: ctx, id, login
+func (_m *MockProjectsRepo) RemoveProjectMember(ctx context.Context, id ProjectId, login string) error {
+	ret := _m.Called(ctx, id, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveProjectMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId, string) error); ok {
+		r0 = rf(ctx, id, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectsRepo_RemoveProjectMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveProjectMember'
+type MockProjectsRepo_RemoveProjectMember_Call struct {
+	*mock.Call
+}
+
+// RemoveProjectMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ProjectId
+//   - login string
+func (_e *MockProjectsRepo_Expecter) RemoveProjectMember(ctx interface{}, id interface{}, login interface{}) *MockProjectsRepo_RemoveProjectMember_Call {
+	return &MockProjectsRepo_RemoveProjectMember_Call{Call: _e.mock.On("RemoveProjectMember", ctx, id, login)}
+}
+
+func (_c *MockProjectsRepo_RemoveProjectMember_Call) Run(run func(ctx context.Context, id ProjectId, login string)) *MockProjectsRepo_RemoveProjectMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProjectId), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_RemoveProjectMember_Call) Return(_a0 error) *MockProjectsRepo_RemoveProjectMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectsRepo_RemoveProjectMember_Call) RunAndReturn(run func(context.Context, ProjectId, string) error) *MockProjectsRepo_RemoveProjectMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveProject provides a mock function with given fields: ctx, project
+func (_m *MockProjectsRepo) SaveProject(ctx context.Context, project Project) error {
+	ret := _m.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Project) error); ok {
+		r0 = rf(ctx, project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectsRepo_SaveProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProject'
+type MockProjectsRepo_SaveProject_Call struct {
+	*mock.Call
+}
+
+// SaveProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project Project
+func (_e *MockProjectsRepo_Expecter) SaveProject(ctx interface{}, project interface{}) *MockProjectsRepo_SaveProject_Call {
+	return &MockProjectsRepo_SaveProject_Call{Call: _e.mock.On("SaveProject", ctx, project)}
+}
+
+func (_c *MockProjectsRepo_SaveProject_Call) Run(run func(ctx context.Context, project Project)) *MockProjectsRepo_SaveProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Project))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_SaveProject_Call) Return(_a0 error) *MockProjectsRepo_SaveProject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectsRepo_SaveProject_Call) RunAndReturn(run func(context.Context, Project) error) *MockProjectsRepo_SaveProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveProjectMember provides a mock function with given fields: ctx, id, member
+func (_m *MockProjectsRepo) SaveProjectMember(ctx context.Context, id ProjectId, member Member) error {
+	ret := _m.Called(ctx, id, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProjectMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId, Member) error); ok {
+		r0 = rf(ctx, id, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectsRepo_SaveProjectMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProjectMember'
+type MockProjectsRepo_SaveProjectMember_Call struct {
+	*mock.Call
+}
+
+// SaveProjectMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ProjectId
+//   - member Member
+func (_e *MockProjectsRepo_Expecter) SaveProjectMember(ctx interface{}, id interface{}, member interface{}) *MockProjectsRepo_SaveProjectMember_Call {
+	return &MockProjectsRepo_SaveProjectMember_Call{Call: _e.mock.On("SaveProjectMember", ctx, id, member)}
+}
+
+func (_c *MockProjectsRepo_SaveProjectMember_Call) Run(run func(ctx context.Context, id ProjectId, member Member)) *MockProjectsRepo_SaveProjectMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProjectId), args[2].(Member))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_SaveProjectMember_Call) Return(_a0 error) *MockProjectsRepo_SaveProjectMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectsRepo_SaveProjectMember_Call) RunAndReturn(run func(context.Context, ProjectId, Member) error) *MockProjectsRepo_SaveProjectMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProjectById provides a mock function with given fields: ctx, id, params, updatedAt
+func (_m *MockProjectsRepo) UpdateProjectById(ctx context.Context, id ProjectId, params ProjectParams, updatedAt time.Time) error {
+	ret := _m.Called(ctx, id, params, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProjectById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProjectId, ProjectParams, time.Time) error); ok {
+		r0 = rf(ctx, id, params, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectsRepo_UpdateProjectById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProjectById'
+type MockProjectsRepo_UpdateProjectById_Call struct {
+	*mock.Call
+}
+
+// UpdateProjectById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ProjectId
+//   - params ProjectParams
+//   - updatedAt time.Time
+func (_e *MockProjectsRepo_Expecter) UpdateProjectById(ctx interface{}, id interface{}, params interface{}, updatedAt interface{}) *MockProjectsRepo_UpdateProjectById_Call {
+	return &MockProjectsRepo_UpdateProjectById_Call{Call: _e.mock.On("UpdateProjectById", ctx, id, params, updatedAt)}
+}
+
+func (_c *MockProjectsRepo_UpdateProjectById_Call) Run(run func(ctx context.Context, id ProjectId, params ProjectParams, updatedAt time.Time)) *MockProjectsRepo_UpdateProjectById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProjectId), args[2].(ProjectParams), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_UpdateProjectById_Call) Return(_a0 error) *MockProjectsRepo_UpdateProjectById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectsRepo_UpdateProjectById_Call) RunAndReturn(run func(context.Context, ProjectId, ProjectParams, time.Time) error) *MockProjectsRepo_UpdateProjectById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProjectsRepo creates a new instance of MockProjectsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProjectsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProjectsRepo {
+	mock := &MockProjectsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
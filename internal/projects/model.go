@@ -0,0 +1,116 @@
+package projects
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrProjectNotFound = errors.New("project not found")
+var ErrInvalidProjectName = errors.New("invalid project name")
+var ErrInvalidRole = errors.New("invalid role")
+var ErrMemberNotFound = errors.New("member not found")
+var ErrUserNotFound = errors.New("user not found")
+var ErrProjectOwnerMembership = errors.New("project owner membership can't be changed")
+
+type Role string
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roles[string(r)]
+	return ok
+}
+
+// CanWrite reports whether the role allows to modify the project
+// and its tasks.
+func (r Role) CanWrite() bool {
+	return r == Owner || r == Editor
+}
+
+// CanManage reports whether the role allows to modify or remove
+// the project itself and its members.
+func (r Role) CanManage() bool {
+	return r == Owner
+}
+
+const (
+	Owner  Role = "owner"
+	Editor Role = "editor"
+	Viewer Role = "viewer"
+)
+
+var roles = map[string]Role{
+	string(Owner):  Owner,
+	string(Editor): Editor,
+	string(Viewer): Viewer,
+}
+
+func ParseRole(r string) (Role, error) {
+	role, ok := roles[r]
+	if !ok {
+		return Viewer, ErrInvalidRole
+	}
+	return role, nil
+}
+
+type ProjectId uuid.UUID
+
+func (id ProjectId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewProjectId() ProjectId {
+	return ProjectId(uuid.New())
+}
+
+func ParseProjectId(id string) (ProjectId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return ProjectId(uuid.Nil), err
+	}
+	return ProjectId(uid), nil
+}
+
+type Project struct {
+	Id          ProjectId
+	Name        string
+	Description *string
+	Owner       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ProjectParams struct {
+	Name        string
+	Description *string
+}
+
+func NewProject(
+	projectId ProjectId,
+	name string,
+	description *string,
+	owner string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (Project, error) {
+	if len(name) == 0 {
+		return Project{}, ErrInvalidProjectName
+	}
+	return Project{
+		Id:          projectId,
+		Name:        name,
+		Description: description,
+		Owner:       owner,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+type Member struct {
+	Login string
+	Role  Role
+}
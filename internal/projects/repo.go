@@ -0,0 +1,224 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+)
+
+type Repo struct {
+	log     *logger.Logger
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewRepo(
+	log *logger.Logger,
+	pool *pgxpool.Pool,
+	queries *db.Queries,
+) *Repo {
+	return &Repo{log, pool, queries}
+}
+
+func (r *Repo) SaveProject(ctx context.Context, project Project) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.log.Error(ctx, "failed to rollback transaction", sl.Err(err))
+		}
+	}()
+	queries := r.queries.WithTx(tx)
+	if err := queries.InsertProject(ctx, db.InsertProjectParams{
+		ID:          r.projectIdToPg(project.Id),
+		Name:        project.Name,
+		Description: r.descriptionToPg(project.Description),
+		Owner:       project.Owner,
+		CreatedAt: pgtype.Timestamp{
+			Time:  project.CreatedAt.UTC(),
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamp{
+			Time:  project.UpdatedAt.UTC(),
+			Valid: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to insert project: %w", err)
+	}
+	if err := queries.UpsertProjectMember(ctx, db.UpsertProjectMemberParams{
+		ProjectID: r.projectIdToPg(project.Id),
+		Login:     project.Owner,
+		Role:      db.ProjectRoleOwner,
+	}); err != nil {
+		return fmt.Errorf("failed to insert project owner: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *Repo) ProjectById(ctx context.Context, login string, id ProjectId) (Project, error) {
+	row, err := r.queries.ProjectById(ctx, db.ProjectByIdParams{
+		ID:    r.projectIdToPg(id),
+		Login: login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Project{}, ErrProjectNotFound
+	}
+	if err != nil {
+		return Project{}, err
+	}
+	return r.projectFromPg(row)
+}
+
+func (r *Repo) ProjectsByMember(ctx context.Context, login string) ([]Project, error) {
+	rows, err := r.queries.ProjectsByMember(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]Project, len(rows))
+	for i, row := range rows {
+		if projects[i], err = r.projectFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	return projects, nil
+}
+
+func (r *Repo) ProjectRole(ctx context.Context, login string, id ProjectId) (Role, error) {
+	role, err := r.queries.ProjectMemberRole(ctx, db.ProjectMemberRoleParams{
+		ProjectID: r.projectIdToPg(id),
+		Login:     login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Viewer, ErrProjectNotFound
+	}
+	if err != nil {
+		return Viewer, err
+	}
+	return ParseRole(string(role))
+}
+
+func (r *Repo) UpdateProjectById(
+	ctx context.Context,
+	id ProjectId,
+	params ProjectParams,
+	updatedAt time.Time,
+) error {
+	rowsAffected, err := r.queries.UpdateProject(ctx, db.UpdateProjectParams{
+		ID:          r.projectIdToPg(id),
+		Name:        params.Name,
+		Description: r.descriptionToPg(params.Description),
+		UpdatedAt: pgtype.Timestamp{
+			Time:  updatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+func (r *Repo) RemoveProjectById(ctx context.Context, id ProjectId) error {
+	rowsAffected, err := r.queries.DeleteProject(ctx, r.projectIdToPg(id))
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+func (r *Repo) ProjectMembers(ctx context.Context, id ProjectId) ([]Member, error) {
+	rows, err := r.queries.ProjectMembers(ctx, r.projectIdToPg(id))
+	if err != nil {
+		return nil, err
+	}
+	members := make([]Member, len(rows))
+	for i, row := range rows {
+		role, err := ParseRole(string(row.Role))
+		if err != nil {
+			return nil, err
+		}
+		members[i] = Member{
+			Login: row.Login,
+			Role:  role,
+		}
+	}
+	return members, nil
+}
+
+func (r *Repo) SaveProjectMember(ctx context.Context, id ProjectId, member Member) error {
+	err := r.queries.UpsertProjectMember(ctx, db.UpsertProjectMemberParams{
+		ProjectID: r.projectIdToPg(id),
+		Login:     member.Login,
+		Role:      db.ProjectRole(member.Role),
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func (r *Repo) RemoveProjectMember(ctx context.Context, id ProjectId, login string) error {
+	rowsAffected, err := r.queries.DeleteProjectMember(ctx, db.DeleteProjectMemberParams{
+		ProjectID: r.projectIdToPg(id),
+		Login:     login,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *Repo) projectFromPg(row db.Project) (Project, error) {
+	return NewProject(
+		row.ID.Bytes,
+		row.Name,
+		r.descriptionFromPg(row.Description),
+		row.Owner,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+func (r *Repo) projectIdToPg(id ProjectId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func (r *Repo) descriptionToPg(d *string) pgtype.Text {
+	var t pgtype.Text
+	if d != nil {
+		t.String = *d
+		t.Valid = true
+	}
+	return t
+}
+
+func (r *Repo) descriptionFromPg(t pgtype.Text) *string {
+	if t.Valid {
+		return &t.String
+	}
+	return nil
+}
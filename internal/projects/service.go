@@ -0,0 +1,177 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+type ProjectsRepo interface {
+	SaveProject(ctx context.Context, project Project) error
+	ProjectById(ctx context.Context, login string, id ProjectId) (Project, error)
+	ProjectsByMember(ctx context.Context, login string) ([]Project, error)
+	ProjectRole(ctx context.Context, login string, id ProjectId) (Role, error)
+	UpdateProjectById(ctx context.Context, id ProjectId, params ProjectParams, updatedAt time.Time) error
+	RemoveProjectById(ctx context.Context, id ProjectId) error
+	ProjectMembers(ctx context.Context, id ProjectId) ([]Member, error)
+	SaveProjectMember(ctx context.Context, id ProjectId, member Member) error
+	RemoveProjectMember(ctx context.Context, id ProjectId, login string) error
+}
+
+type Service struct {
+	log          *logger.Logger
+	projectsRepo ProjectsRepo
+}
+
+func NewService(
+	log *logger.Logger,
+	repo ProjectsRepo,
+) *Service {
+	return &Service{log, repo}
+}
+
+func (s *Service) CreateProject(ctx context.Context, owner string, params ProjectParams) (Project, *shared.ServiceError) {
+	now := time.Now()
+	project, err := NewProject(
+		NewProjectId(),
+		params.Name,
+		params.Description,
+		owner,
+		now,
+		now,
+	)
+	if err != nil {
+		return project, shared.NewServiceError(err, "failed to create project")
+	}
+	if err := s.projectsRepo.SaveProject(ctx, project); err != nil {
+		return project, shared.NewUnexpectedError(err, "failed to save project")
+	}
+	return project, nil
+}
+
+func (s *Service) Projects(ctx context.Context, login string) ([]Project, *shared.ServiceError) {
+	projects, err := s.projectsRepo.ProjectsByMember(ctx, login)
+	if err != nil {
+		return projects, shared.NewUnexpectedError(err, "failed to load projects")
+	}
+	return projects, nil
+}
+
+func (s *Service) ProjectById(ctx context.Context, login string, id ProjectId) (Project, *shared.ServiceError) {
+	project, err := s.projectsRepo.ProjectById(ctx, login, id)
+	if errors.Is(err, ErrProjectNotFound) {
+		return project, shared.NewServiceError(err, fmt.Sprintf("project with id %q not found", id.String()))
+	}
+	if err != nil {
+		return project, shared.NewUnexpectedError(err, "failed to load project")
+	}
+	return project, nil
+}
+
+func (s *Service) UpdateProjectById(ctx context.Context, login string, id ProjectId, params ProjectParams) *shared.ServiceError {
+	if len(params.Name) == 0 {
+		return shared.NewServiceError(ErrInvalidProjectName, "failed to update project")
+	}
+	if err := s.checkRole(ctx, login, id, Role.CanWrite); err != nil {
+		return err
+	}
+	err := s.projectsRepo.UpdateProjectById(ctx, id, params, time.Now())
+	if errors.Is(err, ErrProjectNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("project with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to update project")
+	}
+	return nil
+}
+
+func (s *Service) RemoveProjectById(ctx context.Context, login string, id ProjectId) *shared.ServiceError {
+	if err := s.checkRole(ctx, login, id, Role.CanManage); err != nil {
+		return err
+	}
+	err := s.projectsRepo.RemoveProjectById(ctx, id)
+	if errors.Is(err, ErrProjectNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("project with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove project")
+	}
+	return nil
+}
+
+func (s *Service) ProjectMembers(ctx context.Context, login string, id ProjectId) ([]Member, *shared.ServiceError) {
+	if err := s.checkRole(ctx, login, id, Role.IsValid); err != nil {
+		return nil, err
+	}
+	members, err := s.projectsRepo.ProjectMembers(ctx, id)
+	if err != nil {
+		return members, shared.NewUnexpectedError(err, "failed to load project members")
+	}
+	return members, nil
+}
+
+func (s *Service) SetProjectMember(ctx context.Context, login string, id ProjectId, member Member) *shared.ServiceError {
+	if !member.Role.IsValid() {
+		return shared.NewServiceError(ErrInvalidRole, "failed to set project member")
+	}
+	if err := s.checkMemberChange(ctx, login, id, member.Login); err != nil {
+		return err
+	}
+	err := s.projectsRepo.SaveProjectMember(ctx, id, member)
+	if errors.Is(err, ErrUserNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("user %q not found", member.Login))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to save project member")
+	}
+	return nil
+}
+
+func (s *Service) RemoveProjectMember(ctx context.Context, login string, id ProjectId, member string) *shared.ServiceError {
+	if err := s.checkMemberChange(ctx, login, id, member); err != nil {
+		return err
+	}
+	err := s.projectsRepo.RemoveProjectMember(ctx, id, member)
+	if errors.Is(err, ErrMemberNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("member %q not found", member))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove project member")
+	}
+	return nil
+}
+
+func (s *Service) checkMemberChange(ctx context.Context, login string, id ProjectId, member string) *shared.ServiceError {
+	if err := s.checkRole(ctx, login, id, Role.CanManage); err != nil {
+		return err
+	}
+	project, err := s.projectsRepo.ProjectById(ctx, login, id)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load project")
+	}
+	if project.Owner == member {
+		return shared.NewServiceError(ErrProjectOwnerMembership, "failed to change project member")
+	}
+	return nil
+}
+
+func (s *Service) checkRole(ctx context.Context, login string, id ProjectId, allowed func(Role) bool) *shared.ServiceError {
+	role, err := s.projectsRepo.ProjectRole(ctx, login, id)
+	if errors.Is(err, ErrProjectNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("project with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load project role")
+	}
+	if !allowed(role) {
+		return shared.NewServiceError(
+			shared.ErrForbidden,
+			fmt.Sprintf("%q role is not allowed to perform this action", role.String()),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package projects_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+)
+
+const owner = "owner"
+
+func newTestService(t *testing.T, setup func(repo *projects.MockProjectsRepo)) *projects.Service {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	repo := projects.NewMockProjectsRepo(t)
+	if setup != nil {
+		setup(repo)
+	}
+	return projects.NewService(
+		log,
+		repo,
+	)
+}
+
+func TestServiceCreateProject(t *testing.T) {
+	params := projects.ProjectParams{
+		Name: "name",
+	}
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *projects.Service
+		params  projects.ProjectParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				paramsMatcher := mock.MatchedBy(func(p projects.Project) bool {
+					return p.Name == params.Name && p.Owner == owner
+				})
+				repo.EXPECT().SaveProject(mock.Anything, paramsMatcher).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  projects.ProjectParams{},
+			err:     shared.NewServiceError(projects.ErrInvalidProjectName, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().SaveProject(mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := c.service.CreateProject(t.Context(), owner, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceProjectById(t *testing.T) {
+	now := time.Now()
+	project, pErr := projects.NewProject(
+		projects.NewProjectId(),
+		"name",
+		nil,
+		owner,
+		now,
+		now,
+	)
+	if pErr != nil {
+		t.Fatal("failed to prepare project")
+	}
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *projects.Service
+		project projects.Project
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectById(mock.Anything, owner, project.Id).Return(project, nil)
+			}),
+			project: project,
+		},
+		{
+			name: "project not found",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectById(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Project{}, projects.ErrProjectNotFound)
+			}),
+			err: shared.NewServiceError(projects.ErrProjectNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectById(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Project{}, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			project, err := c.service.ProjectById(t.Context(), owner, project.Id)
+			if err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(c.project, project) {
+				t.Fatalf("expected project %v, but got %v", c.project, project)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateProjectById(t *testing.T) {
+	projectId := projects.NewProjectId()
+	params := projects.ProjectParams{
+		Name: "new name",
+	}
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *projects.Service
+		params  projects.ProjectParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Editor, nil)
+				repo.EXPECT().
+					UpdateProjectById(mock.Anything, projectId, params, mock.AnythingOfType("time.Time")).
+					Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  projects.ProjectParams{},
+			err:     shared.NewServiceError(projects.ErrInvalidProjectName, ""),
+		},
+		{
+			name: "project not found",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Viewer, projects.ErrProjectNotFound)
+			}),
+			params: params,
+			err:    shared.NewServiceError(projects.ErrProjectNotFound, ""),
+		},
+		{
+			name: "read only access",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Viewer, nil)
+			}),
+			params: params,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Owner, nil)
+				repo.EXPECT().
+					UpdateProjectById(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.UpdateProjectById(t.Context(), owner, projectId, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceRemoveProjectById(t *testing.T) {
+	projectId := projects.NewProjectId()
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *projects.Service
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Owner, nil)
+				repo.EXPECT().RemoveProjectById(mock.Anything, projectId).Return(nil)
+			}),
+		},
+		{
+			name: "editor",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Editor, nil)
+			}),
+			err: shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "project not found",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Viewer, projects.ErrProjectNotFound)
+			}),
+			err: shared.NewServiceError(projects.ErrProjectNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, mock.Anything, mock.Anything).
+					Return(projects.Owner, nil)
+				repo.EXPECT().RemoveProjectById(mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RemoveProjectById(t.Context(), owner, projectId); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceSetProjectMember(t *testing.T) {
+	now := time.Now()
+	project, pErr := projects.NewProject(
+		projects.NewProjectId(),
+		"name",
+		nil,
+		owner,
+		now,
+		now,
+	)
+	if pErr != nil {
+		t.Fatal("failed to prepare project")
+	}
+	member := projects.Member{
+		Login: "member",
+		Role:  projects.Viewer,
+	}
+	cases := []struct {
+		name    string
+		service *projects.Service
+		member  projects.Member
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, project.Id).Return(projects.Owner, nil)
+				repo.EXPECT().ProjectById(mock.Anything, owner, project.Id).Return(project, nil)
+				repo.EXPECT().SaveProjectMember(mock.Anything, project.Id, member).Return(nil)
+			}),
+			member: member,
+		},
+		{
+			name:    "invalid role",
+			service: newTestService(t, nil),
+			member: projects.Member{
+				Login: "member",
+				Role:  projects.Role("admin"),
+			},
+			err: shared.NewServiceError(projects.ErrInvalidRole, ""),
+		},
+		{
+			name: "editor",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, project.Id).Return(projects.Editor, nil)
+			}),
+			member: member,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "project owner",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, project.Id).Return(projects.Owner, nil)
+				repo.EXPECT().ProjectById(mock.Anything, owner, project.Id).Return(project, nil)
+			}),
+			member: projects.Member{
+				Login: owner,
+				Role:  projects.Viewer,
+			},
+			err: shared.NewServiceError(projects.ErrProjectOwnerMembership, ""),
+		},
+		{
+			name: "unknown user",
+			service: newTestService(t, func(repo *projects.MockProjectsRepo) {
+				repo.EXPECT().ProjectRole(mock.Anything, owner, project.Id).Return(projects.Owner, nil)
+				repo.EXPECT().ProjectById(mock.Anything, owner, project.Id).Return(project, nil)
+				repo.EXPECT().SaveProjectMember(mock.Anything, project.Id, member).Return(projects.ErrUserNotFound)
+			}),
+			member: member,
+			err:    shared.NewServiceError(projects.ErrUserNotFound, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.SetProjectMember(t.Context(), owner, project.Id, c.member); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
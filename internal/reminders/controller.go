@@ -0,0 +1,192 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type RemindersService interface {
+	Reminders(ctx context.Context, login string, taskId tasks.TaskId) ([]Reminder, *shared.ServiceError)
+	CreateReminder(ctx context.Context, login string, taskId tasks.TaskId, params ReminderParams) (Reminder, *shared.ServiceError)
+	RemoveReminderById(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId) *shared.ServiceError
+	SendDueReminders(ctx context.Context) *shared.ServiceError
+}
+
+type Controller struct {
+	log              *logger.Logger
+	remindersService RemindersService
+}
+
+// NewController registers reminders routes on the tasks router
+func NewController(
+	router fiber.Router,
+	log *logger.Logger,
+	remindersService RemindersService,
+) *Controller {
+	c := &Controller{log, remindersService}
+	router.Get("/:id/reminders", c.reminders)
+	router.Post("/:id/reminders", c.createReminder)
+	router.Delete("/:id/reminders/:reminder_id", c.removeReminderById)
+	return c
+}
+
+type CreateReminderDTO struct {
+	// Before is a duration string like "24h" or "30m"
+	Before string `json:"before" validate:"required"`
+}
+
+type ReminderDTO struct {
+	Id        string `json:"id"`
+	TaskId    string `json:"task_id"`
+	Before    string `json:"before"`
+	CreatedAt string `json:"created_at"`
+}
+
+func reminderToDTO(r Reminder) ReminderDTO {
+	return ReminderDTO{
+		Id:        r.Id.String(),
+		TaskId:    r.TaskId.String(),
+		Before:    r.Before.String(),
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (rc *Controller) SendDueReminders(ctx context.Context) {
+	if err := rc.remindersService.SendDueReminders(ctx); err != nil {
+		rc.log.Error(
+			ctx,
+			"failed to send due reminders",
+			slog.String("message", err.Msg),
+			sl.Err(err.Err),
+		)
+	}
+}
+
+func (rc *Controller) reminders(c *fiber.Ctx) error {
+	login, err := rc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := rc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	reminders, sErr := rc.remindersService.Reminders(c.Context(), login, taskId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(rc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	remindersDto := make([]ReminderDTO, len(reminders))
+	for i, reminder := range reminders {
+		remindersDto[i] = reminderToDTO(reminder)
+	}
+	return c.JSON(remindersDto)
+}
+
+func (rc *Controller) createReminder(c *fiber.Ctx) error {
+	login, err := rc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := rc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	params, err := rc.reminderParams(c)
+	if err != nil {
+		return err
+	}
+	reminder, sErr := rc.remindersService.CreateReminder(c.Context(), login, taskId, params)
+	if sErr != nil {
+		logger_adapter.LogServiceError(rc.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(reminderToDTO(reminder))
+}
+
+func (rc *Controller) removeReminderById(c *fiber.Ctx) error {
+	login, err := rc.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := rc.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	reminderId, err := rc.reminderId(c, c.Params("reminder_id"))
+	if err != nil {
+		return err
+	}
+	if sErr := rc.remindersService.RemoveReminderById(c.Context(), login, taskId, reminderId); sErr != nil {
+		logger_adapter.LogServiceError(rc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrReminderNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (rc *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		rc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
+func (rc *Controller) reminderParams(c *fiber.Ctx) (ReminderParams, error) {
+	var dto CreateReminderDTO
+	if err := c.BodyParser(&dto); err != nil {
+		rc.log.Debug(c.Context(), "failed to decode body")
+		return ReminderParams{}, err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		rc.log.Debug(c.Context(), "invalid create reminder dto struct", sl.Err(err))
+		return ReminderParams{}, fiber_adapter.BadRequest(err)
+	}
+	before, err := time.ParseDuration(dto.Before)
+	if err != nil {
+		rc.log.Debug(c.Context(), "invalid reminder offset value", slog.String("before", dto.Before))
+		return ReminderParams{}, fiber_adapter.BadRequest(err)
+	}
+	return ReminderParams{
+		Before: before,
+	}, nil
+}
+
+func (rc *Controller) taskId(c *fiber.Ctx, value string) (tasks.TaskId, error) {
+	taskId, err := tasks.ParseTaskId(value)
+	if err != nil {
+		rc.log.Debug(c.Context(), "invalid task id value", slog.String("task_id", value))
+		return taskId, fiber_adapter.BadRequest(err)
+	}
+	return taskId, nil
+}
+
+func (rc *Controller) reminderId(c *fiber.Ctx, value string) (ReminderId, error) {
+	reminderId, err := ParseReminderId(value)
+	if err != nil {
+		rc.log.Debug(c.Context(), "invalid reminder id value", slog.String("reminder_id", value))
+		return reminderId, fiber_adapter.BadRequest(err)
+	}
+	return reminderId, nil
+}
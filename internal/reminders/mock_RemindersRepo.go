@@ -0,0 +1,302 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package reminders
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	tasks "github.com/x0k/skillrock-tasks-service/internal/tasks"
+
+	time "time"
+)
+
+// MockRemindersRepo is an autogenerated mock type for the RemindersRepo type
+type MockRemindersRepo struct {
+	mock.Mock
+}
+
+type MockRemindersRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRemindersRepo) EXPECT() *MockRemindersRepo_Expecter {
+	return &MockRemindersRepo_Expecter{mock: &_m.Mock}
+}
+
+// DueNotifications provides a mock function with given fields: ctx, now
+func (_m *MockRemindersRepo) DueNotifications(ctx context.Context, now time.Time) ([]Notification, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DueNotifications")
+	}
+
+	var r0 []Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]Notification, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []Notification); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRemindersRepo_DueNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DueNotifications'
+type MockRemindersRepo_DueNotifications_Call struct {
+	*mock.Call
+}
+
+// DueNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockRemindersRepo_Expecter) DueNotifications(ctx interface{}, now interface{}) *MockRemindersRepo_DueNotifications_Call {
+	return &MockRemindersRepo_DueNotifications_Call{Call: _e.mock.On("DueNotifications", ctx, now)}
+}
+
+func (_c *MockRemindersRepo_DueNotifications_Call) Run(run func(ctx context.Context, now time.Time)) *MockRemindersRepo_DueNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRemindersRepo_DueNotifications_Call) Return(_a0 []Notification, _a1 error) *MockRemindersRepo_DueNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRemindersRepo_DueNotifications_Call) RunAndReturn(run func(context.Context, time.Time) ([]Notification, error)) *MockRemindersRepo_DueNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReminderSent provides a mock function with given fields: ctx, id, dueAt
+func (_m *MockRemindersRepo) MarkReminderSent(ctx context.Context, id ReminderId, dueAt time.Time) error {
+	ret := _m.Called(ctx, id, dueAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReminderSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ReminderId, time.Time) error); ok {
+		r0 = rf(ctx, id, dueAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRemindersRepo_MarkReminderSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReminderSent'
+type MockRemindersRepo_MarkReminderSent_Call struct {
+	*mock.Call
+}
+
+// MarkReminderSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id ReminderId
+//   - dueAt time.Time
+func (_e *MockRemindersRepo_Expecter) MarkReminderSent(ctx interface{}, id interface{}, dueAt interface{}) *MockRemindersRepo_MarkReminderSent_Call {
+	return &MockRemindersRepo_MarkReminderSent_Call{Call: _e.mock.On("MarkReminderSent", ctx, id, dueAt)}
+}
+
+func (_c *MockRemindersRepo_MarkReminderSent_Call) Run(run func(ctx context.Context, id ReminderId, dueAt time.Time)) *MockRemindersRepo_MarkReminderSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ReminderId), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRemindersRepo_MarkReminderSent_Call) Return(_a0 error) *MockRemindersRepo_MarkReminderSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRemindersRepo_MarkReminderSent_Call) RunAndReturn(run func(context.Context, ReminderId, time.Time) error) *MockRemindersRepo_MarkReminderSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemindersByTask provides a mock function with given fields: ctx, login, taskId
+func (_m *MockRemindersRepo) RemindersByTask(ctx context.Context, login string, taskId tasks.TaskId) ([]Reminder, error) {
+	ret := _m.Called(ctx, login, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemindersByTask")
+	}
+
+	var r0 []Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TaskId) ([]Reminder, error)); ok {
+		return rf(ctx, login, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TaskId) []Reminder); ok {
+		r0 = rf(ctx, login, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, tasks.TaskId) error); ok {
+		r1 = rf(ctx, login, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRemindersRepo_RemindersByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemindersByTask'
+type MockRemindersRepo_RemindersByTask_Call struct {
+	*mock.Call
+}
+
+// RemindersByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - taskId tasks.TaskId
+func (_e *MockRemindersRepo_Expecter) RemindersByTask(ctx interface{}, login interface{}, taskId interface{}) *MockRemindersRepo_RemindersByTask_Call {
+	return &MockRemindersRepo_RemindersByTask_Call{Call: _e.mock.On("RemindersByTask", ctx, login, taskId)}
+}
+
+func (_c *MockRemindersRepo_RemindersByTask_Call) Run(run func(ctx context.Context, login string, taskId tasks.TaskId)) *MockRemindersRepo_RemindersByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(tasks.TaskId))
+	})
+	return _c
+}
+
+func (_c *MockRemindersRepo_RemindersByTask_Call) Return(_a0 []Reminder, _a1 error) *MockRemindersRepo_RemindersByTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRemindersRepo_RemindersByTask_Call) RunAndReturn(run func(context.Context, string, tasks.TaskId) ([]Reminder, error)) *MockRemindersRepo_RemindersByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReminderById provides a mock function with given fields: ctx, login, taskId, id
+func (_m *MockRemindersRepo) RemoveReminderById(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId) error {
+	ret := _m.Called(ctx, login, taskId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReminderById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TaskId, ReminderId) error); ok {
+		r0 = rf(ctx, login, taskId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRemindersRepo_RemoveReminderById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReminderById'
+type MockRemindersRepo_RemoveReminderById_Call struct {
+	*mock.Call
+}
+
+// RemoveReminderById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - taskId tasks.TaskId
+//   - id ReminderId
+func (_e *MockRemindersRepo_Expecter) RemoveReminderById(ctx interface{}, login interface{}, taskId interface{}, id interface{}) *MockRemindersRepo_RemoveReminderById_Call {
+	return &MockRemindersRepo_RemoveReminderById_Call{Call: _e.mock.On("RemoveReminderById", ctx, login, taskId, id)}
+}
+
+func (_c *MockRemindersRepo_RemoveReminderById_Call) Run(run func(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId)) *MockRemindersRepo_RemoveReminderById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(tasks.TaskId), args[3].(ReminderId))
+	})
+	return _c
+}
+
+func (_c *MockRemindersRepo_RemoveReminderById_Call) Return(_a0 error) *MockRemindersRepo_RemoveReminderById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRemindersRepo_RemoveReminderById_Call) RunAndReturn(run func(context.Context, string, tasks.TaskId, ReminderId) error) *MockRemindersRepo_RemoveReminderById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveReminder provides a mock function with given fields: ctx, reminder
+func (_m *MockRemindersRepo) SaveReminder(ctx context.Context, reminder Reminder) error {
+	ret := _m.Called(ctx, reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Reminder) error); ok {
+		r0 = rf(ctx, reminder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRemindersRepo_SaveReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveReminder'
+type MockRemindersRepo_SaveReminder_Call struct {
+	*mock.Call
+}
+
+// SaveReminder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reminder Reminder
+func (_e *MockRemindersRepo_Expecter) SaveReminder(ctx interface{}, reminder interface{}) *MockRemindersRepo_SaveReminder_Call {
+	return &MockRemindersRepo_SaveReminder_Call{Call: _e.mock.On("SaveReminder", ctx, reminder)}
+}
+
+func (_c *MockRemindersRepo_SaveReminder_Call) Run(run func(ctx context.Context, reminder Reminder)) *MockRemindersRepo_SaveReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Reminder))
+	})
+	return _c
+}
+
+func (_c *MockRemindersRepo_SaveReminder_Call) Return(_a0 error) *MockRemindersRepo_SaveReminder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRemindersRepo_SaveReminder_Call) RunAndReturn(run func(context.Context, Reminder) error) *MockRemindersRepo_SaveReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRemindersRepo creates a new instance of MockRemindersRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRemindersRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRemindersRepo {
+	mock := &MockRemindersRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+package reminders
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+var ErrReminderNotFound = errors.New("reminder not found")
+var ErrInvalidReminderOffset = errors.New("invalid reminder offset")
+
+type ReminderId uuid.UUID
+
+func (id ReminderId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewReminderId() ReminderId {
+	return ReminderId(uuid.New())
+}
+
+func ParseReminderId(id string) (ReminderId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return ReminderId(uuid.Nil), err
+	}
+	return ReminderId(uid), nil
+}
+
+// Reminder schedules a notification for the user relative to
+// the moment when the task is due
+type Reminder struct {
+	Id     ReminderId
+	TaskId tasks.TaskId
+	Login  string
+	// Before is the offset from the moment when the task is due
+	Before    time.Duration
+	CreatedAt time.Time
+}
+
+type ReminderParams struct {
+	Before time.Duration
+}
+
+func NewReminder(
+	reminderId ReminderId,
+	taskId tasks.TaskId,
+	login string,
+	before time.Duration,
+	createdAt time.Time,
+) (Reminder, error) {
+	if before < 0 {
+		return Reminder{}, ErrInvalidReminderOffset
+	}
+	return Reminder{
+		Id:        reminderId,
+		TaskId:    taskId,
+		Login:     login,
+		Before:    before.Truncate(time.Second),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// Notification is delivered to the user when the reminder fires
+type Notification struct {
+	ReminderId ReminderId
+	Login      string
+	TaskId     tasks.TaskId
+	TaskTitle  string
+	DueDate    time.Time
+	// DueAt is the moment when the task is due, tasks without
+	// the due time are due at the start of the due date
+	DueAt    time.Time
+	RemindAt time.Time
+}
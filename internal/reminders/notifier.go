@@ -0,0 +1,84 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+)
+
+// LogNotifier writes notifications to the log
+type LogNotifier struct {
+	log *logger.Logger
+}
+
+func NewLogNotifier(log *logger.Logger) *LogNotifier {
+	return &LogNotifier{log}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.log.Info(
+		ctx, "task reminder",
+		slog.String("reminder_id", notification.ReminderId.String()),
+		slog.String("login", notification.Login),
+		slog.String("task_id", notification.TaskId.String()),
+		slog.String("task_title", notification.TaskTitle),
+		slog.String("due_date", notification.DueDate.Format(time.DateOnly)),
+		slog.String("due_at", notification.DueAt.Format(time.RFC3339)),
+	)
+	return nil
+}
+
+type WebhookPayload struct {
+	ReminderId string `json:"reminder_id"`
+	Login      string `json:"login"`
+	TaskId     string `json:"task_id"`
+	TaskTitle  string `json:"task_title"`
+	DueDate    string `json:"due_date"`
+	DueAt      string `json:"due_at"`
+	RemindAt   string `json:"remind_at"`
+}
+
+// WebhookNotifier posts notifications as JSON to the webhook URL
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(client *http.Client, url string) *WebhookNotifier {
+	return &WebhookNotifier{client, url}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(WebhookPayload{
+		ReminderId: notification.ReminderId.String(),
+		Login:      notification.Login,
+		TaskId:     notification.TaskId.String(),
+		TaskTitle:  notification.TaskTitle,
+		DueDate:    notification.DueDate.Format(time.DateOnly),
+		DueAt:      notification.DueAt.Format(time.RFC3339),
+		RemindAt:   notification.RemindAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected webhook response status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package reminders_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/reminders"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var payload reminders.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dueDate := time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2025, 2, 5, 16, 0, 0, 0, time.UTC)
+	notification := reminders.Notification{
+		ReminderId: reminders.NewReminderId(),
+		Login:      login,
+		TaskId:     tasks.NewTaskId(),
+		TaskTitle:  "title",
+		DueDate:    dueDate,
+		DueAt:      dueAt,
+		RemindAt:   dueAt.Add(-24 * time.Hour),
+	}
+	notifier := reminders.NewWebhookNotifier(server.Client(), server.URL)
+	if err := notifier.Notify(t.Context(), notification); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payload.TaskId != notification.TaskId.String() ||
+		payload.Login != login ||
+		payload.DueDate != "2025-02-05" ||
+		payload.DueAt != "2025-02-05T16:00:00Z" ||
+		payload.RemindAt != "2025-02-04T16:00:00Z" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	failing := reminders.NewWebhookNotifier(server.Client(), server.URL+"/missing")
+	server.Config.Handler = http.NotFoundHandler()
+	if err := failing.Notify(t.Context(), notification); err == nil {
+		t.Fatal("expected error for unsuccessful response")
+	}
+}
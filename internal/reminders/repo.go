@@ -0,0 +1,127 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type Repo struct {
+	log     *logger.Logger
+	queries *db.Queries
+}
+
+func NewRepo(
+	log *logger.Logger,
+	queries *db.Queries,
+) *Repo {
+	return &Repo{log, queries}
+}
+
+func (r *Repo) SaveReminder(ctx context.Context, reminder Reminder) error {
+	return r.queries.InsertTaskReminder(ctx, db.InsertTaskReminderParams{
+		ID:            r.reminderIdToPg(reminder.Id),
+		TaskID:        r.taskIdToPg(reminder.TaskId),
+		Login:         reminder.Login,
+		BeforeSeconds: int64(reminder.Before / time.Second),
+		CreatedAt: pgtype.Timestamp{
+			Time:  reminder.CreatedAt.UTC(),
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) RemindersByTask(ctx context.Context, login string, taskId tasks.TaskId) ([]Reminder, error) {
+	rows, err := r.queries.TaskReminders(ctx, db.TaskRemindersParams{
+		TaskID: r.taskIdToPg(taskId),
+		Login:  login,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reminders := make([]Reminder, len(rows))
+	for i, row := range rows {
+		if reminders[i], err = NewReminder(
+			row.ID.Bytes,
+			row.TaskID.Bytes,
+			row.Login,
+			time.Duration(row.BeforeSeconds)*time.Second,
+			row.CreatedAt.Time,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return reminders, nil
+}
+
+func (r *Repo) RemoveReminderById(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId) error {
+	rowsAffected, err := r.queries.DeleteTaskReminder(ctx, db.DeleteTaskReminderParams{
+		ID:     r.reminderIdToPg(id),
+		TaskID: r.taskIdToPg(taskId),
+		Login:  login,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// DueNotifications returns notifications of the reminders that should
+// be fired at the given moment and have not been sent for the current
+// due moment of the task
+func (r *Repo) DueNotifications(ctx context.Context, now time.Time) ([]Notification, error) {
+	rows, err := r.queries.DueTaskReminders(ctx, db.DueTaskRemindersParams{
+		DoneStatus: tasks.Done.String(),
+		Now: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	notifications := make([]Notification, len(rows))
+	for i, row := range rows {
+		notifications[i] = Notification{
+			ReminderId: row.ID.Bytes,
+			Login:      row.Login,
+			TaskId:     row.TaskID.Bytes,
+			TaskTitle:  row.Title,
+			DueDate:    row.DueDate.Time,
+			DueAt:      row.DueAt.Time,
+			RemindAt:   row.DueAt.Time.Add(-time.Duration(row.BeforeSeconds) * time.Second),
+		}
+	}
+	return notifications, nil
+}
+
+func (r *Repo) MarkReminderSent(ctx context.Context, id ReminderId, dueAt time.Time) error {
+	return r.queries.MarkTaskReminderSent(ctx, db.MarkTaskReminderSentParams{
+		ID: r.reminderIdToPg(id),
+		SentFor: pgtype.Timestamp{
+			Time:  dueAt.UTC(),
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) reminderIdToPg(id ReminderId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func (r *Repo) taskIdToPg(id tasks.TaskId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
@@ -0,0 +1,128 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type RemindersRepo interface {
+	SaveReminder(ctx context.Context, reminder Reminder) error
+	RemindersByTask(ctx context.Context, login string, taskId tasks.TaskId) ([]Reminder, error)
+	RemoveReminderById(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId) error
+	DueNotifications(ctx context.Context, now time.Time) ([]Notification, error)
+	MarkReminderSent(ctx context.Context, id ReminderId, dueAt time.Time) error
+}
+
+type TasksRepo interface {
+	TaskById(ctx context.Context, login string, id tasks.TaskId) (tasks.Task, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type Service struct {
+	log           *logger.Logger
+	remindersRepo RemindersRepo
+	tasksRepo     TasksRepo
+	notifier      Notifier
+}
+
+func NewService(
+	log *logger.Logger,
+	remindersRepo RemindersRepo,
+	tasksRepo TasksRepo,
+	notifier Notifier,
+) *Service {
+	return &Service{log, remindersRepo, tasksRepo, notifier}
+}
+
+func (s *Service) Reminders(ctx context.Context, login string, taskId tasks.TaskId) ([]Reminder, *shared.ServiceError) {
+	if err := s.checkTask(ctx, login, taskId); err != nil {
+		return nil, err
+	}
+	reminders, err := s.remindersRepo.RemindersByTask(ctx, login, taskId)
+	if err != nil {
+		return reminders, shared.NewUnexpectedError(err, "failed to load reminders")
+	}
+	return reminders, nil
+}
+
+func (s *Service) CreateReminder(
+	ctx context.Context,
+	login string,
+	taskId tasks.TaskId,
+	params ReminderParams,
+) (Reminder, *shared.ServiceError) {
+	reminder, err := NewReminder(
+		NewReminderId(),
+		taskId,
+		login,
+		params.Before,
+		time.Now(),
+	)
+	if err != nil {
+		return reminder, shared.NewServiceError(err, "failed to create reminder")
+	}
+	if err := s.checkTask(ctx, login, taskId); err != nil {
+		return reminder, err
+	}
+	if err := s.remindersRepo.SaveReminder(ctx, reminder); err != nil {
+		return reminder, shared.NewUnexpectedError(err, "failed to save reminder")
+	}
+	return reminder, nil
+}
+
+func (s *Service) RemoveReminderById(ctx context.Context, login string, taskId tasks.TaskId, id ReminderId) *shared.ServiceError {
+	err := s.remindersRepo.RemoveReminderById(ctx, login, taskId, id)
+	if errors.Is(err, ErrReminderNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("reminder with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove reminder")
+	}
+	return nil
+}
+
+// SendDueReminders delivers notifications of the fired reminders.
+// Reminders that failed to be delivered are retried on the next call.
+func (s *Service) SendDueReminders(ctx context.Context) *shared.ServiceError {
+	notifications, err := s.remindersRepo.DueNotifications(ctx, time.Now())
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load due reminders")
+	}
+	for _, n := range notifications {
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			s.log.Error(
+				ctx, "failed to deliver reminder",
+				slog.String("reminder_id", n.ReminderId.String()),
+				sl.Err(err),
+			)
+			continue
+		}
+		if err := s.remindersRepo.MarkReminderSent(ctx, n.ReminderId, n.DueAt); err != nil {
+			return shared.NewUnexpectedError(err, "failed to mark reminder as sent")
+		}
+	}
+	return nil
+}
+
+// checkTask ensures that the task is visible to the user
+func (s *Service) checkTask(ctx context.Context, login string, taskId tasks.TaskId) *shared.ServiceError {
+	_, err := s.tasksRepo.TaskById(ctx, login, taskId)
+	if errors.Is(err, tasks.ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", taskId.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load task")
+	}
+	return nil
+}
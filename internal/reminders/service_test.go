@@ -0,0 +1,218 @@
+package reminders_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/reminders"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+const login = "login"
+
+type serviceMocks struct {
+	remindersRepo *reminders.MockRemindersRepo
+	tasksRepo     *reminders.MockTasksRepo
+	notifier      *reminders.MockNotifier
+}
+
+func newTestService(t *testing.T, setup func(sm serviceMocks)) *reminders.Service {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	remindersRepo := reminders.NewMockRemindersRepo(t)
+	tasksRepo := reminders.NewMockTasksRepo(t)
+	notifier := reminders.NewMockNotifier(t)
+	if setup != nil {
+		setup(serviceMocks{
+			remindersRepo: remindersRepo,
+			tasksRepo:     tasksRepo,
+			notifier:      notifier,
+		})
+	}
+	return reminders.NewService(
+		log,
+		remindersRepo,
+		tasksRepo,
+		notifier,
+	)
+}
+
+func TestServiceCreateReminder(t *testing.T) {
+	taskId := tasks.NewTaskId()
+	params := reminders.ReminderParams{
+		Before: 24 * time.Hour,
+	}
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *reminders.Service
+		params  reminders.ReminderParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, login, taskId).Return(tasks.Task{}, nil)
+				reminderMatcher := mock.MatchedBy(func(r reminders.Reminder) bool {
+					return r.Before == params.Before && r.Login == login && r.TaskId == taskId
+				})
+				sm.remindersRepo.EXPECT().SaveReminder(mock.Anything, reminderMatcher).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "negative offset",
+			service: newTestService(t, nil),
+			params: reminders.ReminderParams{
+				Before: -time.Hour,
+			},
+			err: shared.NewServiceError(reminders.ErrInvalidReminderOffset, ""),
+		},
+		{
+			name: "task not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, login, taskId).
+					Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			params: params,
+			err:    shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, login, taskId).Return(tasks.Task{}, nil)
+				sm.remindersRepo.EXPECT().SaveReminder(mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := c.service.CreateReminder(t.Context(), login, taskId, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceRemoveReminderById(t *testing.T) {
+	taskId := tasks.NewTaskId()
+	reminderId := reminders.NewReminderId()
+	cases := []struct {
+		name    string
+		service *reminders.Service
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.remindersRepo.EXPECT().RemoveReminderById(mock.Anything, login, taskId, reminderId).Return(nil)
+			}),
+		},
+		{
+			name: "reminder not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.remindersRepo.EXPECT().RemoveReminderById(mock.Anything, login, taskId, reminderId).
+					Return(reminders.ErrReminderNotFound)
+			}),
+			err: shared.NewServiceError(reminders.ErrReminderNotFound, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RemoveReminderById(t.Context(), login, taskId, reminderId); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceSendDueReminders(t *testing.T) {
+	dueDate := time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2025, 2, 5, 16, 0, 0, 0, time.UTC)
+	first := reminders.Notification{
+		ReminderId: reminders.NewReminderId(),
+		Login:      login,
+		TaskId:     tasks.NewTaskId(),
+		TaskTitle:  "first",
+		DueDate:    dueDate,
+		DueAt:      dueAt,
+		RemindAt:   dueAt.Add(-time.Hour),
+	}
+	second := first
+	second.ReminderId = reminders.NewReminderId()
+	second.TaskTitle = "second"
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *reminders.Service
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.remindersRepo.EXPECT().DueNotifications(mock.Anything, mock.AnythingOfType("time.Time")).
+					Return([]reminders.Notification{first, second}, nil)
+				sm.notifier.EXPECT().Notify(mock.Anything, first).Return(nil)
+				sm.notifier.EXPECT().Notify(mock.Anything, second).Return(nil)
+				sm.remindersRepo.EXPECT().MarkReminderSent(mock.Anything, first.ReminderId, dueAt).Return(nil)
+				sm.remindersRepo.EXPECT().MarkReminderSent(mock.Anything, second.ReminderId, dueAt).Return(nil)
+			}),
+		},
+		{
+			name: "failed delivery is retried later",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.remindersRepo.EXPECT().DueNotifications(mock.Anything, mock.Anything).
+					Return([]reminders.Notification{first, second}, nil)
+				sm.notifier.EXPECT().Notify(mock.Anything, first).Return(unexpectedErr)
+				sm.notifier.EXPECT().Notify(mock.Anything, second).Return(nil)
+				sm.remindersRepo.EXPECT().MarkReminderSent(mock.Anything, second.ReminderId, dueAt).Return(nil)
+			}),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.remindersRepo.EXPECT().DueNotifications(mock.Anything, mock.Anything).
+					Return(nil, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.SendDueReminders(t.Context()); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if c.err != nil {
+				t.Fatalf("expected error: %v", c.err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package shared
 import "errors"
 
 var ErrNotFound = errors.New("not found")
+var ErrForbidden = errors.New("forbidden")
 
 type ServiceError struct {
 	Expected bool
@@ -0,0 +1,24 @@
+package tasks_controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+)
+
+func (t *Controller) archivedTasks(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	filter, page, err := t.tasksQuery(c, login)
+	if err != nil {
+		return err
+	}
+	result, sErr := t.tasksService.ArchivedTasks(c.Context(), login, filter, page)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(TasksPageToDTO(result))
+}
@@ -0,0 +1,103 @@
+package tasks_controller
+
+import (
+	"errors"
+	"mime"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func (t *Controller) addTaskAttachment(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		t.log.Debug(c.Context(), "failed to get attachment file", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	content, err := file.Open()
+	if err != nil {
+		t.log.Debug(c.Context(), "failed to open attachment file", sl.Err(err))
+		return err
+	}
+	defer content.Close()
+	attachment, sErr := t.tasksService.AddTaskAttachment(
+		c.Context(),
+		login,
+		taskId,
+		file.Filename,
+		file.Header.Get(fiber.HeaderContentType),
+		file.Size,
+		content,
+	)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(attachmentToDTO(attachment))
+}
+
+func (t *Controller) taskAttachment(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	attachmentId, err := t.attachmentId(c, c.Params("attachment_id"))
+	if err != nil {
+		return err
+	}
+	attachment, content, sErr := t.tasksService.TaskAttachment(c.Context(), login, taskId, attachmentId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) || errors.Is(sErr.Err, tasks.ErrAttachmentNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Name,
+	}))
+	// The stream is closed by fasthttp after the response is sent
+	return c.SendStream(content, int(attachment.Size))
+}
+
+func (t *Controller) removeTaskAttachment(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	attachmentId, err := t.attachmentId(c, c.Params("attachment_id"))
+	if err != nil {
+		return err
+	}
+	if sErr := t.tasksService.RemoveTaskAttachment(c.Context(), login, taskId, attachmentId); sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) || errors.Is(sErr.Err, tasks.ErrAttachmentNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
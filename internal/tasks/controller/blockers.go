@@ -0,0 +1,59 @@
+package tasks_controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func (t *Controller) addTaskBlocker(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	blockerId, err := t.taskId(c, c.Params("blocker_id"))
+	if err != nil {
+		return err
+	}
+	if sErr := t.tasksService.AddTaskBlocker(c.Context(), login, taskId, blockerId); sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(sErr.Err, tasks.ErrDependencyCycle) {
+			return fiber_adapter.SpecificServiceError(sErr, fiber.StatusConflict)
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (t *Controller) removeTaskBlocker(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	blockerId, err := t.taskId(c, c.Params("blocker_id"))
+	if err != nil {
+		return err
+	}
+	if sErr := t.tasksService.RemoveTaskBlocker(c.Context(), login, taskId, blockerId); sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) || errors.Is(sErr.Err, tasks.ErrBlockerNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package tasks_controller
 
 import (
 	"context"
+	"io"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
@@ -10,13 +11,36 @@ import (
 )
 
 type TasksService interface {
-	CreateTask(ctx context.Context, params tasks.TaskParams) *shared.ServiceError
-	FindTasks(ctx context.Context, filter tasks.TasksFilter) ([]tasks.Task, *shared.ServiceError)
-	UpdateTaskById(ctx context.Context, id tasks.TaskId, params tasks.TaskParams) *shared.ServiceError
-	RemoveTaskById(ctx context.Context, id tasks.TaskId) *shared.ServiceError
-	ExportTasks(ctx context.Context) ([]tasks.Task, *shared.ServiceError)
-	ImportTasks(ctx context.Context, tasks []tasks.Task) *shared.ServiceError
+	CreateTask(ctx context.Context, owner string, params tasks.TaskParams) *shared.ServiceError
+	FindTasks(ctx context.Context, login string, filter tasks.TasksFilter, page tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)
+	ArchivedTasks(ctx context.Context, login string, filter tasks.TasksFilter, page tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)
+	TaskById(ctx context.Context, login string, id tasks.TaskId) (tasks.Task, *shared.ServiceError)
+	UpdateTaskById(ctx context.Context, login string, id tasks.TaskId, version *int64, params tasks.TaskParams) *shared.ServiceError
+	ReopenTaskById(ctx context.Context, login string, id tasks.TaskId, version *int64, reason *string) *shared.ServiceError
+	MoveTaskById(ctx context.Context, login string, id tasks.TaskId, version *int64, params tasks.MoveParams) *shared.ServiceError
+	RemoveTaskById(ctx context.Context, login string, id tasks.TaskId, version *int64) *shared.ServiceError
+	Trash(ctx context.Context, login string) ([]tasks.Task, *shared.ServiceError)
+	RestoreTaskById(ctx context.Context, login string, id tasks.TaskId) *shared.ServiceError
+	Subtasks(ctx context.Context, login string, id tasks.TaskId) ([]tasks.Task, *shared.ServiceError)
+	TaskHistory(ctx context.Context, login string, id tasks.TaskId) ([]tasks.TaskEvent, *shared.ServiceError)
+	AddTaskBlocker(ctx context.Context, login string, id tasks.TaskId, blockerId tasks.TaskId) *shared.ServiceError
+	RemoveTaskBlocker(ctx context.Context, login string, id tasks.TaskId, blockerId tasks.TaskId) *shared.ServiceError
+	AddTaskAttachment(
+		ctx context.Context,
+		login string,
+		id tasks.TaskId,
+		name string,
+		contentType string,
+		size int64,
+		content io.Reader,
+	) (tasks.Attachment, *shared.ServiceError)
+	TaskAttachment(ctx context.Context, login string, id tasks.TaskId, attachmentId tasks.AttachmentId) (tasks.Attachment, io.ReadCloser, *shared.ServiceError)
+	RemoveTaskAttachment(ctx context.Context, login string, id tasks.TaskId, attachmentId tasks.AttachmentId) *shared.ServiceError
+	ExportTasks(ctx context.Context, login string) ([]tasks.Task, *shared.ServiceError)
+	ImportTasks(ctx context.Context, owner string, tasks []tasks.Task) *shared.ServiceError
 	PruneOverdueTasks(ctx context.Context) *shared.ServiceError
+	PruneCandidates(ctx context.Context) ([]tasks.Task, *shared.ServiceError)
+	PurgeDeletedTasks(ctx context.Context) *shared.ServiceError
 }
 
 type Controller struct {
@@ -32,9 +56,23 @@ func New(
 	c := &Controller{log, tasksService}
 	router.Get("/", c.findTasks)
 	router.Post("/", c.createTask)
+	router.Get("/trash", c.trash)
+	router.Get("/archive", c.archivedTasks)
 	router.Put("/:id", c.updateTaskById)
+	router.Patch("/:id", c.patchTaskById)
 	router.Delete("/:id", c.removeTaskById)
+	router.Get("/:id/subtasks", c.subtasks)
+	router.Get("/:id/history", c.taskHistory)
+	router.Post("/:id/reopen", c.reopenTaskById)
+	router.Post("/:id/move", c.moveTaskById)
+	router.Post("/:id/restore", c.restoreTaskById)
+	router.Put("/:id/blocked_by/:blocker_id", c.addTaskBlocker)
+	router.Delete("/:id/blocked_by/:blocker_id", c.removeTaskBlocker)
+	router.Post("/:id/attachments", c.addTaskAttachment)
+	router.Get("/:id/attachments/:attachment_id", c.taskAttachment)
+	router.Delete("/:id/attachments/:attachment_id", c.removeTaskAttachment)
 	router.Post("/import", c.importTasks)
 	router.Get("/export", c.exportTasks)
+	router.Get("/:id", c.taskById)
 	return c
 }
@@ -7,19 +7,30 @@ import (
 )
 
 type CreateTaskDTO struct {
-	Title       string  `json:"title" validate:"required"`
-	Description *string `json:"description,omitempty"`
-	Status      string  `json:"status" validate:"required"`
-	Priority    string  `json:"priority" validate:"required"`
-	DueDate     string  `json:"due_date" validate:"required"`
+	Title       string   `json:"title" validate:"required"`
+	Description *string  `json:"description,omitempty"`
+	Status      string   `json:"status" validate:"required"`
+	Priority    string   `json:"priority" validate:"required"`
+	DueDate     string   `json:"due_date" validate:"required"`
+	DueTime     *string  `json:"due_time,omitempty"`
+	TimeZone    *string  `json:"time_zone,omitempty"`
+	ProjectId   *string  `json:"project_id,omitempty"`
+	ParentId    *string  `json:"parent_id,omitempty"`
+	Recurrence  *string  `json:"recurrence,omitempty"`
+	Assignee    *string  `json:"assignee,omitempty"`
+	LabelIds    []string `json:"label_ids,omitempty"`
 }
 
 func (t *Controller) createTask(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
 	params, err := t.taskParams(c)
 	if err != nil {
 		return err
 	}
-	if err := t.tasksService.CreateTask(c.Context(), params); err != nil {
+	if err := t.tasksService.CreateTask(c.Context(), login, params); err != nil {
 		logger_adapter.LogServiceError(t.log, c, err)
 		return fiber_adapter.ServiceError(err)
 	}
@@ -7,10 +7,14 @@ import (
 )
 
 func (t *Controller) exportTasks(c *fiber.Ctx) error {
-	tasks, err := t.tasksService.ExportTasks(c.Context())
+	login, err := t.login(c)
 	if err != nil {
-		logger_adapter.LogServiceError(t.log, c, err)
-		return fiber_adapter.ServiceError(err)
+		return err
+	}
+	tasks, sErr := t.tasksService.ExportTasks(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
 	}
 	tasksDto := make([]TaskDTO, len(tasks))
 	for i, t := range tasks {
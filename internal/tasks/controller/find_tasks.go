@@ -1,58 +1,210 @@
 package tasks_controller
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
 	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 )
 
+type TasksPageDTO struct {
+	Tasks      []TaskDTO `json:"tasks"`
+	NextCursor *string   `json:"next_cursor"`
+	Total      *int64    `json:"total,omitempty"`
+}
+
+func TasksPageToDTO(page tasks.TasksPage) TasksPageDTO {
+	tasksDto := make([]TaskDTO, len(page.Tasks))
+	for i, t := range page.Tasks {
+		tasksDto[i] = taskToDTO(t)
+	}
+	dto := TasksPageDTO{
+		Tasks: tasksDto,
+		Total: page.Total,
+	}
+	if page.NextCursor != nil {
+		cursor := page.NextCursor.String()
+		dto.NextCursor = &cursor
+	}
+	return dto
+}
+
 func (t *Controller) findTasks(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	filter, page, err := t.tasksQuery(c, login)
+	if err != nil {
+		return err
+	}
+	result, sErr := t.tasksService.FindTasks(c.Context(), login, filter, page)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(TasksPageToDTO(result))
+}
+
+// tasksQuery parses the filter and the page parameters of the tasks list
+func (t *Controller) tasksQuery(c *fiber.Ctx, login string) (tasks.TasksFilter, tasks.PageParams, error) {
+	value := func(key string) string {
+		return c.Query(key)
+	}
+	var page tasks.PageParams
+	filter, err := t.tasksFilter(c, login, value)
+	if err != nil {
+		return filter, page, err
+	}
+	if page, err = t.pageParams(c, value); err != nil {
+		return filter, page, err
+	}
+	sort := c.Query("sort")
+	if sort != "" {
+		if s, err := t.sort(c, sort); err != nil {
+			return filter, page, err
+		} else {
+			page.Sort = s
+		}
+	}
+	return filter, page, nil
+}
+
+// ParseTasksFilter parses the filter of the tasks list from the parameters
+// with the names of the query parameters, the value function returns
+// the empty string for the missing parameters
+func ParseTasksFilter(c *fiber.Ctx, log *logger.Logger, login string, value func(key string) string) (tasks.TasksFilter, error) {
+	return (&Controller{log: log}).tasksFilter(c, login, value)
+}
+
+// ParsePageParams parses the cursor, the limit and the total flag of
+// the tasks list page, the value function returns the empty string for
+// the missing parameters
+func ParsePageParams(c *fiber.Ctx, log *logger.Logger, value func(key string) string) (tasks.PageParams, error) {
+	return (&Controller{log: log}).pageParams(c, value)
+}
+
+func (t *Controller) tasksFilter(c *fiber.Ctx, login string, value func(key string) string) (tasks.TasksFilter, error) {
 	var filter tasks.TasksFilter
-	title := c.Query("title")
+	title := value("title")
 	if title != "" {
 		filter.Title = &title
 	}
-	status := c.Query("status")
+	query := strings.TrimSpace(value("q"))
+	if query != "" {
+		filter.Query = &query
+	}
+	status := value("status")
 	if status != "" {
-		if s, err := t.status(c, status); err != nil {
-			return err
-		} else {
-			filter.Status = &s
-		}
+		s := tasks.Status(status)
+		filter.Status = &s
 	}
-	priority := c.Query("priority")
+	priority := value("priority")
 	if priority != "" {
 		if p, err := t.priority(c, priority); err != nil {
-			return err
+			return filter, err
 		} else {
 			filter.Priority = &p
 		}
 	}
-	dueBefore := c.Query("due_before")
+	dueBefore := value("due_before")
 	if dueBefore != "" {
 		if d, err := t.date(c, dueBefore); err != nil {
-			return err
+			return filter, err
 		} else {
 			filter.DueBefore = &d
 		}
 	}
-	dueAfter := c.Query("due_after")
+	dueAfter := value("due_after")
 	if dueAfter != "" {
 		if d, err := t.date(c, dueAfter); err != nil {
-			return err
+			return filter, err
 		} else {
 			filter.DueAfter = &d
 		}
 	}
-	tasks, err := t.tasksService.FindTasks(c.Context(), filter)
-	if err != nil {
-		logger_adapter.LogServiceError(t.log, c, err)
-		return fiber_adapter.ServiceError(err)
+	projectId := value("project_id")
+	if projectId != "" {
+		if id, err := t.projectId(c, projectId); err != nil {
+			return filter, err
+		} else {
+			filter.ProjectId = &id
+		}
 	}
-	tasksDto := make([]TaskDTO, len(tasks))
-	for i, t := range tasks {
-		tasksDto[i] = taskToDTO(t)
+	parentId := value("parent_id")
+	if parentId != "" {
+		if id, err := t.taskId(c, parentId); err != nil {
+			return filter, err
+		} else {
+			filter.ParentId = &id
+		}
+	}
+	assignee := value("assignee")
+	if assignee == "me" {
+		filter.Assignee = &login
+	} else if assignee != "" {
+		filter.Assignee = &assignee
+	}
+	ready := value("ready")
+	if ready != "" {
+		if r, err := t.flag(c, "ready", ready); err != nil {
+			return filter, err
+		} else {
+			filter.ReadyToStart = r
+		}
+	}
+	labels := value("labels")
+	if labels != "" {
+		filter.Labels = strings.Split(labels, ",")
+		filter.LabelsMatch = tasks.AnyLabel
+	}
+	labelsMatch := value("labels_match")
+	if labelsMatch != "" {
+		if m, err := t.labelsMatch(c, labelsMatch); err != nil {
+			return filter, err
+		} else {
+			filter.LabelsMatch = m
+		}
+	}
+	expr := value("filter")
+	if expr != "" {
+		if e, err := t.filterExpr(c, expr); err != nil {
+			return filter, err
+		} else {
+			filter.Expr = e
+		}
+	}
+	return filter, nil
+}
+
+func (t *Controller) pageParams(c *fiber.Ctx, value func(key string) string) (tasks.PageParams, error) {
+	var page tasks.PageParams
+	cursor := value("cursor")
+	if cursor != "" {
+		if cur, err := t.cursor(c, cursor); err != nil {
+			return page, err
+		} else {
+			page.Cursor = &cur
+		}
+	}
+	limit := value("limit")
+	if limit != "" {
+		if l, err := t.limit(c, limit); err != nil {
+			return page, err
+		} else {
+			page.Limit = l
+		}
+	}
+	total := value("total")
+	if total != "" {
+		if wt, err := t.flag(c, "total", total); err != nil {
+			return page, err
+		} else {
+			page.WithTotal = wt
+		}
 	}
-	return c.JSON(tasksDto)
+	return page, nil
 }
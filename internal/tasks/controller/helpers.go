@@ -1,22 +1,40 @@
 package tasks_controller
 
 import (
+	"errors"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
 	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 )
 
+func (t *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		t.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
 func (t *Controller) taskParams(c *fiber.Ctx) (tasks.TaskParams, error) {
 	var dto CreateTaskDTO
 	if err := c.BodyParser(&dto); err != nil {
 		t.log.Debug(c.Context(), "failed to decode body")
 		return tasks.TaskParams{}, err
 	}
+	return t.taskParamsFromDTO(c, dto)
+}
+
+func (t *Controller) taskParamsFromDTO(c *fiber.Ctx, dto CreateTaskDTO) (tasks.TaskParams, error) {
 	if err := validator_adapter.ValidateStruct(&dto); err != nil {
 		t.log.Debug(c.Context(), "invalid create task dto struct", sl.Err(err))
 		return tasks.TaskParams{}, fiber_adapter.BadRequest(err)
@@ -24,20 +42,80 @@ func (t *Controller) taskParams(c *fiber.Ctx) (tasks.TaskParams, error) {
 	params := tasks.TaskParams{
 		Title:       dto.Title,
 		Description: dto.Description,
+		Status:      tasks.Status(dto.Status),
+		Assignee:    dto.Assignee,
+		TimeZone:    dto.TimeZone,
 	}
 	var err error
-	if params.Status, err = t.status(c, dto.Status); err != nil {
-		return params, err
-	}
 	if params.Priority, err = t.priority(c, dto.Priority); err != nil {
 		return params, err
 	}
 	if params.DueDate, err = t.date(c, dto.DueDate); err != nil {
 		return params, err
 	}
+	if dto.DueTime != nil {
+		dueTime, err := t.dueTime(c, *dto.DueTime)
+		if err != nil {
+			return params, err
+		}
+		params.DueTime = &dueTime
+	}
+	if dto.ProjectId != nil {
+		projectId, err := t.projectId(c, *dto.ProjectId)
+		if err != nil {
+			return params, err
+		}
+		params.ProjectId = &projectId
+	}
+	if dto.ParentId != nil {
+		parentId, err := t.taskId(c, *dto.ParentId)
+		if err != nil {
+			return params, err
+		}
+		params.ParentId = &parentId
+	}
+	if dto.Recurrence != nil {
+		recurrence, err := t.recurrence(c, *dto.Recurrence)
+		if err != nil {
+			return params, err
+		}
+		params.Recurrence = &recurrence
+	}
+	if dto.LabelIds != nil {
+		params.LabelIds = make([]labels.LabelId, len(dto.LabelIds))
+		for i, id := range dto.LabelIds {
+			if params.LabelIds[i], err = t.labelId(c, id); err != nil {
+				return params, err
+			}
+		}
+	}
 	return params, nil
 }
 
+// ifMatch parses the If-Match header, nil means that the header
+// is absent or matches any version of the task
+func (t *Controller) ifMatch(c *fiber.Ctx) (*int64, error) {
+	value := c.Get(fiber.HeaderIfMatch)
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	// Weak entity tags never match in If-Match
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if !ok || err != nil {
+		t.log.Debug(c.Context(), "invalid if-match value", slog.String("if_match", value))
+		return nil, fiber.ErrPreconditionFailed
+	}
+	return &version, nil
+}
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
 func (t *Controller) taskId(c *fiber.Ctx, value string) (tasks.TaskId, error) {
 	taskId, err := tasks.ParseTaskId(value)
 	if err != nil {
@@ -47,13 +125,58 @@ func (t *Controller) taskId(c *fiber.Ctx, value string) (tasks.TaskId, error) {
 	return taskId, nil
 }
 
-func (t *Controller) status(c *fiber.Ctx, value string) (tasks.Status, error) {
-	status, err := tasks.ParseStatus(value)
+func (t *Controller) projectId(c *fiber.Ctx, value string) (projects.ProjectId, error) {
+	projectId, err := projects.ParseProjectId(value)
 	if err != nil {
-		t.log.Debug(c.Context(), "invalid status value", slog.String("status", value))
-		return status, fiber_adapter.BadRequest(err)
+		t.log.Debug(c.Context(), "invalid project id value", slog.String("project_id", value))
+		return projectId, fiber_adapter.BadRequest(err)
 	}
-	return status, nil
+	return projectId, nil
+}
+
+func (t *Controller) labelId(c *fiber.Ctx, value string) (labels.LabelId, error) {
+	labelId, err := labels.ParseLabelId(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid label id value", slog.String("label_id", value))
+		return labelId, fiber_adapter.BadRequest(err)
+	}
+	return labelId, nil
+}
+
+func (t *Controller) attachmentId(c *fiber.Ctx, value string) (tasks.AttachmentId, error) {
+	attachmentId, err := tasks.ParseAttachmentId(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid attachment id value", slog.String("attachment_id", value))
+		return attachmentId, fiber_adapter.BadRequest(err)
+	}
+	return attachmentId, nil
+}
+
+func (t *Controller) labelsMatch(c *fiber.Ctx, value string) (tasks.LabelsMatch, error) {
+	match, err := tasks.ParseLabelsMatch(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid labels match value", slog.String("labels_match", value))
+		return match, fiber_adapter.BadRequest(err)
+	}
+	return match, nil
+}
+
+func (t *Controller) recurrence(c *fiber.Ctx, value string) (tasks.Recurrence, error) {
+	recurrence, err := tasks.ParseRecurrence(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid recurrence value", slog.String("recurrence", value))
+		return recurrence, fiber_adapter.BadRequest(err)
+	}
+	return recurrence, nil
+}
+
+func (t *Controller) dueTime(c *fiber.Ctx, value string) (tasks.DueTime, error) {
+	dueTime, err := tasks.ParseDueTime(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid due time value", slog.String("due_time", value))
+		return dueTime, fiber_adapter.BadRequest(err)
+	}
+	return dueTime, nil
 }
 
 func (t *Controller) priority(c *fiber.Ctx, value string) (tasks.Priority, error) {
@@ -73,3 +196,48 @@ func (t *Controller) date(c *fiber.Ctx, value string) (time.Time, error) {
 	}
 	return date, nil
 }
+
+func (t *Controller) filterExpr(c *fiber.Ctx, value string) (tasks.FilterExpr, error) {
+	expr, err := tasks.ParseFilterExpr(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid filter value", slog.String("filter", value), sl.Err(err))
+		return expr, fiber_adapter.BadRequest(err)
+	}
+	return expr, nil
+}
+
+func (t *Controller) sort(c *fiber.Ctx, value string) (tasks.Sort, error) {
+	sort, err := tasks.ParseSort(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid sort value", slog.String("sort", value))
+		return sort, fiber_adapter.BadRequest(err)
+	}
+	return sort, nil
+}
+
+func (t *Controller) cursor(c *fiber.Ctx, value string) (tasks.Cursor, error) {
+	cursor, err := tasks.ParseCursor(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid cursor value", slog.String("cursor", value))
+		return cursor, fiber_adapter.BadRequest(err)
+	}
+	return cursor, nil
+}
+
+func (t *Controller) limit(c *fiber.Ctx, value string) (int, error) {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		t.log.Debug(c.Context(), "invalid limit value", slog.String("limit", value))
+		return limit, fiber_adapter.BadRequest(errors.New("limit should be a positive integer"))
+	}
+	return limit, nil
+}
+
+func (t *Controller) flag(c *fiber.Ctx, name string, value string) (bool, error) {
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		t.log.Debug(c.Context(), "invalid flag value", slog.String(name, value))
+		return flag, fiber_adapter.BadRequest(err)
+	}
+	return flag, nil
+}
@@ -12,6 +12,10 @@ import (
 )
 
 func (t *Controller) importTasks(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
 	var dto []TaskDTO
 	if err := c.BodyParser(&dto); err != nil {
 		t.log.Debug(c.Context(), "failed to decode body")
@@ -22,14 +26,13 @@ func (t *Controller) importTasks(c *fiber.Ctx) error {
 		return err
 	}
 	tasksList := make([]tasks.Task, len(dto))
-	var err error
 	for i, item := range dto {
 		if tasksList[i], err = taskFromDTO(item); err != nil {
 			t.log.Debug(c.Context(), "failed to construct task from dto", slog.Any("task", item))
 			return fiber_adapter.BadRequest(err)
 		}
 	}
-	if err := t.tasksService.ImportTasks(c.Context(), tasksList); err != nil {
+	if err := t.tasksService.ImportTasks(c.Context(), login, tasksList); err != nil {
 		logger_adapter.LogServiceError(t.log, c, err)
 		if errors.Is(err.Err, tasks.ErrTaskIdsConflict) {
 			return fiber.ErrConflict
@@ -0,0 +1,70 @@
+package tasks_controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type MoveTaskDTO struct {
+	Status string  `json:"status" validate:"required"`
+	After  *string `json:"after"`
+	Before *string `json:"before"`
+}
+
+func (t *Controller) moveTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	version, err := t.ifMatch(c)
+	if err != nil {
+		return err
+	}
+	var dto MoveTaskDTO
+	if err := c.BodyParser(&dto); err != nil {
+		t.log.Debug(c.Context(), "failed to decode body")
+		return err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		t.log.Debug(c.Context(), "invalid move task dto struct", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	params := tasks.MoveParams{
+		Status: tasks.Status(dto.Status),
+	}
+	if dto.After != nil {
+		after, err := t.taskId(c, *dto.After)
+		if err != nil {
+			return err
+		}
+		params.After = &after
+	}
+	if dto.Before != nil {
+		before, err := t.taskId(c, *dto.Before)
+		if err != nil {
+			return err
+		}
+		params.Before = &before
+	}
+	if err := t.tasksService.MoveTaskById(c.Context(), login, taskId, version, params); err != nil {
+		logger_adapter.LogServiceError(t.log, c, err)
+		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(err.Err, tasks.ErrVersionMismatch) {
+			return fiber.ErrPreconditionFailed
+		}
+		return fiber_adapter.ServiceError(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
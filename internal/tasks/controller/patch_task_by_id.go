@@ -0,0 +1,98 @@
+package tasks_controller
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/mergepatch"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+const mimeMergePatch = "application/merge-patch+json"
+
+// taskToCreateDTO returns the editable fields of the task,
+// labels are omitted to keep them unchanged unless the patch sets them
+func taskToCreateDTO(task tasks.Task) CreateTaskDTO {
+	dto := taskToDTO(task)
+	return CreateTaskDTO{
+		Title:       dto.Title,
+		Description: dto.Description,
+		Status:      dto.Status,
+		Priority:    dto.Priority,
+		DueDate:     dto.DueDate,
+		DueTime:     dto.DueTime,
+		TimeZone:    &dto.TimeZone,
+		ProjectId:   dto.ProjectId,
+		ParentId:    dto.ParentId,
+		Recurrence:  dto.Recurrence,
+		Assignee:    dto.Assignee,
+	}
+}
+
+// patchTaskById applies JSON Merge Patch to the task. The task is updated
+// only if it has not been changed since it was loaded for patching.
+func (t *Controller) patchTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	if !strings.HasPrefix(contentType, mimeMergePatch) && !strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) {
+		t.log.Debug(c.Context(), "unsupported patch content type", slog.String("content_type", contentType))
+		return fiber.ErrUnsupportedMediaType
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	version, err := t.ifMatch(c)
+	if err != nil {
+		return err
+	}
+	task, sErr := t.tasksService.TaskById(c.Context(), login, taskId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	if version == nil {
+		version = &task.Version
+	}
+	doc, err := json.Marshal(taskToCreateDTO(task))
+	if err != nil {
+		return err
+	}
+	patched, err := mergepatch.Apply(doc, c.Body())
+	if err != nil {
+		t.log.Debug(c.Context(), "failed to apply merge patch", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	var dto CreateTaskDTO
+	if err := json.Unmarshal(patched, &dto); err != nil {
+		t.log.Debug(c.Context(), "failed to decode patched task", sl.Err(err))
+		return fiber_adapter.BadRequest(err)
+	}
+	params, err := t.taskParamsFromDTO(c, dto)
+	if err != nil {
+		return err
+	}
+	if err := t.tasksService.UpdateTaskById(c.Context(), login, taskId, version, params); err != nil {
+		logger_adapter.LogServiceError(t.log, c, err)
+		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(err.Err, tasks.ErrVersionMismatch) {
+			return fiber.ErrPreconditionFailed
+		}
+		return fiber_adapter.ServiceError(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,33 @@
+package tasks_controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+)
+
+// NewAdmin registers endpoints of the tasks administration, access
+// to the router should be restricted by the caller
+func NewAdmin(
+	router fiber.Router,
+	log *logger.Logger,
+	tasksService TasksService,
+) *Controller {
+	c := &Controller{log, tasksService}
+	router.Get("/prune", c.pruneCandidates)
+	return c
+}
+
+func (t *Controller) pruneCandidates(c *fiber.Ctx) error {
+	candidates, sErr := t.tasksService.PruneCandidates(c.Context())
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	tasksDto := make([]TaskDTO, len(candidates))
+	for i, t := range candidates {
+		tasksDto[i] = taskToDTO(t)
+	}
+	return c.JSON(tasksDto)
+}
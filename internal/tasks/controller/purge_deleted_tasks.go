@@ -0,0 +1,19 @@
+package tasks_controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+)
+
+func (c *Controller) PurgeDeletedTasks(ctx context.Context) {
+	if err := c.tasksService.PurgeDeletedTasks(ctx); err != nil {
+		c.log.Error(
+			ctx,
+			"failed to purge deleted tasks",
+			slog.String("message", err.Msg),
+			sl.Err(err.Err),
+		)
+	}
+}
@@ -10,15 +10,26 @@ import (
 )
 
 func (t *Controller) removeTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
 	taskId, err := t.taskId(c, c.Params("id"))
 	if err != nil {
 		return err
 	}
-	if err := t.tasksService.RemoveTaskById(c.Context(), taskId); err != nil {
+	version, err := t.ifMatch(c)
+	if err != nil {
+		return err
+	}
+	if err := t.tasksService.RemoveTaskById(c.Context(), login, taskId, version); err != nil {
 		logger_adapter.LogServiceError(t.log, c, err)
 		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
 			return fiber.ErrNotFound
 		}
+		if errors.Is(err.Err, tasks.ErrVersionMismatch) {
+			return fiber.ErrPreconditionFailed
+		}
 		return fiber_adapter.ServiceError(err)
 	}
 	return c.SendStatus(fiber.StatusNoContent)
@@ -0,0 +1,47 @@
+package tasks_controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type ReopenTaskDTO struct {
+	Reason *string `json:"reason"`
+}
+
+func (t *Controller) reopenTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	version, err := t.ifMatch(c)
+	if err != nil {
+		return err
+	}
+	var dto ReopenTaskDTO
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&dto); err != nil {
+			t.log.Debug(c.Context(), "failed to decode body")
+			return err
+		}
+	}
+	if err := t.tasksService.ReopenTaskById(c.Context(), login, taskId, version, dto.Reason); err != nil {
+		logger_adapter.LogServiceError(t.log, c, err)
+		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(err.Err, tasks.ErrVersionMismatch) {
+			return fiber.ErrPreconditionFailed
+		}
+		return fiber_adapter.ServiceError(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
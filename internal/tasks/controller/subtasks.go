@@ -0,0 +1,34 @@
+package tasks_controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func (t *Controller) subtasks(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	subtasks, sErr := t.tasksService.Subtasks(c.Context(), login, taskId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	tasksDto := make([]TaskDTO, len(subtasks))
+	for i, t := range subtasks {
+		tasksDto[i] = taskToDTO(t)
+	}
+	return c.JSON(tasksDto)
+}
@@ -3,65 +3,238 @@ package tasks_controller
 import (
 	"time"
 
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 )
 
 type TaskDTO struct {
-	Id          string  `json:"id" validate:"required"`
-	Title       string  `json:"title" validate:"required"`
+	Id          string          `json:"id" validate:"required"`
+	Owner       string          `json:"owner,omitempty"`
+	Title       string          `json:"title" validate:"required"`
+	Description *string         `json:"description,omitempty"`
+	Status      string          `json:"status" validate:"required"`
+	Priority    string          `json:"priority" validate:"required"`
+	DueDate     string          `json:"due_date" validate:"required"`
+	DueTime     *string         `json:"due_time,omitempty"`
+	TimeZone    string          `json:"time_zone,omitempty"`
+	ProjectId   *string         `json:"project_id,omitempty"`
+	ParentId    *string         `json:"parent_id,omitempty"`
+	Recurrence  *string         `json:"recurrence,omitempty"`
+	Assignee    *string         `json:"assignee,omitempty"`
+	Labels      []TaskLabelDTO  `json:"labels,omitempty"`
+	BlockedBy   []BlockerDTO    `json:"blocked_by,omitempty"`
+	Blocked     bool            `json:"blocked"`
+	Attachments []AttachmentDTO `json:"attachments,omitempty"`
+	Version     int64           `json:"version,omitempty"`
+	Position    string          `json:"position,omitempty"`
+	CreatedAt   string          `json:"created_at" validate:"required"`
+	UpdatedAt   string          `json:"updated_at" validate:"required"`
+	DeletedAt   *string         `json:"deleted_at,omitempty"`
+	ArchivedAt  *string         `json:"archived_at,omitempty"`
+	Match       *SearchMatchDTO `json:"match,omitempty"`
+}
+
+type SearchMatchDTO struct {
+	Rank        float32 `json:"rank"`
+	Title       string  `json:"title"`
 	Description *string `json:"description,omitempty"`
-	Status      string  `json:"status" validate:"required"`
-	Priority    string  `json:"priority" validate:"required"`
-	DueDate     string  `json:"due_date" validate:"required"`
-	CreatedAt   string  `json:"created_at" validate:"required"`
-	UpdatedAt   string  `json:"updated_at" validate:"required"`
+}
+
+type TaskLabelDTO struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type BlockerDTO struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type AttachmentDTO struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Uploader    string `json:"uploader"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func attachmentToDTO(a tasks.Attachment) AttachmentDTO {
+	return AttachmentDTO{
+		Id:          a.Id.String(),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Uploader:    a.Uploader,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
 }
 
 func taskToDTO(task tasks.Task) TaskDTO {
+	var projectId *string
+	if task.ProjectId != nil {
+		id := task.ProjectId.String()
+		projectId = &id
+	}
+	var parentId *string
+	if task.ParentId != nil {
+		id := task.ParentId.String()
+		parentId = &id
+	}
+	var recurrence *string
+	if task.Recurrence != nil {
+		r := task.Recurrence.String()
+		recurrence = &r
+	}
+	var labels []TaskLabelDTO
+	if len(task.Labels) > 0 {
+		labels = make([]TaskLabelDTO, len(task.Labels))
+		for i, l := range task.Labels {
+			labels[i] = TaskLabelDTO{
+				Id:   l.Id.String(),
+				Name: l.Name,
+			}
+		}
+	}
+	var blockedBy []BlockerDTO
+	if len(task.BlockedBy) > 0 {
+		blockedBy = make([]BlockerDTO, len(task.BlockedBy))
+		for i, b := range task.BlockedBy {
+			blockedBy[i] = BlockerDTO{
+				Id:     b.Id.String(),
+				Status: b.Status.String(),
+			}
+		}
+	}
+	var match *SearchMatchDTO
+	if task.Match != nil {
+		match = &SearchMatchDTO{
+			Rank:        task.Match.Rank,
+			Title:       task.Match.Title,
+			Description: task.Match.Description,
+		}
+	}
+	var dueTime *string
+	if task.DueTime != nil {
+		d := task.DueTime.String()
+		dueTime = &d
+	}
+	var deletedAt *string
+	if task.DeletedAt != nil {
+		d := task.DeletedAt.Format(time.RFC3339)
+		deletedAt = &d
+	}
+	var archivedAt *string
+	if task.ArchivedAt != nil {
+		d := task.ArchivedAt.Format(time.RFC3339)
+		archivedAt = &d
+	}
+	var attachments []AttachmentDTO
+	if len(task.Attachments) > 0 {
+		attachments = make([]AttachmentDTO, len(task.Attachments))
+		for i, a := range task.Attachments {
+			attachments[i] = attachmentToDTO(a)
+		}
+	}
 	return TaskDTO{
 		Id:          task.Id.String(),
+		Owner:       task.Owner,
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      task.Status.String(),
 		Priority:    task.Priority.String(),
 		DueDate:     task.DueDate.Format(time.DateOnly),
+		DueTime:     dueTime,
+		TimeZone:    task.TimeZone,
+		ProjectId:   projectId,
+		ParentId:    parentId,
+		Recurrence:  recurrence,
+		Assignee:    task.Assignee,
+		Labels:      labels,
+		BlockedBy:   blockedBy,
+		Blocked:     task.IsBlocked(),
+		Attachments: attachments,
+		Version:     task.Version,
+		Position:    task.Position,
 		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+		DeletedAt:   deletedAt,
+		ArchivedAt:  archivedAt,
+		Match:       match,
 	}
 }
 
 func taskFromDTO(dto TaskDTO) (tasks.Task, error) {
 	task := tasks.Task{
+		Owner:       dto.Owner,
 		Title:       dto.Title,
 		Description: dto.Description,
+		Assignee:    dto.Assignee,
 	}
 	var err error
 	if task.Id, err = tasks.ParseTaskId(dto.Id); err != nil {
 		return task, err
 	}
-	if task.Status, err = tasks.ParseStatus(dto.Status); err != nil {
-		return task, err
-	}
+	task.Status = tasks.Status(dto.Status)
 	if task.Priority, err = tasks.ParsePriority(dto.Priority); err != nil {
 		return task, err
 	}
 	if task.DueDate, err = time.Parse(time.DateOnly, dto.DueDate); err != nil {
 		return task, err
 	}
+	if dto.DueTime != nil {
+		dueTime, err := tasks.ParseDueTime(*dto.DueTime)
+		if err != nil {
+			return task, err
+		}
+		task.DueTime = &dueTime
+	}
+	if dto.ProjectId != nil {
+		projectId, err := projects.ParseProjectId(*dto.ProjectId)
+		if err != nil {
+			return task, err
+		}
+		task.ProjectId = &projectId
+	}
+	if dto.ParentId != nil {
+		parentId, err := tasks.ParseTaskId(*dto.ParentId)
+		if err != nil {
+			return task, err
+		}
+		task.ParentId = &parentId
+	}
+	if dto.Recurrence != nil {
+		recurrence, err := tasks.ParseRecurrence(*dto.Recurrence)
+		if err != nil {
+			return task, err
+		}
+		task.Recurrence = &recurrence
+	}
 	if task.CreatedAt, err = time.Parse(time.RFC3339, dto.CreatedAt); err != nil {
 		return task, err
 	}
 	if task.UpdatedAt, err = time.Parse(time.RFC3339, dto.UpdatedAt); err != nil {
 		return task, err
 	}
-	return tasks.NewTask(
+	created, err := tasks.NewTask(
 		task.Id,
+		task.Owner,
 		task.Title,
 		task.Description,
 		task.Status,
 		task.Priority,
 		task.DueDate,
+		task.ProjectId,
+		task.ParentId,
+		task.Recurrence,
+		task.Assignee,
 		task.CreatedAt,
 		task.UpdatedAt,
 	)
+	if err != nil {
+		return created, err
+	}
+	created.DueTime = task.DueTime
+	created.TimeZone = dto.TimeZone
+	return created, nil
 }
@@ -0,0 +1,69 @@
+package tasks_controller
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type TaskEventDTO struct {
+	Id        string           `json:"id"`
+	Actor     string           `json:"actor"`
+	Kind      string           `json:"kind"`
+	Changes   []FieldChangeDTO `json:"changes"`
+	Reason    *string          `json:"reason,omitempty"`
+	CreatedAt string           `json:"created_at"`
+}
+
+type FieldChangeDTO struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
+
+func taskEventToDTO(event tasks.TaskEvent) TaskEventDTO {
+	changes := make([]FieldChangeDTO, len(event.Changes))
+	for i, c := range event.Changes {
+		changes[i] = FieldChangeDTO{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		}
+	}
+	return TaskEventDTO{
+		Id:        event.Id.String(),
+		Actor:     event.Actor,
+		Kind:      event.Kind.String(),
+		Changes:   changes,
+		Reason:    event.Reason,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (t *Controller) taskHistory(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	events, sErr := t.tasksService.TaskHistory(c.Context(), login, taskId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		if errors.Is(sErr.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	eventsDto := make([]TaskEventDTO, len(events))
+	for i, e := range events {
+		eventsDto[i] = taskEventToDTO(e)
+	}
+	return c.JSON(eventsDto)
+}
@@ -0,0 +1,46 @@
+package tasks_controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func (t *Controller) trash(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	deleted, sErr := t.tasksService.Trash(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(t.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	tasksDto := make([]TaskDTO, len(deleted))
+	for i, t := range deleted {
+		tasksDto[i] = taskToDTO(t)
+	}
+	return c.JSON(tasksDto)
+}
+
+func (t *Controller) restoreTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
+	taskId, err := t.taskId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if err := t.tasksService.RestoreTaskById(c.Context(), login, taskId); err != nil {
+		logger_adapter.LogServiceError(t.log, c, err)
+		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -10,15 +10,23 @@ import (
 )
 
 func (t *Controller) updateTaskById(c *fiber.Ctx) error {
+	login, err := t.login(c)
+	if err != nil {
+		return err
+	}
 	taskId, err := t.taskId(c, c.Params("id"))
 	if err != nil {
 		return err
 	}
+	version, err := t.ifMatch(c)
+	if err != nil {
+		return err
+	}
 	params, err := t.taskParams(c)
 	if err != nil {
 		return err
 	}
-	if err := t.tasksService.UpdateTaskById(c.Context(), taskId, params); err != nil {
+	if err := t.tasksService.UpdateTaskById(c.Context(), login, taskId, version, params); err != nil {
 		t.log.Debug(
 			c.Context(),
 			"failed to update task",
@@ -28,6 +36,9 @@ func (t *Controller) updateTaskById(c *fiber.Ctx) error {
 		if errors.Is(err.Err, tasks.ErrTaskNotFound) {
 			return fiber.ErrNotFound
 		}
+		if errors.Is(err.Err, tasks.ErrVersionMismatch) {
+			return fiber.ErrPreconditionFailed
+		}
 		return fiber_adapter.ServiceError(err)
 	}
 	return c.SendStatus(fiber.StatusNoContent)
@@ -0,0 +1,42 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidDueTime = errors.New("invalid due time")
+
+// DueTime is the time of the day in the time zone of the task when
+// the task is due, it is counted from midnight with minute precision
+type DueTime time.Duration
+
+const dueTimeLayout = "15:04"
+
+// ParseDueTime parses the 24-hour `HH:MM` time, the leading zero
+// of the hour is optional
+func ParseDueTime(value string) (DueTime, error) {
+	t, err := time.Parse(dueTimeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDueTime, value)
+	}
+	return NewDueTime(t.Hour(), t.Minute())
+}
+
+func NewDueTime(hour int, minute int) (DueTime, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("%w: %d:%d", ErrInvalidDueTime, hour, minute)
+	}
+	return DueTime(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute), nil
+}
+
+// Duration returns the duration since midnight
+func (t DueTime) Duration() time.Duration {
+	return time.Duration(t)
+}
+
+func (t DueTime) String() string {
+	d := t.Duration()
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
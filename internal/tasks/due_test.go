@@ -0,0 +1,37 @@
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestParseDueTime(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+		err      error
+	}{
+		{value: "00:00", expected: "00:00"},
+		{value: "09:30", expected: "09:30"},
+		{value: "17:00", expected: "17:00"},
+		{value: "23:59", expected: "23:59"},
+		{value: "24:00", err: tasks.ErrInvalidDueTime},
+		{value: "9:30", expected: "09:30"},
+		{value: "17:00:00", err: tasks.ErrInvalidDueTime},
+		{value: "5pm", err: tasks.ErrInvalidDueTime},
+		{value: "", err: tasks.ErrInvalidDueTime},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			dueTime, err := tasks.ParseDueTime(c.value)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if err == nil && dueTime.String() != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, dueTime.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SystemActor is the actor of the changes made by the service itself,
+// such as the overdue tasks pruning
+const SystemActor = "system"
+
+type TaskEventKind string
+
+func (k TaskEventKind) String() string {
+	return string(k)
+}
+
+const (
+	TaskCreated  TaskEventKind = "created"
+	TaskUpdated  TaskEventKind = "updated"
+	TaskDeleted  TaskEventKind = "deleted"
+	TaskReopened TaskEventKind = "reopened"
+	TaskRestored TaskEventKind = "restored"
+	TaskArchived TaskEventKind = "archived"
+)
+
+type TaskEventId uuid.UUID
+
+func (id TaskEventId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewTaskEventId() TaskEventId {
+	return TaskEventId(uuid.New())
+}
+
+// FieldChange describes a change of the task field, values are formatted
+// as in the API and nil means that the value is absent
+type FieldChange struct {
+	Field  string
+	Before *string
+	After  *string
+}
+
+// TaskEvent is an entry of the task history, the reason is provided
+// by the actor for explicit actions such as reopening
+type TaskEvent struct {
+	Id        TaskEventId
+	TaskId    TaskId
+	Actor     string
+	Kind      TaskEventKind
+	Changes   []FieldChange
+	Reason    *string
+	CreatedAt time.Time
+}
+
+func NewTaskEvent(
+	id TaskEventId,
+	taskId TaskId,
+	actor string,
+	kind TaskEventKind,
+	changes []FieldChange,
+	reason *string,
+	createdAt time.Time,
+) TaskEvent {
+	return TaskEvent{
+		Id:        id,
+		TaskId:    taskId,
+		Actor:     actor,
+		Kind:      kind,
+		Changes:   changes,
+		Reason:    reason,
+		CreatedAt: createdAt,
+	}
+}
+
+type taskField struct {
+	name  string
+	value func(t *Task) *string
+}
+
+var taskFields = []taskField{
+	{"title", func(t *Task) *string {
+		return &t.Title
+	}},
+	{"description", func(t *Task) *string {
+		return t.Description
+	}},
+	{"status", func(t *Task) *string {
+		s := t.Status.String()
+		return &s
+	}},
+	{"priority", func(t *Task) *string {
+		p := t.Priority.String()
+		return &p
+	}},
+	{"due_date", func(t *Task) *string {
+		d := t.DueDate.Format(time.DateOnly)
+		return &d
+	}},
+	{"due_time", func(t *Task) *string {
+		if t.DueTime == nil {
+			return nil
+		}
+		d := t.DueTime.String()
+		return &d
+	}},
+	{"time_zone", func(t *Task) *string {
+		if t.TimeZone == "" {
+			return nil
+		}
+		return &t.TimeZone
+	}},
+	{"project_id", func(t *Task) *string {
+		if t.ProjectId == nil {
+			return nil
+		}
+		id := t.ProjectId.String()
+		return &id
+	}},
+	{"parent_id", func(t *Task) *string {
+		if t.ParentId == nil {
+			return nil
+		}
+		id := t.ParentId.String()
+		return &id
+	}},
+	{"recurrence", func(t *Task) *string {
+		if t.Recurrence == nil {
+			return nil
+		}
+		r := t.Recurrence.String()
+		return &r
+	}},
+	{"assignee", func(t *Task) *string {
+		return t.Assignee
+	}},
+	{"labels", func(t *Task) *string {
+		if len(t.Labels) == 0 {
+			return nil
+		}
+		names := make([]string, len(t.Labels))
+		for i, l := range t.Labels {
+			names[i] = l.Name
+		}
+		l := strings.Join(names, ",")
+		return &l
+	}},
+}
+
+// DiffTasks returns changes of the task fields, nil before means that
+// the task is created and nil after means that the task is deleted
+func DiffTasks(before *Task, after *Task) []FieldChange {
+	var changes []FieldChange
+	for _, f := range taskFields {
+		var b, a *string
+		if before != nil {
+			b = f.value(before)
+		}
+		if after != nil {
+			a = f.value(after)
+		}
+		if (b == nil && a == nil) || (b != nil && a != nil && *b == *a) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field:  f.name,
+			Before: b,
+			After:  a,
+		})
+	}
+	return changes
+}
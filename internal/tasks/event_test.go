@@ -0,0 +1,104 @@
+package tasks_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestDiffTasks(t *testing.T) {
+	description := "description"
+	assignee := "other"
+	before := tasks.Task{
+		Id:          tasks.NewTaskId(),
+		Title:       "title",
+		Description: &description,
+		Status:      tasks.Pending,
+		Priority:    tasks.Low,
+		DueDate:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		TimeZone:    "UTC",
+	}
+	after := before
+	after.Description = nil
+	after.Status = tasks.InProgress
+	after.Assignee = &assignee
+	after.Labels = []labels.Label{{Name: "bug"}, {Name: "urgent"}}
+	dueTime, err := tasks.NewDueTime(17, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rescheduled := before
+	rescheduled.DueTime = &dueTime
+	rescheduled.TimeZone = "Europe/Berlin"
+	str := func(s string) *string {
+		return &s
+	}
+	cases := []struct {
+		name     string
+		before   *tasks.Task
+		after    *tasks.Task
+		expected []tasks.FieldChange
+	}{
+		{
+			name:  "created",
+			after: &before,
+			expected: []tasks.FieldChange{
+				{Field: "title", After: str("title")},
+				{Field: "description", After: str("description")},
+				{Field: "status", After: str("pending")},
+				{Field: "priority", After: str("low")},
+				{Field: "due_date", After: str("2025-02-05")},
+				{Field: "time_zone", After: str("UTC")},
+			},
+		},
+		{
+			name:   "updated",
+			before: &before,
+			after:  &after,
+			expected: []tasks.FieldChange{
+				{Field: "description", Before: str("description")},
+				{Field: "status", Before: str("pending"), After: str("in_progress")},
+				{Field: "assignee", After: str("other")},
+				{Field: "labels", After: str("bug,urgent")},
+			},
+		},
+		{
+			name:   "rescheduled",
+			before: &before,
+			after:  &rescheduled,
+			expected: []tasks.FieldChange{
+				{Field: "due_time", After: str("17:00")},
+				{Field: "time_zone", Before: str("UTC"), After: str("Europe/Berlin")},
+			},
+		},
+		{
+			name:   "unchanged",
+			before: &before,
+			after:  &before,
+		},
+		{
+			name:   "deleted",
+			before: &after,
+			expected: []tasks.FieldChange{
+				{Field: "title", Before: str("title")},
+				{Field: "status", Before: str("in_progress")},
+				{Field: "priority", Before: str("low")},
+				{Field: "due_date", Before: str("2025-02-05")},
+				{Field: "time_zone", Before: str("UTC")},
+				{Field: "assignee", Before: str("other")},
+				{Field: "labels", Before: str("bug,urgent")},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			changes := tasks.DiffTasks(c.before, c.after)
+			if !reflect.DeepEqual(c.expected, changes) {
+				t.Fatalf("expected changes %v, but got %v", c.expected, changes)
+			}
+		})
+	}
+}
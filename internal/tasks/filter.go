@@ -0,0 +1,404 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+)
+
+// FilterExpr is a node of the parsed filter expression
+type FilterExpr interface {
+	filterExpr()
+}
+
+// AndExpr matches tasks matching all of the operands
+type AndExpr struct {
+	Operands []FilterExpr
+}
+
+// OrExpr matches tasks matching any of the operands
+type OrExpr struct {
+	Operands []FilterExpr
+}
+
+// NotExpr matches tasks that don't match the operand
+type NotExpr struct {
+	Operand FilterExpr
+}
+
+// CompareExpr compares the task field with the value, the value type
+// depends on the field and nil value means that the field is not set
+type CompareExpr struct {
+	Field FilterField
+	Op    FilterOp
+	Value any
+}
+
+func (AndExpr) filterExpr()     {}
+func (OrExpr) filterExpr()      {}
+func (NotExpr) filterExpr()     {}
+func (CompareExpr) filterExpr() {}
+
+type FilterField string
+
+const (
+	// FilterByStatus compares with Status
+	FilterByStatus FilterField = "status"
+	// FilterByPriority compares with Priority in the order of importance
+	FilterByPriority FilterField = "priority"
+	// FilterByDue compares the due date with time.Time
+	FilterByDue FilterField = "due"
+	// FilterByCreated compares the creation date with time.Time
+	FilterByCreated FilterField = "created"
+	// FilterByUpdated compares the update date with time.Time
+	FilterByUpdated FilterField = "updated"
+	// FilterByTitle matches titles containing the string
+	FilterByTitle FilterField = "title"
+	// FilterByLabel matches tasks with the label of the string name
+	FilterByLabel FilterField = "label"
+	// FilterByAssignee compares with the login, `me` is the current user
+	FilterByAssignee FilterField = "assignee"
+	// FilterByProject compares with projects.ProjectId
+	FilterByProject FilterField = "project"
+	// FilterByParent compares with TaskId
+	FilterByParent FilterField = "parent"
+)
+
+type FilterOp string
+
+const (
+	OpEq  FilterOp = ":"
+	OpLt  FilterOp = "<"
+	OpLte FilterOp = "<="
+	OpGt  FilterOp = ">"
+	OpGte FilterOp = ">="
+)
+
+// noneValue selects tasks without the value of the optional field
+const noneValue = "none"
+
+type filterField struct {
+	ordered  bool
+	optional bool
+	parse    func(value string) (any, error)
+}
+
+func parseFilterString(value string) (any, error) {
+	return value, nil
+}
+
+func parseFilterDate(value string) (any, error) {
+	return time.Parse(time.DateOnly, value)
+}
+
+var filterFields = map[FilterField]filterField{
+	FilterByStatus: {parse: func(value string) (any, error) {
+		return Status(value), nil
+	}},
+	FilterByPriority: {ordered: true, parse: func(value string) (any, error) {
+		return ParsePriority(value)
+	}},
+	FilterByDue:      {ordered: true, parse: parseFilterDate},
+	FilterByCreated:  {ordered: true, parse: parseFilterDate},
+	FilterByUpdated:  {ordered: true, parse: parseFilterDate},
+	FilterByTitle:    {parse: parseFilterString},
+	FilterByLabel:    {parse: parseFilterString},
+	FilterByAssignee: {optional: true, parse: parseFilterString},
+	FilterByProject: {optional: true, parse: func(value string) (any, error) {
+		return projects.ParseProjectId(value)
+	}},
+	FilterByParent: {optional: true, parse: func(value string) (any, error) {
+		return ParseTaskId(value)
+	}},
+}
+
+// ParseFilterExpr parses the filter expression such as
+// `status:in_progress priority>=medium (due<2026-11-01 OR -label:blocked)`.
+// Terms are combined with AND, `OR` has lower precedence,
+// `-` negates the term or the group and values with spaces are quoted.
+// The bare `none` value selects tasks without the optional field.
+func ParseFilterExpr(value string) (FilterExpr, error) {
+	p := &filterParser{input: value}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+	return expr, nil
+}
+
+type filterParser struct {
+	input string
+	pos   int
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidFilter, fmt.Sprintf(format, args...), p.pos)
+}
+
+func isFilterSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func (p *filterParser) skipSpaces() {
+	for p.pos < len(p.input) && isFilterSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// atOr reports whether the `OR` keyword is at the current position
+func (p *filterParser) atOr() bool {
+	rest := p.input[p.pos:]
+	if !strings.HasPrefix(rest, "OR") {
+		return false
+	}
+	return len(rest) == 2 || isFilterSpace(rest[2]) || rest[2] == '(' || rest[2] == '-'
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	var operands []FilterExpr
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+		p.skipSpaces()
+		if !p.atOr() {
+			break
+		}
+		p.pos += 2
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return OrExpr{operands}, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	var operands []FilterExpr
+	for {
+		p.skipSpaces()
+		if p.pos == len(p.input) || p.input[p.pos] == ')' || p.atOr() {
+			break
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+	}
+	switch len(operands) {
+	case 0:
+		return nil, p.errorf("expected term")
+	case 1:
+		return operands[0], nil
+	}
+	return AndExpr{operands}, nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	if p.input[p.pos] == '-' {
+		p.pos++
+		if p.pos == len(p.input) || isFilterSpace(p.input[p.pos]) {
+			return nil, p.errorf("expected term after negation")
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NotExpr{expr}, nil
+	}
+	if p.input[p.pos] == '(' {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos == len(p.input) || p.input[p.pos] != ')' {
+			return nil, p.errorf("expected closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *filterParser) parseCompare() (FilterExpr, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] == '_' || 'a' <= p.input[p.pos] && p.input[p.pos] <= 'z') {
+		p.pos++
+	}
+	name := FilterField(p.input[start:p.pos])
+	field, ok := filterFields[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown field %q", name)
+	}
+	var op FilterOp
+	for _, o := range []FilterOp{OpEq, OpLte, OpGte, OpLt, OpGt} {
+		if strings.HasPrefix(p.input[p.pos:], string(o)) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, p.errorf("expected operator after %q", name)
+	}
+	if op != OpEq && !field.ordered {
+		return nil, p.errorf("field %q supports only %q operator", name, OpEq)
+	}
+	p.pos += len(op)
+	valueStart := p.pos
+	value, quoted, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if field.optional && !quoted && value == noneValue {
+		return CompareExpr{name, op, nil}, nil
+	}
+	v, err := field.parse(value)
+	if err != nil {
+		p.pos = valueStart
+		return nil, p.errorf("invalid value %q of %q", value, name)
+	}
+	return CompareExpr{name, op, v}, nil
+}
+
+// parseValue returns the value and whether it was quoted
+func (p *filterParser) parseValue() (string, bool, error) {
+	if p.pos < len(p.input) && p.input[p.pos] == '"' {
+		p.pos++
+		b := strings.Builder{}
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			p.pos++
+			switch c {
+			case '"':
+				return b.String(), true, nil
+			case '\\':
+				if p.pos < len(p.input) {
+					c = p.input[p.pos]
+					p.pos++
+				}
+			}
+			b.WriteByte(c)
+		}
+		return "", true, p.errorf("unterminated quoted value")
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !isFilterSpace(p.input[p.pos]) && p.input[p.pos] != ')' {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", false, p.errorf("expected value")
+	}
+	return p.input[start:p.pos], false, nil
+}
+
+// FormatFilterExpr formats the expression into the form accepted by ParseFilterExpr
+func FormatFilterExpr(expr FilterExpr) string {
+	b := strings.Builder{}
+	writeFilterExpr(&b, expr, false)
+	return b.String()
+}
+
+// writeFilterExpr writes the expression, grouped expressions
+// are enclosed in parentheses
+func writeFilterExpr(b *strings.Builder, expr FilterExpr, grouped bool) {
+	switch e := expr.(type) {
+	case AndExpr:
+		if grouped {
+			b.WriteByte('(')
+		}
+		for i, o := range e.Operands {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			_, isOr := o.(OrExpr)
+			writeFilterExpr(b, o, isOr)
+		}
+		if grouped {
+			b.WriteByte(')')
+		}
+	case OrExpr:
+		if grouped {
+			b.WriteByte('(')
+		}
+		for i, o := range e.Operands {
+			if i > 0 {
+				b.WriteString(" OR ")
+			}
+			writeFilterExpr(b, o, false)
+		}
+		if grouped {
+			b.WriteByte(')')
+		}
+	case NotExpr:
+		b.WriteByte('-')
+		writeFilterExpr(b, e.Operand, true)
+	case CompareExpr:
+		b.WriteString(string(e.Field))
+		b.WriteString(string(e.Op))
+		writeFilterValue(b, e.Value)
+	}
+}
+
+func writeFilterValue(b *strings.Builder, value any) {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		b.WriteString(noneValue)
+		return
+	case time.Time:
+		s = v.Format(time.DateOnly)
+	case fmt.Stringer:
+		s = v.String()
+	case string:
+		s = v
+	}
+	if s != "" && s != noneValue && !strings.ContainsAny(s, " \t\n()\"\\") {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte('"')
+	for i := range len(s) {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+// filterStatuses returns statuses used in the filter expression
+func filterStatuses(expr FilterExpr) []Status {
+	var statuses []Status
+	var walk func(e FilterExpr)
+	walk = func(e FilterExpr) {
+		switch e := e.(type) {
+		case AndExpr:
+			for _, o := range e.Operands {
+				walk(o)
+			}
+		case OrExpr:
+			for _, o := range e.Operands {
+				walk(o)
+			}
+		case NotExpr:
+			walk(e.Operand)
+		case CompareExpr:
+			if s, ok := e.Value.(Status); ok {
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	walk(expr)
+	return statuses
+}
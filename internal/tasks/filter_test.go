@@ -0,0 +1,96 @@
+package tasks_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	projectId := projects.NewProjectId()
+	cases := []struct {
+		name  string
+		value string
+		expr  tasks.FilterExpr
+		err   error
+	}{
+		{
+			name:  "term",
+			value: "status:in_progress",
+			expr:  tasks.CompareExpr{Field: tasks.FilterByStatus, Op: tasks.OpEq, Value: tasks.InProgress},
+		},
+		{
+			name:  "implicit and",
+			value: "status:in_progress priority>=medium due<2026-11-01 -label:blocked",
+			expr: tasks.AndExpr{Operands: []tasks.FilterExpr{
+				tasks.CompareExpr{Field: tasks.FilterByStatus, Op: tasks.OpEq, Value: tasks.InProgress},
+				tasks.CompareExpr{Field: tasks.FilterByPriority, Op: tasks.OpGte, Value: tasks.Medium},
+				tasks.CompareExpr{Field: tasks.FilterByDue, Op: tasks.OpLt, Value: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+				tasks.NotExpr{Operand: tasks.CompareExpr{Field: tasks.FilterByLabel, Op: tasks.OpEq, Value: "blocked"}},
+			}},
+		},
+		{
+			name:  "or has lower precedence",
+			value: `priority:high OR assignee:me title:"release notes"`,
+			expr: tasks.OrExpr{Operands: []tasks.FilterExpr{
+				tasks.CompareExpr{Field: tasks.FilterByPriority, Op: tasks.OpEq, Value: tasks.High},
+				tasks.AndExpr{Operands: []tasks.FilterExpr{
+					tasks.CompareExpr{Field: tasks.FilterByAssignee, Op: tasks.OpEq, Value: "me"},
+					tasks.CompareExpr{Field: tasks.FilterByTitle, Op: tasks.OpEq, Value: "release notes"},
+				}},
+			}},
+		},
+		{
+			name:  "negated group",
+			value: "-(project:" + projectId.String() + " OR parent:none)",
+			expr: tasks.NotExpr{Operand: tasks.OrExpr{Operands: []tasks.FilterExpr{
+				tasks.CompareExpr{Field: tasks.FilterByProject, Op: tasks.OpEq, Value: projectId},
+				tasks.CompareExpr{Field: tasks.FilterByParent, Op: tasks.OpEq, Value: nil},
+			}}},
+		},
+		{name: "unknown field", value: "owner:login", err: tasks.ErrInvalidFilter},
+		{name: "unordered field", value: "status>pending", err: tasks.ErrInvalidFilter},
+		{name: "invalid value", value: "priority:urgent", err: tasks.ErrInvalidFilter},
+		{name: "missing value", value: "label:", err: tasks.ErrInvalidFilter},
+		{name: "unbalanced parenthesis", value: "(status:done", err: tasks.ErrInvalidFilter},
+		{name: "dangling or", value: "status:done OR", err: tasks.ErrInvalidFilter},
+		{name: "unterminated quote", value: `title:"foo`, err: tasks.ErrInvalidFilter},
+		{name: "empty", value: "", err: tasks.ErrInvalidFilter},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := tasks.ParseFilterExpr(tc.value)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(tc.expr, expr) {
+				t.Fatalf("expected expression %v, got %v", tc.expr, expr)
+			}
+		})
+	}
+}
+
+func TestFormatFilterExpr(t *testing.T) {
+	cases := []string{
+		"status:in_progress priority>=medium due<2026-11-01 -label:blocked",
+		"priority:high OR assignee:me title:\"release notes\"",
+		"(status:pending OR status:done) -(parent:none label:bug) created>=2025-01-01",
+		`label:"none" title:"say \"hi\"" project:none`,
+		"-(updated<=2025-02-01 OR -assignee:none)",
+	}
+	for _, value := range cases {
+		t.Run(value, func(t *testing.T) {
+			expr, err := tasks.ParseFilterExpr(value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if formatted := tasks.FormatFilterExpr(expr); formatted != value {
+				t.Fatalf("expected %q, got %q", value, formatted)
+			}
+		})
+	}
+}
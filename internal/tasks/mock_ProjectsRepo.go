@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package tasks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	projects "github.com/x0k/skillrock-tasks-service/internal/projects"
+)
+
+// MockProjectsRepo is an autogenerated mock type for the ProjectsRepo type
+type MockProjectsRepo struct {
+	mock.Mock
+}
+
+type MockProjectsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProjectsRepo) EXPECT() *MockProjectsRepo_Expecter {
+	return &MockProjectsRepo_Expecter{mock: &_m.Mock}
+}
+
+// ProjectRole provides a mock function with given fields: ctx, login, id
+func (_m *MockProjectsRepo) ProjectRole(ctx context.Context, login string, id projects.ProjectId) (projects.Role, error) {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectRole")
+	}
+
+	var r0 projects.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, projects.ProjectId) (projects.Role, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, projects.ProjectId) projects.Role); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Get(0).(projects.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, projects.ProjectId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProjectsRepo_ProjectRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectRole'
+type MockProjectsRepo_ProjectRole_Call struct {
+	*mock.Call
+}
+
+// ProjectRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id projects.ProjectId
+func (_e *MockProjectsRepo_Expecter) ProjectRole(ctx interface{}, login interface{}, id interface{}) *MockProjectsRepo_ProjectRole_Call {
+	return &MockProjectsRepo_ProjectRole_Call{Call: _e.mock.On("ProjectRole", ctx, login, id)}
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) Run(run func(ctx context.Context, login string, id projects.ProjectId)) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(projects.ProjectId))
+	})
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) Return(_a0 projects.Role, _a1 error) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProjectsRepo_ProjectRole_Call) RunAndReturn(run func(context.Context, string, projects.ProjectId) (projects.Role, error)) *MockProjectsRepo_ProjectRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProjectsRepo creates a new instance of MockProjectsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProjectsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProjectsRepo {
+	mock := &MockProjectsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,9 +4,11 @@ package tasks
 
 import (
 	context "context"
-	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+	labels "github.com/x0k/skillrock-tasks-service/internal/labels"
+
+	time "time"
 )
 
 // MockTasksRepo is an autogenerated mock type for the TasksRepo type
@@ -22,9 +24,9 @@ func (_m *MockTasksRepo) EXPECT() *MockTasksRepo_Expecter {
 	return &MockTasksRepo_Expecter{mock: &_m.Mock}
 }
 
-// AllTasks provides a mock function with given fields: ctx
-func (_m *MockTasksRepo) AllTasks(ctx context.Context) ([]Task, error) {
-	ret := _m.Called(ctx)
+// AllTasks provides a mock function with given fields: ctx, login
+func (_m *MockTasksRepo) AllTasks(ctx context.Context, login string) ([]Task, error) {
+	ret := _m.Called(ctx, login)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AllTasks")
@@ -32,19 +34,19 @@ func (_m *MockTasksRepo) AllTasks(ctx context.Context) ([]Task, error) {
 
 	var r0 []Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Task, error)); ok {
+		return rf(ctx, login)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Task); ok {
+		r0 = rf(ctx, login)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +61,14 @@ type MockTasksRepo_AllTasks_Call struct {
 
 // AllTasks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTasksRepo_Expecter) AllTasks(ctx interface{}) *MockTasksRepo_AllTasks_Call {
-	return &MockTasksRepo_AllTasks_Call{Call: _e.mock.On("AllTasks", ctx)}
+//   - login string
+func (_e *MockTasksRepo_Expecter) AllTasks(ctx interface{}, login interface{}) *MockTasksRepo_AllTasks_Call {
+	return &MockTasksRepo_AllTasks_Call{Call: _e.mock.On("AllTasks", ctx, login)}
 }
 
-func (_c *MockTasksRepo_AllTasks_Call) Run(run func(ctx context.Context)) *MockTasksRepo_AllTasks_Call {
+func (_c *MockTasksRepo_AllTasks_Call) Run(run func(ctx context.Context, login string)) *MockTasksRepo_AllTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -75,34 +78,81 @@ func (_c *MockTasksRepo_AllTasks_Call) Return(_a0 []Task, _a1 error) *MockTasksR
 	return _c
 }
 
-func (_c *MockTasksRepo_AllTasks_Call) RunAndReturn(run func(context.Context) ([]Task, error)) *MockTasksRepo_AllTasks_Call {
+func (_c *MockTasksRepo_AllTasks_Call) RunAndReturn(run func(context.Context, string) ([]Task, error)) *MockTasksRepo_AllTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindTasks provides a mock function with given fields: ctx, filter
-func (_m *MockTasksRepo) FindTasks(ctx context.Context, filter TasksFilter) ([]Task, error) {
+// ArchiveOverdueTasks provides a mock function with given fields: ctx, filter
+func (_m *MockTasksRepo) ArchiveOverdueTasks(ctx context.Context, filter OverdueFilter) error {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindTasks")
+		panic("no return value specified for ArchiveOverdueTasks")
 	}
 
-	var r0 []Task
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, OverdueFilter) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_ArchiveOverdueTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveOverdueTasks'
+type MockTasksRepo_ArchiveOverdueTasks_Call struct {
+	*mock.Call
+}
+
+// ArchiveOverdueTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter OverdueFilter
+func (_e *MockTasksRepo_Expecter) ArchiveOverdueTasks(ctx interface{}, filter interface{}) *MockTasksRepo_ArchiveOverdueTasks_Call {
+	return &MockTasksRepo_ArchiveOverdueTasks_Call{Call: _e.mock.On("ArchiveOverdueTasks", ctx, filter)}
+}
+
+func (_c *MockTasksRepo_ArchiveOverdueTasks_Call) Run(run func(ctx context.Context, filter OverdueFilter)) *MockTasksRepo_ArchiveOverdueTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(OverdueFilter))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_ArchiveOverdueTasks_Call) Return(_a0 error) *MockTasksRepo_ArchiveOverdueTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_ArchiveOverdueTasks_Call) RunAndReturn(run func(context.Context, OverdueFilter) error) *MockTasksRepo_ArchiveOverdueTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockersIds provides a mock function with given fields: ctx, ids
+func (_m *MockTasksRepo) BlockersIds(ctx context.Context, ids []TaskId) ([]TaskId, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockersIds")
+	}
+
+	var r0 []TaskId
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, TasksFilter) ([]Task, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []TaskId) ([]TaskId, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, TasksFilter) []Task); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []TaskId) []TaskId); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Task)
+			r0 = ret.Get(0).([]TaskId)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, TasksFilter) error); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, []TaskId) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,234 +160,1403 @@ func (_m *MockTasksRepo) FindTasks(ctx context.Context, filter TasksFilter) ([]T
 	return r0, r1
 }
 
-// MockTasksRepo_FindTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTasks'
-type MockTasksRepo_FindTasks_Call struct {
+// MockTasksRepo_BlockersIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockersIds'
+type MockTasksRepo_BlockersIds_Call struct {
 	*mock.Call
 }
 
-// FindTasks is a helper method to define mock.On call
+// BlockersIds is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filter TasksFilter
-func (_e *MockTasksRepo_Expecter) FindTasks(ctx interface{}, filter interface{}) *MockTasksRepo_FindTasks_Call {
-	return &MockTasksRepo_FindTasks_Call{Call: _e.mock.On("FindTasks", ctx, filter)}
+//   - ids []TaskId
+func (_e *MockTasksRepo_Expecter) BlockersIds(ctx interface{}, ids interface{}) *MockTasksRepo_BlockersIds_Call {
+	return &MockTasksRepo_BlockersIds_Call{Call: _e.mock.On("BlockersIds", ctx, ids)}
 }
 
-func (_c *MockTasksRepo_FindTasks_Call) Run(run func(ctx context.Context, filter TasksFilter)) *MockTasksRepo_FindTasks_Call {
+func (_c *MockTasksRepo_BlockersIds_Call) Run(run func(ctx context.Context, ids []TaskId)) *MockTasksRepo_BlockersIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(TasksFilter))
+		run(args[0].(context.Context), args[1].([]TaskId))
 	})
 	return _c
 }
 
-func (_c *MockTasksRepo_FindTasks_Call) Return(_a0 []Task, _a1 error) *MockTasksRepo_FindTasks_Call {
+func (_c *MockTasksRepo_BlockersIds_Call) Return(_a0 []TaskId, _a1 error) *MockTasksRepo_BlockersIds_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTasksRepo_FindTasks_Call) RunAndReturn(run func(context.Context, TasksFilter) ([]Task, error)) *MockTasksRepo_FindTasks_Call {
+func (_c *MockTasksRepo_BlockersIds_Call) RunAndReturn(run func(context.Context, []TaskId) ([]TaskId, error)) *MockTasksRepo_BlockersIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveOverdueTasksWithDueDateBefore provides a mock function with given fields: ctx, date
-func (_m *MockTasksRepo) RemoveOverdueTasksWithDueDateBefore(ctx context.Context, date time.Time) error {
-	ret := _m.Called(ctx, date)
+// CountOpenBlockers provides a mock function with given fields: ctx, id
+func (_m *MockTasksRepo) CountOpenBlockers(ctx context.Context, id TaskId) (int64, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveOverdueTasksWithDueDateBefore")
+		panic("no return value specified for CountOpenBlockers")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
-		r0 = rf(ctx, date)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) int64); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, TaskId) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveOverdueTasksWithDueDateBefore'
-type MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call struct {
+// MockTasksRepo_CountOpenBlockers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOpenBlockers'
+type MockTasksRepo_CountOpenBlockers_Call struct {
 	*mock.Call
 }
 
-// RemoveOverdueTasksWithDueDateBefore is a helper method to define mock.On call
+// CountOpenBlockers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - date time.Time
-func (_e *MockTasksRepo_Expecter) RemoveOverdueTasksWithDueDateBefore(ctx interface{}, date interface{}) *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call {
-	return &MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call{Call: _e.mock.On("RemoveOverdueTasksWithDueDateBefore", ctx, date)}
+//   - id TaskId
+func (_e *MockTasksRepo_Expecter) CountOpenBlockers(ctx interface{}, id interface{}) *MockTasksRepo_CountOpenBlockers_Call {
+	return &MockTasksRepo_CountOpenBlockers_Call{Call: _e.mock.On("CountOpenBlockers", ctx, id)}
 }
 
-func (_c *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call) Run(run func(ctx context.Context, date time.Time)) *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call {
+func (_c *MockTasksRepo_CountOpenBlockers_Call) Run(run func(ctx context.Context, id TaskId)) *MockTasksRepo_CountOpenBlockers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(TaskId))
 	})
 	return _c
 }
 
-func (_c *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call) Return(_a0 error) *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call {
-	_c.Call.Return(_a0)
+func (_c *MockTasksRepo_CountOpenBlockers_Call) Return(_a0 int64, _a1 error) *MockTasksRepo_CountOpenBlockers_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockTasksRepo_RemoveOverdueTasksWithDueDateBefore_Call {
+func (_c *MockTasksRepo_CountOpenBlockers_Call) RunAndReturn(run func(context.Context, TaskId) (int64, error)) *MockTasksRepo_CountOpenBlockers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveTaskById provides a mock function with given fields: ctx, id
-func (_m *MockTasksRepo) RemoveTaskById(ctx context.Context, id TaskId) error {
+// CountOpenSubtasks provides a mock function with given fields: ctx, id
+func (_m *MockTasksRepo) CountOpenSubtasks(ctx context.Context, id TaskId) (int64, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveTaskById")
+		panic("no return value specified for CountOpenSubtasks")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, TaskId) error); ok {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) int64); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, TaskId) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockTasksRepo_RemoveTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskById'
-type MockTasksRepo_RemoveTaskById_Call struct {
+// MockTasksRepo_CountOpenSubtasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOpenSubtasks'
+type MockTasksRepo_CountOpenSubtasks_Call struct {
 	*mock.Call
 }
 
-// RemoveTaskById is a helper method to define mock.On call
+// CountOpenSubtasks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id TaskId
-func (_e *MockTasksRepo_Expecter) RemoveTaskById(ctx interface{}, id interface{}) *MockTasksRepo_RemoveTaskById_Call {
-	return &MockTasksRepo_RemoveTaskById_Call{Call: _e.mock.On("RemoveTaskById", ctx, id)}
+func (_e *MockTasksRepo_Expecter) CountOpenSubtasks(ctx interface{}, id interface{}) *MockTasksRepo_CountOpenSubtasks_Call {
+	return &MockTasksRepo_CountOpenSubtasks_Call{Call: _e.mock.On("CountOpenSubtasks", ctx, id)}
 }
 
-func (_c *MockTasksRepo_RemoveTaskById_Call) Run(run func(ctx context.Context, id TaskId)) *MockTasksRepo_RemoveTaskById_Call {
+func (_c *MockTasksRepo_CountOpenSubtasks_Call) Run(run func(ctx context.Context, id TaskId)) *MockTasksRepo_CountOpenSubtasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(TaskId))
 	})
 	return _c
 }
 
-func (_c *MockTasksRepo_RemoveTaskById_Call) Return(_a0 error) *MockTasksRepo_RemoveTaskById_Call {
-	_c.Call.Return(_a0)
+func (_c *MockTasksRepo_CountOpenSubtasks_Call) Return(_a0 int64, _a1 error) *MockTasksRepo_CountOpenSubtasks_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTasksRepo_RemoveTaskById_Call) RunAndReturn(run func(context.Context, TaskId) error) *MockTasksRepo_RemoveTaskById_Call {
+func (_c *MockTasksRepo_CountOpenSubtasks_Call) RunAndReturn(run func(context.Context, TaskId) (int64, error)) *MockTasksRepo_CountOpenSubtasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveTask provides a mock function with given fields: ctx, task
-func (_m *MockTasksRepo) SaveTask(ctx context.Context, task Task) error {
-	ret := _m.Called(ctx, task)
+// CountTasks provides a mock function with given fields: ctx, login, filter
+func (_m *MockTasksRepo) CountTasks(ctx context.Context, login string, filter TasksFilter) (int64, error) {
+	ret := _m.Called(ctx, login, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveTask")
+		panic("no return value specified for CountTasks")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, Task) error); ok {
-		r0 = rf(ctx, task)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TasksFilter) (int64, error)); ok {
+		return rf(ctx, login, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, TasksFilter) int64); ok {
+		r0 = rf(ctx, login, filter)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, TasksFilter) error); ok {
+		r1 = rf(ctx, login, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockTasksRepo_SaveTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTask'
-type MockTasksRepo_SaveTask_Call struct {
+// MockTasksRepo_CountTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTasks'
+type MockTasksRepo_CountTasks_Call struct {
 	*mock.Call
 }
 
-// SaveTask is a helper method to define mock.On call
+// CountTasks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task Task
-func (_e *MockTasksRepo_Expecter) SaveTask(ctx interface{}, task interface{}) *MockTasksRepo_SaveTask_Call {
-	return &MockTasksRepo_SaveTask_Call{Call: _e.mock.On("SaveTask", ctx, task)}
+//   - login string
+//   - filter TasksFilter
+func (_e *MockTasksRepo_Expecter) CountTasks(ctx interface{}, login interface{}, filter interface{}) *MockTasksRepo_CountTasks_Call {
+	return &MockTasksRepo_CountTasks_Call{Call: _e.mock.On("CountTasks", ctx, login, filter)}
 }
 
-func (_c *MockTasksRepo_SaveTask_Call) Run(run func(ctx context.Context, task Task)) *MockTasksRepo_SaveTask_Call {
+func (_c *MockTasksRepo_CountTasks_Call) Run(run func(ctx context.Context, login string, filter TasksFilter)) *MockTasksRepo_CountTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(Task))
+		run(args[0].(context.Context), args[1].(string), args[2].(TasksFilter))
 	})
 	return _c
 }
 
-func (_c *MockTasksRepo_SaveTask_Call) Return(_a0 error) *MockTasksRepo_SaveTask_Call {
-	_c.Call.Return(_a0)
+func (_c *MockTasksRepo_CountTasks_Call) Return(_a0 int64, _a1 error) *MockTasksRepo_CountTasks_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTasksRepo_SaveTask_Call) RunAndReturn(run func(context.Context, Task) error) *MockTasksRepo_SaveTask_Call {
+func (_c *MockTasksRepo_CountTasks_Call) RunAndReturn(run func(context.Context, string, TasksFilter) (int64, error)) *MockTasksRepo_CountTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveTasks provides a mock function with given fields: ctx, _a1
-func (_m *MockTasksRepo) SaveTasks(ctx context.Context, _a1 []Task) error {
-	ret := _m.Called(ctx, _a1)
+// DeletedTaskById provides a mock function with given fields: ctx, login, id
+func (_m *MockTasksRepo) DeletedTaskById(ctx context.Context, login string, id TaskId) (Task, error) {
+	ret := _m.Called(ctx, login, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveTasks")
+		panic("no return value specified for DeletedTaskById")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []Task) error); ok {
-		r0 = rf(ctx, _a1)
+	var r0 Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId) (Task, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId) Task); ok {
+		r0 = rf(ctx, login, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(Task)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, TaskId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockTasksRepo_SaveTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTasks'
-type MockTasksRepo_SaveTasks_Call struct {
+// MockTasksRepo_DeletedTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletedTaskById'
+type MockTasksRepo_DeletedTaskById_Call struct {
 	*mock.Call
 }
 
-// SaveTasks is a helper method to define mock.On call
+// DeletedTaskById is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 []Task
-func (_e *MockTasksRepo_Expecter) SaveTasks(ctx interface{}, _a1 interface{}) *MockTasksRepo_SaveTasks_Call {
-	return &MockTasksRepo_SaveTasks_Call{Call: _e.mock.On("SaveTasks", ctx, _a1)}
+//   - login string
+//   - id TaskId
+func (_e *MockTasksRepo_Expecter) DeletedTaskById(ctx interface{}, login interface{}, id interface{}) *MockTasksRepo_DeletedTaskById_Call {
+	return &MockTasksRepo_DeletedTaskById_Call{Call: _e.mock.On("DeletedTaskById", ctx, login, id)}
 }
 
-func (_c *MockTasksRepo_SaveTasks_Call) Run(run func(ctx context.Context, _a1 []Task)) *MockTasksRepo_SaveTasks_Call {
+func (_c *MockTasksRepo_DeletedTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId)) *MockTasksRepo_DeletedTaskById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]Task))
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId))
 	})
 	return _c
 }
 
-func (_c *MockTasksRepo_SaveTasks_Call) Return(_a0 error) *MockTasksRepo_SaveTasks_Call {
-	_c.Call.Return(_a0)
+func (_c *MockTasksRepo_DeletedTaskById_Call) Return(_a0 Task, _a1 error) *MockTasksRepo_DeletedTaskById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_DeletedTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId) (Task, error)) *MockTasksRepo_DeletedTaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletedTasks provides a mock function with given fields: ctx, login
+func (_m *MockTasksRepo) DeletedTasks(ctx context.Context, login string) ([]Task, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletedTasks")
+	}
+
+	var r0 []Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Task, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Task); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_DeletedTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletedTasks'
+type MockTasksRepo_DeletedTasks_Call struct {
+	*mock.Call
+}
+
+// DeletedTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *MockTasksRepo_Expecter) DeletedTasks(ctx interface{}, login interface{}) *MockTasksRepo_DeletedTasks_Call {
+	return &MockTasksRepo_DeletedTasks_Call{Call: _e.mock.On("DeletedTasks", ctx, login)}
+}
+
+func (_c *MockTasksRepo_DeletedTasks_Call) Run(run func(ctx context.Context, login string)) *MockTasksRepo_DeletedTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_DeletedTasks_Call) Return(_a0 []Task, _a1 error) *MockTasksRepo_DeletedTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_DeletedTasks_Call) RunAndReturn(run func(context.Context, string) ([]Task, error)) *MockTasksRepo_DeletedTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTasks provides a mock function with given fields: ctx, login, filter, page
+func (_m *MockTasksRepo) FindTasks(ctx context.Context, login string, filter TasksFilter, page PageParams) ([]Task, error) {
+	ret := _m.Called(ctx, login, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTasks")
+	}
+
+	var r0 []Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TasksFilter, PageParams) ([]Task, error)); ok {
+		return rf(ctx, login, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, TasksFilter, PageParams) []Task); ok {
+		r0 = rf(ctx, login, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, TasksFilter, PageParams) error); ok {
+		r1 = rf(ctx, login, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_FindTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTasks'
+type MockTasksRepo_FindTasks_Call struct {
+	*mock.Call
+}
+
+// FindTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - filter TasksFilter
+//   - page PageParams
+func (_e *MockTasksRepo_Expecter) FindTasks(ctx interface{}, login interface{}, filter interface{}, page interface{}) *MockTasksRepo_FindTasks_Call {
+	return &MockTasksRepo_FindTasks_Call{Call: _e.mock.On("FindTasks", ctx, login, filter, page)}
+}
+
+func (_c *MockTasksRepo_FindTasks_Call) Run(run func(ctx context.Context, login string, filter TasksFilter, page PageParams)) *MockTasksRepo_FindTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TasksFilter), args[3].(PageParams))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_FindTasks_Call) Return(_a0 []Task, _a1 error) *MockTasksRepo_FindTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_FindTasks_Call) RunAndReturn(run func(context.Context, string, TasksFilter, PageParams) ([]Task, error)) *MockTasksRepo_FindTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsTaskDescendant provides a mock function with given fields: ctx, id, ancestorId
+func (_m *MockTasksRepo) IsTaskDescendant(ctx context.Context, id TaskId, ancestorId TaskId) (bool, error) {
+	ret := _m.Called(ctx, id, ancestorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTaskDescendant")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, TaskId) (bool, error)); ok {
+		return rf(ctx, id, ancestorId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, TaskId) bool); ok {
+		r0 = rf(ctx, id, ancestorId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, TaskId, TaskId) error); ok {
+		r1 = rf(ctx, id, ancestorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_IsTaskDescendant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTaskDescendant'
+type MockTasksRepo_IsTaskDescendant_Call struct {
+	*mock.Call
+}
+
+// IsTaskDescendant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - ancestorId TaskId
+func (_e *MockTasksRepo_Expecter) IsTaskDescendant(ctx interface{}, id interface{}, ancestorId interface{}) *MockTasksRepo_IsTaskDescendant_Call {
+	return &MockTasksRepo_IsTaskDescendant_Call{Call: _e.mock.On("IsTaskDescendant", ctx, id, ancestorId)}
+}
+
+func (_c *MockTasksRepo_IsTaskDescendant_Call) Run(run func(ctx context.Context, id TaskId, ancestorId TaskId)) *MockTasksRepo_IsTaskDescendant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_IsTaskDescendant_Call) Return(_a0 bool, _a1 error) *MockTasksRepo_IsTaskDescendant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_IsTaskDescendant_Call) RunAndReturn(run func(context.Context, TaskId, TaskId) (bool, error)) *MockTasksRepo_IsTaskDescendant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveTaskById provides a mock function with given fields: ctx, login, id, version, status, position, next
+func (_m *MockTasksRepo) MoveTaskById(ctx context.Context, login string, id TaskId, version int64, status Status, position string, next *Occurrence) error {
+	ret := _m.Called(ctx, login, id, version, status, position, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTaskById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId, int64, Status, string, *Occurrence) error); ok {
+		r0 = rf(ctx, login, id, version, status, position, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_MoveTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTaskById'
+type MockTasksRepo_MoveTaskById_Call struct {
+	*mock.Call
+}
+
+// MoveTaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id TaskId
+//   - version int64
+//   - status Status
+//   - position string
+//   - next *Occurrence
+func (_e *MockTasksRepo_Expecter) MoveTaskById(ctx interface{}, login interface{}, id interface{}, version interface{}, status interface{}, position interface{}, next interface{}) *MockTasksRepo_MoveTaskById_Call {
+	return &MockTasksRepo_MoveTaskById_Call{Call: _e.mock.On("MoveTaskById", ctx, login, id, version, status, position, next)}
+}
+
+func (_c *MockTasksRepo_MoveTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId, version int64, status Status, position string, next *Occurrence)) *MockTasksRepo_MoveTaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId), args[3].(int64), args[4].(Status), args[5].(string), args[6].(*Occurrence))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_MoveTaskById_Call) Return(_a0 error) *MockTasksRepo_MoveTaskById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_MoveTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId, int64, Status, string, *Occurrence) error) *MockTasksRepo_MoveTaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NextTaskPosition provides a mock function with given fields: ctx, login, status, position, exclude
+func (_m *MockTasksRepo) NextTaskPosition(ctx context.Context, login string, status Status, position string, exclude TaskId) (string, error) {
+	ret := _m.Called(ctx, login, status, position, exclude)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextTaskPosition")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Status, string, TaskId) (string, error)); ok {
+		return rf(ctx, login, status, position, exclude)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Status, string, TaskId) string); ok {
+		r0 = rf(ctx, login, status, position, exclude)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Status, string, TaskId) error); ok {
+		r1 = rf(ctx, login, status, position, exclude)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_NextTaskPosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextTaskPosition'
+type MockTasksRepo_NextTaskPosition_Call struct {
+	*mock.Call
+}
+
+// NextTaskPosition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - status Status
+//   - position string
+//   - exclude TaskId
+func (_e *MockTasksRepo_Expecter) NextTaskPosition(ctx interface{}, login interface{}, status interface{}, position interface{}, exclude interface{}) *MockTasksRepo_NextTaskPosition_Call {
+	return &MockTasksRepo_NextTaskPosition_Call{Call: _e.mock.On("NextTaskPosition", ctx, login, status, position, exclude)}
+}
+
+func (_c *MockTasksRepo_NextTaskPosition_Call) Run(run func(ctx context.Context, login string, status Status, position string, exclude TaskId)) *MockTasksRepo_NextTaskPosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(Status), args[3].(string), args[4].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_NextTaskPosition_Call) Return(_a0 string, _a1 error) *MockTasksRepo_NextTaskPosition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_NextTaskPosition_Call) RunAndReturn(run func(context.Context, string, Status, string, TaskId) (string, error)) *MockTasksRepo_NextTaskPosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OverdueTasks provides a mock function with given fields: ctx, filter
+func (_m *MockTasksRepo) OverdueTasks(ctx context.Context, filter OverdueFilter) ([]Task, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OverdueTasks")
+	}
+
+	var r0 []Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, OverdueFilter) ([]Task, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, OverdueFilter) []Task); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, OverdueFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_OverdueTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OverdueTasks'
+type MockTasksRepo_OverdueTasks_Call struct {
+	*mock.Call
+}
+
+// OverdueTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter OverdueFilter
+func (_e *MockTasksRepo_Expecter) OverdueTasks(ctx interface{}, filter interface{}) *MockTasksRepo_OverdueTasks_Call {
+	return &MockTasksRepo_OverdueTasks_Call{Call: _e.mock.On("OverdueTasks", ctx, filter)}
+}
+
+func (_c *MockTasksRepo_OverdueTasks_Call) Run(run func(ctx context.Context, filter OverdueFilter)) *MockTasksRepo_OverdueTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(OverdueFilter))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_OverdueTasks_Call) Return(_a0 []Task, _a1 error) *MockTasksRepo_OverdueTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_OverdueTasks_Call) RunAndReturn(run func(context.Context, OverdueFilter) ([]Task, error)) *MockTasksRepo_OverdueTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrevTaskPosition provides a mock function with given fields: ctx, login, status, position, exclude
+func (_m *MockTasksRepo) PrevTaskPosition(ctx context.Context, login string, status Status, position *string, exclude TaskId) (string, error) {
+	ret := _m.Called(ctx, login, status, position, exclude)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrevTaskPosition")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Status, *string, TaskId) (string, error)); ok {
+		return rf(ctx, login, status, position, exclude)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Status, *string, TaskId) string); ok {
+		r0 = rf(ctx, login, status, position, exclude)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Status, *string, TaskId) error); ok {
+		r1 = rf(ctx, login, status, position, exclude)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_PrevTaskPosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrevTaskPosition'
+type MockTasksRepo_PrevTaskPosition_Call struct {
+	*mock.Call
+}
+
+// PrevTaskPosition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - status Status
+//   - position *string
+//   - exclude TaskId
+func (_e *MockTasksRepo_Expecter) PrevTaskPosition(ctx interface{}, login interface{}, status interface{}, position interface{}, exclude interface{}) *MockTasksRepo_PrevTaskPosition_Call {
+	return &MockTasksRepo_PrevTaskPosition_Call{Call: _e.mock.On("PrevTaskPosition", ctx, login, status, position, exclude)}
+}
+
+func (_c *MockTasksRepo_PrevTaskPosition_Call) Run(run func(ctx context.Context, login string, status Status, position *string, exclude TaskId)) *MockTasksRepo_PrevTaskPosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(Status), args[3].(*string), args[4].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_PrevTaskPosition_Call) Return(_a0 string, _a1 error) *MockTasksRepo_PrevTaskPosition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_PrevTaskPosition_Call) RunAndReturn(run func(context.Context, string, Status, *string, TaskId) (string, error)) *MockTasksRepo_PrevTaskPosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTasksDeletedBefore provides a mock function with given fields: ctx, date
+func (_m *MockTasksRepo) PurgeTasksDeletedBefore(ctx context.Context, date time.Time) ([]AttachmentId, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTasksDeletedBefore")
+	}
+
+	var r0 []AttachmentId
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]AttachmentId, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []AttachmentId); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AttachmentId)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_PurgeTasksDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTasksDeletedBefore'
+type MockTasksRepo_PurgeTasksDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// PurgeTasksDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockTasksRepo_Expecter) PurgeTasksDeletedBefore(ctx interface{}, date interface{}) *MockTasksRepo_PurgeTasksDeletedBefore_Call {
+	return &MockTasksRepo_PurgeTasksDeletedBefore_Call{Call: _e.mock.On("PurgeTasksDeletedBefore", ctx, date)}
+}
+
+func (_c *MockTasksRepo_PurgeTasksDeletedBefore_Call) Run(run func(ctx context.Context, date time.Time)) *MockTasksRepo_PurgeTasksDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_PurgeTasksDeletedBefore_Call) Return(_a0 []AttachmentId, _a1 error) *MockTasksRepo_PurgeTasksDeletedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_PurgeTasksDeletedBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]AttachmentId, error)) *MockTasksRepo_PurgeTasksDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveOverdueTasks provides a mock function with given fields: ctx, filter
+func (_m *MockTasksRepo) RemoveOverdueTasks(ctx context.Context, filter OverdueFilter) error {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveOverdueTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, OverdueFilter) error); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_RemoveOverdueTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveOverdueTasks'
+type MockTasksRepo_RemoveOverdueTasks_Call struct {
+	*mock.Call
+}
+
+// RemoveOverdueTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter OverdueFilter
+func (_e *MockTasksRepo_Expecter) RemoveOverdueTasks(ctx interface{}, filter interface{}) *MockTasksRepo_RemoveOverdueTasks_Call {
+	return &MockTasksRepo_RemoveOverdueTasks_Call{Call: _e.mock.On("RemoveOverdueTasks", ctx, filter)}
+}
+
+func (_c *MockTasksRepo_RemoveOverdueTasks_Call) Run(run func(ctx context.Context, filter OverdueFilter)) *MockTasksRepo_RemoveOverdueTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(OverdueFilter))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveOverdueTasks_Call) Return(_a0 error) *MockTasksRepo_RemoveOverdueTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveOverdueTasks_Call) RunAndReturn(run func(context.Context, OverdueFilter) error) *MockTasksRepo_RemoveOverdueTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTaskAttachment provides a mock function with given fields: ctx, id, attachmentId
+func (_m *MockTasksRepo) RemoveTaskAttachment(ctx context.Context, id TaskId, attachmentId AttachmentId) error {
+	ret := _m.Called(ctx, id, attachmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, AttachmentId) error); ok {
+		r0 = rf(ctx, id, attachmentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_RemoveTaskAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskAttachment'
+type MockTasksRepo_RemoveTaskAttachment_Call struct {
+	*mock.Call
+}
+
+// RemoveTaskAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - attachmentId AttachmentId
+func (_e *MockTasksRepo_Expecter) RemoveTaskAttachment(ctx interface{}, id interface{}, attachmentId interface{}) *MockTasksRepo_RemoveTaskAttachment_Call {
+	return &MockTasksRepo_RemoveTaskAttachment_Call{Call: _e.mock.On("RemoveTaskAttachment", ctx, id, attachmentId)}
+}
+
+func (_c *MockTasksRepo_RemoveTaskAttachment_Call) Run(run func(ctx context.Context, id TaskId, attachmentId AttachmentId)) *MockTasksRepo_RemoveTaskAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(AttachmentId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskAttachment_Call) Return(_a0 error) *MockTasksRepo_RemoveTaskAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskAttachment_Call) RunAndReturn(run func(context.Context, TaskId, AttachmentId) error) *MockTasksRepo_RemoveTaskAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTaskBlocker provides a mock function with given fields: ctx, id, blockerId
+func (_m *MockTasksRepo) RemoveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error {
+	ret := _m.Called(ctx, id, blockerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, TaskId) error); ok {
+		r0 = rf(ctx, id, blockerId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_RemoveTaskBlocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskBlocker'
+type MockTasksRepo_RemoveTaskBlocker_Call struct {
+	*mock.Call
+}
+
+// RemoveTaskBlocker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - blockerId TaskId
+func (_e *MockTasksRepo_Expecter) RemoveTaskBlocker(ctx interface{}, id interface{}, blockerId interface{}) *MockTasksRepo_RemoveTaskBlocker_Call {
+	return &MockTasksRepo_RemoveTaskBlocker_Call{Call: _e.mock.On("RemoveTaskBlocker", ctx, id, blockerId)}
+}
+
+func (_c *MockTasksRepo_RemoveTaskBlocker_Call) Run(run func(ctx context.Context, id TaskId, blockerId TaskId)) *MockTasksRepo_RemoveTaskBlocker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskBlocker_Call) Return(_a0 error) *MockTasksRepo_RemoveTaskBlocker_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskBlocker_Call) RunAndReturn(run func(context.Context, TaskId, TaskId) error) *MockTasksRepo_RemoveTaskBlocker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTaskById provides a mock function with given fields: ctx, login, id, version
+func (_m *MockTasksRepo) RemoveTaskById(ctx context.Context, login string, id TaskId, version int64) error {
+	ret := _m.Called(ctx, login, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId, int64) error); ok {
+		r0 = rf(ctx, login, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_RemoveTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTaskById'
+type MockTasksRepo_RemoveTaskById_Call struct {
+	*mock.Call
+}
+
+// RemoveTaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id TaskId
+//   - version int64
+func (_e *MockTasksRepo_Expecter) RemoveTaskById(ctx interface{}, login interface{}, id interface{}, version interface{}) *MockTasksRepo_RemoveTaskById_Call {
+	return &MockTasksRepo_RemoveTaskById_Call{Call: _e.mock.On("RemoveTaskById", ctx, login, id, version)}
+}
+
+func (_c *MockTasksRepo_RemoveTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId, version int64)) *MockTasksRepo_RemoveTaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskById_Call) Return(_a0 error) *MockTasksRepo_RemoveTaskById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_RemoveTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId, int64) error) *MockTasksRepo_RemoveTaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReopenTaskById provides a mock function with given fields: ctx, login, id, version, position, reason
+func (_m *MockTasksRepo) ReopenTaskById(ctx context.Context, login string, id TaskId, version int64, position string, reason *string) error {
+	ret := _m.Called(ctx, login, id, version, position, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenTaskById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId, int64, string, *string) error); ok {
+		r0 = rf(ctx, login, id, version, position, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_ReopenTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReopenTaskById'
+type MockTasksRepo_ReopenTaskById_Call struct {
+	*mock.Call
+}
+
+// ReopenTaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id TaskId
+//   - version int64
+//   - position string
+//   - reason *string
+func (_e *MockTasksRepo_Expecter) ReopenTaskById(ctx interface{}, login interface{}, id interface{}, version interface{}, position interface{}, reason interface{}) *MockTasksRepo_ReopenTaskById_Call {
+	return &MockTasksRepo_ReopenTaskById_Call{Call: _e.mock.On("ReopenTaskById", ctx, login, id, version, position, reason)}
+}
+
+func (_c *MockTasksRepo_ReopenTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId, version int64, position string, reason *string)) *MockTasksRepo_ReopenTaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId), args[3].(int64), args[4].(string), args[5].(*string))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_ReopenTaskById_Call) Return(_a0 error) *MockTasksRepo_ReopenTaskById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_ReopenTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId, int64, string, *string) error) *MockTasksRepo_ReopenTaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTaskById provides a mock function with given fields: ctx, login, id
+func (_m *MockTasksRepo) RestoreTaskById(ctx context.Context, login string, id TaskId) error {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTaskById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId) error); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_RestoreTaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTaskById'
+type MockTasksRepo_RestoreTaskById_Call struct {
+	*mock.Call
+}
+
+// RestoreTaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id TaskId
+func (_e *MockTasksRepo_Expecter) RestoreTaskById(ctx interface{}, login interface{}, id interface{}) *MockTasksRepo_RestoreTaskById_Call {
+	return &MockTasksRepo_RestoreTaskById_Call{Call: _e.mock.On("RestoreTaskById", ctx, login, id)}
+}
+
+func (_c *MockTasksRepo_RestoreTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId)) *MockTasksRepo_RestoreTaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_RestoreTaskById_Call) Return(_a0 error) *MockTasksRepo_RestoreTaskById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_RestoreTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId) error) *MockTasksRepo_RestoreTaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTask provides a mock function with given fields: ctx, owner, task, labelIds
+func (_m *MockTasksRepo) SaveTask(ctx context.Context, owner string, task Task, labelIds []labels.LabelId) error {
+	ret := _m.Called(ctx, owner, task, labelIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Task, []labels.LabelId) error); ok {
+		r0 = rf(ctx, owner, task, labelIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_SaveTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTask'
+type MockTasksRepo_SaveTask_Call struct {
+	*mock.Call
+}
+
+// SaveTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - task Task
+//   - labelIds []labels.LabelId
+func (_e *MockTasksRepo_Expecter) SaveTask(ctx interface{}, owner interface{}, task interface{}, labelIds interface{}) *MockTasksRepo_SaveTask_Call {
+	return &MockTasksRepo_SaveTask_Call{Call: _e.mock.On("SaveTask", ctx, owner, task, labelIds)}
+}
+
+func (_c *MockTasksRepo_SaveTask_Call) Run(run func(ctx context.Context, owner string, task Task, labelIds []labels.LabelId)) *MockTasksRepo_SaveTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(Task), args[3].([]labels.LabelId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTask_Call) Return(_a0 error) *MockTasksRepo_SaveTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTask_Call) RunAndReturn(run func(context.Context, string, Task, []labels.LabelId) error) *MockTasksRepo_SaveTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTaskAttachment provides a mock function with given fields: ctx, id, attachment
+func (_m *MockTasksRepo) SaveTaskAttachment(ctx context.Context, id TaskId, attachment Attachment) error {
+	ret := _m.Called(ctx, id, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTaskAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, Attachment) error); ok {
+		r0 = rf(ctx, id, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_SaveTaskAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTaskAttachment'
+type MockTasksRepo_SaveTaskAttachment_Call struct {
+	*mock.Call
+}
+
+// SaveTaskAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - attachment Attachment
+func (_e *MockTasksRepo_Expecter) SaveTaskAttachment(ctx interface{}, id interface{}, attachment interface{}) *MockTasksRepo_SaveTaskAttachment_Call {
+	return &MockTasksRepo_SaveTaskAttachment_Call{Call: _e.mock.On("SaveTaskAttachment", ctx, id, attachment)}
+}
+
+func (_c *MockTasksRepo_SaveTaskAttachment_Call) Run(run func(ctx context.Context, id TaskId, attachment Attachment)) *MockTasksRepo_SaveTaskAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(Attachment))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTaskAttachment_Call) Return(_a0 error) *MockTasksRepo_SaveTaskAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTaskAttachment_Call) RunAndReturn(run func(context.Context, TaskId, Attachment) error) *MockTasksRepo_SaveTaskAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTaskBlocker provides a mock function with given fields: ctx, id, blockerId
+func (_m *MockTasksRepo) SaveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error {
+	ret := _m.Called(ctx, id, blockerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTaskBlocker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, TaskId) error); ok {
+		r0 = rf(ctx, id, blockerId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_SaveTaskBlocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTaskBlocker'
+type MockTasksRepo_SaveTaskBlocker_Call struct {
+	*mock.Call
+}
+
+// SaveTaskBlocker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - blockerId TaskId
+func (_e *MockTasksRepo_Expecter) SaveTaskBlocker(ctx interface{}, id interface{}, blockerId interface{}) *MockTasksRepo_SaveTaskBlocker_Call {
+	return &MockTasksRepo_SaveTaskBlocker_Call{Call: _e.mock.On("SaveTaskBlocker", ctx, id, blockerId)}
+}
+
+func (_c *MockTasksRepo_SaveTaskBlocker_Call) Run(run func(ctx context.Context, id TaskId, blockerId TaskId)) *MockTasksRepo_SaveTaskBlocker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTaskBlocker_Call) Return(_a0 error) *MockTasksRepo_SaveTaskBlocker_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTaskBlocker_Call) RunAndReturn(run func(context.Context, TaskId, TaskId) error) *MockTasksRepo_SaveTaskBlocker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTasks provides a mock function with given fields: ctx, owner, _a2
+func (_m *MockTasksRepo) SaveTasks(ctx context.Context, owner string, _a2 []Task) error {
+	ret := _m.Called(ctx, owner, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []Task) error); ok {
+		r0 = rf(ctx, owner, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTasksRepo_SaveTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTasks'
+type MockTasksRepo_SaveTasks_Call struct {
+	*mock.Call
+}
+
+// SaveTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - _a2 []Task
+func (_e *MockTasksRepo_Expecter) SaveTasks(ctx interface{}, owner interface{}, _a2 interface{}) *MockTasksRepo_SaveTasks_Call {
+	return &MockTasksRepo_SaveTasks_Call{Call: _e.mock.On("SaveTasks", ctx, owner, _a2)}
+}
+
+func (_c *MockTasksRepo_SaveTasks_Call) Run(run func(ctx context.Context, owner string, _a2 []Task)) *MockTasksRepo_SaveTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]Task))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTasks_Call) Return(_a0 error) *MockTasksRepo_SaveTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksRepo_SaveTasks_Call) RunAndReturn(run func(context.Context, string, []Task) error) *MockTasksRepo_SaveTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskAttachmentById provides a mock function with given fields: ctx, id, attachmentId
+func (_m *MockTasksRepo) TaskAttachmentById(ctx context.Context, id TaskId, attachmentId AttachmentId) (Attachment, error) {
+	ret := _m.Called(ctx, id, attachmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskAttachmentById")
+	}
+
+	var r0 Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, AttachmentId) (Attachment, error)); ok {
+		return rf(ctx, id, attachmentId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId, AttachmentId) Attachment); ok {
+		r0 = rf(ctx, id, attachmentId)
+	} else {
+		r0 = ret.Get(0).(Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, TaskId, AttachmentId) error); ok {
+		r1 = rf(ctx, id, attachmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_TaskAttachmentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskAttachmentById'
+type MockTasksRepo_TaskAttachmentById_Call struct {
+	*mock.Call
+}
+
+// TaskAttachmentById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+//   - attachmentId AttachmentId
+func (_e *MockTasksRepo_Expecter) TaskAttachmentById(ctx interface{}, id interface{}, attachmentId interface{}) *MockTasksRepo_TaskAttachmentById_Call {
+	return &MockTasksRepo_TaskAttachmentById_Call{Call: _e.mock.On("TaskAttachmentById", ctx, id, attachmentId)}
+}
+
+func (_c *MockTasksRepo_TaskAttachmentById_Call) Run(run func(ctx context.Context, id TaskId, attachmentId AttachmentId)) *MockTasksRepo_TaskAttachmentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId), args[2].(AttachmentId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskAttachmentById_Call) Return(_a0 Attachment, _a1 error) *MockTasksRepo_TaskAttachmentById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskAttachmentById_Call) RunAndReturn(run func(context.Context, TaskId, AttachmentId) (Attachment, error)) *MockTasksRepo_TaskAttachmentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskById provides a mock function with given fields: ctx, login, id
+func (_m *MockTasksRepo) TaskById(ctx context.Context, login string, id TaskId) (Task, error) {
+	ret := _m.Called(ctx, login, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskById")
+	}
+
+	var r0 Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId) (Task, error)); ok {
+		return rf(ctx, login, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId) Task); ok {
+		r0 = rf(ctx, login, id)
+	} else {
+		r0 = ret.Get(0).(Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, TaskId) error); ok {
+		r1 = rf(ctx, login, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_TaskById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskById'
+type MockTasksRepo_TaskById_Call struct {
+	*mock.Call
+}
+
+// TaskById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - id TaskId
+func (_e *MockTasksRepo_Expecter) TaskById(ctx interface{}, login interface{}, id interface{}) *MockTasksRepo_TaskById_Call {
+	return &MockTasksRepo_TaskById_Call{Call: _e.mock.On("TaskById", ctx, login, id)}
+}
+
+func (_c *MockTasksRepo_TaskById_Call) Run(run func(ctx context.Context, login string, id TaskId)) *MockTasksRepo_TaskById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskById_Call) Return(_a0 Task, _a1 error) *MockTasksRepo_TaskById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskById_Call) RunAndReturn(run func(context.Context, string, TaskId) (Task, error)) *MockTasksRepo_TaskById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskEvents provides a mock function with given fields: ctx, id
+func (_m *MockTasksRepo) TaskEvents(ctx context.Context, id TaskId) ([]TaskEvent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskEvents")
+	}
+
+	var r0 []TaskEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) ([]TaskEvent, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, TaskId) []TaskEvent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]TaskEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, TaskId) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTasksRepo_TaskEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskEvents'
+type MockTasksRepo_TaskEvents_Call struct {
+	*mock.Call
+}
+
+// TaskEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id TaskId
+func (_e *MockTasksRepo_Expecter) TaskEvents(ctx interface{}, id interface{}) *MockTasksRepo_TaskEvents_Call {
+	return &MockTasksRepo_TaskEvents_Call{Call: _e.mock.On("TaskEvents", ctx, id)}
+}
+
+func (_c *MockTasksRepo_TaskEvents_Call) Run(run func(ctx context.Context, id TaskId)) *MockTasksRepo_TaskEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(TaskId))
+	})
+	return _c
+}
+
+func (_c *MockTasksRepo_TaskEvents_Call) Return(_a0 []TaskEvent, _a1 error) *MockTasksRepo_TaskEvents_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTasksRepo_SaveTasks_Call) RunAndReturn(run func(context.Context, []Task) error) *MockTasksRepo_SaveTasks_Call {
+func (_c *MockTasksRepo_TaskEvents_Call) RunAndReturn(run func(context.Context, TaskId) ([]TaskEvent, error)) *MockTasksRepo_TaskEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTaskById provides a mock function with given fields: ctx, id, params
-func (_m *MockTasksRepo) UpdateTaskById(ctx context.Context, id TaskId, params TaskParams) error {
-	ret := _m.Called(ctx, id, params)
+// UpdateTaskById provides a mock function with given fields: ctx, login, id, version, params, position, next
+func (_m *MockTasksRepo) UpdateTaskById(ctx context.Context, login string, id TaskId, version int64, params TaskParams, position string, next *Occurrence) error {
+	ret := _m.Called(ctx, login, id, version, params, position, next)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTaskById")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, TaskId, TaskParams) error); ok {
-		r0 = rf(ctx, id, params)
+	if rf, ok := ret.Get(0).(func(context.Context, string, TaskId, int64, TaskParams, string, *Occurrence) error); ok {
+		r0 = rf(ctx, login, id, version, params, position, next)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -352,15 +1571,19 @@ type MockTasksRepo_UpdateTaskById_Call struct {
 
 // UpdateTaskById is a helper method to define mock.On call
 //   - ctx context.Context
+//   - login string
 //   - id TaskId
+//   - version int64
 //   - params TaskParams
-func (_e *MockTasksRepo_Expecter) UpdateTaskById(ctx interface{}, id interface{}, params interface{}) *MockTasksRepo_UpdateTaskById_Call {
-	return &MockTasksRepo_UpdateTaskById_Call{Call: _e.mock.On("UpdateTaskById", ctx, id, params)}
+//   - position string
+//   - next *Occurrence
+func (_e *MockTasksRepo_Expecter) UpdateTaskById(ctx interface{}, login interface{}, id interface{}, version interface{}, params interface{}, position interface{}, next interface{}) *MockTasksRepo_UpdateTaskById_Call {
+	return &MockTasksRepo_UpdateTaskById_Call{Call: _e.mock.On("UpdateTaskById", ctx, login, id, version, params, position, next)}
 }
 
-func (_c *MockTasksRepo_UpdateTaskById_Call) Run(run func(ctx context.Context, id TaskId, params TaskParams)) *MockTasksRepo_UpdateTaskById_Call {
+func (_c *MockTasksRepo_UpdateTaskById_Call) Run(run func(ctx context.Context, login string, id TaskId, version int64, params TaskParams, position string, next *Occurrence)) *MockTasksRepo_UpdateTaskById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(TaskId), args[2].(TaskParams))
+		run(args[0].(context.Context), args[1].(string), args[2].(TaskId), args[3].(int64), args[4].(TaskParams), args[5].(string), args[6].(*Occurrence))
 	})
 	return _c
 }
@@ -370,7 +1593,7 @@ func (_c *MockTasksRepo_UpdateTaskById_Call) Return(_a0 error) *MockTasksRepo_Up
 	return _c
 }
 
-func (_c *MockTasksRepo_UpdateTaskById_Call) RunAndReturn(run func(context.Context, TaskId, TaskParams) error) *MockTasksRepo_UpdateTaskById_Call {
+func (_c *MockTasksRepo_UpdateTaskById_Call) RunAndReturn(run func(context.Context, string, TaskId, int64, TaskParams, string, *Occurrence) error) *MockTasksRepo_UpdateTaskById_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package tasks
+
+import (
+	context "context"
+
+	auth "github.com/x0k/skillrock-tasks-service/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUsersRepo is an autogenerated mock type for the UsersRepo type
+type MockUsersRepo struct {
+	mock.Mock
+}
+
+type MockUsersRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsersRepo) EXPECT() *MockUsersRepo_Expecter {
+	return &MockUsersRepo_Expecter{mock: &_m.Mock}
+}
+
+// UserByLogin provides a mock function with given fields: ctx, login
+func (_m *MockUsersRepo) UserByLogin(ctx context.Context, login string) (*auth.User, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByLogin")
+	}
+
+	var r0 *auth.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.User, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersRepo_UserByLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByLogin'
+type MockUsersRepo_UserByLogin_Call struct {
+	*mock.Call
+}
+
+// UserByLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *MockUsersRepo_Expecter) UserByLogin(ctx interface{}, login interface{}) *MockUsersRepo_UserByLogin_Call {
+	return &MockUsersRepo_UserByLogin_Call{Call: _e.mock.On("UserByLogin", ctx, login)}
+}
+
+func (_c *MockUsersRepo_UserByLogin_Call) Run(run func(ctx context.Context, login string)) *MockUsersRepo_UserByLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUsersRepo_UserByLogin_Call) Return(_a0 *auth.User, _a1 error) *MockUsersRepo_UserByLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersRepo_UserByLogin_Call) RunAndReturn(run func(context.Context, string) (*auth.User, error)) *MockUsersRepo_UserByLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUsersRepo creates a new instance of MockUsersRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsersRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsersRepo {
+	mock := &MockUsersRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,14 +5,38 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 )
 
 var ErrInvalidStatus = errors.New("invalid status")
 var ErrInvalidPriority = errors.New("invalid priority")
 var ErrTaskNotFound = errors.New("task not found")
 var ErrTaskIsAlreadyDone = errors.New("task is already done")
+var ErrTaskIsNotDone = errors.New("task is not done")
+var ErrParentTaskIsDone = errors.New("parent task is done")
 var ErrInvalidTasksTitle = errors.New("invalid task title")
 var ErrTaskIdsConflict = errors.New("task ids conflict")
+var ErrInvalidLabelsMatch = errors.New("invalid labels match")
+var ErrParentTaskNotFound = errors.New("parent task not found")
+var ErrInvalidParentTask = errors.New("invalid parent task")
+var ErrTaskHasOpenSubtasks = errors.New("task has open subtasks")
+var ErrBlockerNotFound = errors.New("blocker not found")
+var ErrInvalidBlocker = errors.New("invalid blocker")
+var ErrDependencyCycle = errors.New("dependency cycle")
+var ErrTaskIsBlocked = errors.New("task is blocked")
+var ErrAttachmentNotFound = errors.New("attachment not found")
+var ErrInvalidAttachmentName = errors.New("invalid attachment name")
+var ErrAssigneeNotFound = errors.New("assignee not found")
+var ErrVersionMismatch = errors.New("version mismatch")
+var ErrInvalidTransition = errors.New("invalid status transition")
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+var ErrInvalidSort = errors.New("invalid sort")
+var ErrInvalidCursor = errors.New("invalid cursor")
+var ErrInvalidFilter = errors.New("invalid filter")
+var ErrInvalidPosition = errors.New("invalid position")
+var ErrParentTaskIsDeleted = errors.New("parent task is deleted")
+var ErrInvalidPrunePolicy = errors.New("invalid prune policy")
 
 type Status string
 
@@ -20,31 +44,14 @@ func (s Status) String() string {
 	return string(s)
 }
 
-func (s Status) IsValid() bool {
-	_, ok := statuses[string(s)]
-	return ok
-}
-
 const (
 	Pending    Status = "pending"
 	InProgress Status = "in_progress"
+	Blocked    Status = "blocked"
+	InReview   Status = "in_review"
 	Done       Status = "done"
 )
 
-var statuses = map[string]Status{
-	string(Pending):    Pending,
-	string(InProgress): InProgress,
-	string(Done):       Done,
-}
-
-func ParseStatus(st string) (Status, error) {
-	s, ok := statuses[st]
-	if !ok {
-		return Pending, ErrInvalidStatus
-	}
-	return s, nil
-}
-
 type Priority string
 
 func (p Priority) String() string {
@@ -76,6 +83,33 @@ func ParsePriority(pr string) (Priority, error) {
 	return p, nil
 }
 
+// LabelsMatch defines how the labels filter is applied
+type LabelsMatch string
+
+func (m LabelsMatch) String() string {
+	return string(m)
+}
+
+const (
+	// AnyLabel matches tasks with at least one of the labels
+	AnyLabel LabelsMatch = "any"
+	// AllLabels matches tasks with every one of the labels
+	AllLabels LabelsMatch = "all"
+)
+
+var labelsMatches = map[string]LabelsMatch{
+	string(AnyLabel):  AnyLabel,
+	string(AllLabels): AllLabels,
+}
+
+func ParseLabelsMatch(m string) (LabelsMatch, error) {
+	match, ok := labelsMatches[m]
+	if !ok {
+		return AnyLabel, ErrInvalidLabelsMatch
+	}
+	return match, nil
+}
+
 type TaskId uuid.UUID
 
 func (id TaskId) String() string {
@@ -94,15 +128,111 @@ func ParseTaskId(id string) (TaskId, error) {
 	return TaskId(uid), nil
 }
 
+// Blocker is a task that should be done before the dependent task
+// can be started
+type Blocker struct {
+	Id     TaskId
+	Status Status
+}
+
+type AttachmentId uuid.UUID
+
+func (id AttachmentId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewAttachmentId() AttachmentId {
+	return AttachmentId(uuid.New())
+}
+
+func ParseAttachmentId(id string) (AttachmentId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return AttachmentId(uuid.Nil), err
+	}
+	return AttachmentId(uid), nil
+}
+
+// Attachment describes a file attached to the task, the file content
+// is kept in the blob store under the attachment id
+type Attachment struct {
+	Id          AttachmentId
+	Name        string
+	ContentType string
+	Size        int64
+	Uploader    string
+	CreatedAt   time.Time
+}
+
+func NewAttachment(
+	id AttachmentId,
+	name string,
+	contentType string,
+	size int64,
+	uploader string,
+	createdAt time.Time,
+) (Attachment, error) {
+	if len(name) == 0 {
+		return Attachment{}, ErrInvalidAttachmentName
+	}
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	return Attachment{
+		Id:          id,
+		Name:        name,
+		ContentType: contentType,
+		Size:        size,
+		Uploader:    uploader,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
 type Task struct {
 	Id          TaskId
+	Owner       string
 	Title       string
 	Description *string
 	Status      Status
 	Priority    Priority
 	DueDate     time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// DueTime is absent for tasks that are due by the end of the due date
+	DueTime *DueTime
+	// TimeZone is the IANA time zone of the due date and time,
+	// new tasks without it get the default time zone of the owner
+	TimeZone   string
+	ProjectId  *projects.ProjectId
+	ParentId   *TaskId
+	Recurrence *Recurrence
+	// Assignee is the login of the user responsible for the task,
+	// the owner is the user who created it
+	Assignee    *string
+	Labels      []labels.Label
+	BlockedBy   []Blocker
+	Attachments []Attachment
+	// Version is incremented on every update of the task
+	Version int64
+	// Position orders tasks within the status column of the board,
+	// it is kept when the status is changed without the move
+	Position  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// DeletedAt is set for tasks in the trash
+	DeletedAt *time.Time
+	// ArchivedAt is set for tasks archived by the overdue pruning
+	ArchivedAt *time.Time
+	// Match is set for tasks found by the full-text search query
+	Match *SearchMatch
+}
+
+// IsBlocked reports whether some of the task blockers are not done yet
+func (t Task) IsBlocked() bool {
+	for _, b := range t.BlockedBy {
+		if b.Status != Done {
+			return true
+		}
+	}
+	return false
 }
 
 type TaskParams struct {
@@ -111,47 +241,106 @@ type TaskParams struct {
 	Status      Status
 	Priority    Priority
 	DueDate     time.Time
+	DueTime     *DueTime
+	// TimeZone of the due date, nil keeps the time zone of the task and
+	// new tasks get the default time zone of the owner
+	TimeZone   *string
+	ProjectId  *projects.ProjectId
+	ParentId   *TaskId
+	Recurrence *Recurrence
+	Assignee   *string
+	// LabelIds replaces the task labels, nil keeps them unchanged
+	LabelIds []labels.LabelId
 }
 
 func NewTask(
 	taskId TaskId,
+	owner string,
 	title string,
 	description *string,
 	status Status,
 	priority Priority,
 	dueDate time.Time,
+	projectId *projects.ProjectId,
+	parentId *TaskId,
+	recurrence *Recurrence,
+	assignee *string,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) (Task, error) {
 	if len(title) == 0 {
 		return Task{}, ErrInvalidTasksTitle
 	}
-	if !status.IsValid() {
+	if len(status) == 0 {
 		return Task{}, ErrInvalidStatus
 	}
 	if !priority.IsValid() {
 		return Task{}, ErrInvalidPriority
 	}
+	if parentId != nil && *parentId == taskId {
+		return Task{}, ErrInvalidParentTask
+	}
 	return Task{
 		Id:          taskId,
+		Owner:       owner,
 		Title:       title,
 		Description: description,
 		Status:      status,
 		Priority:    priority,
 		DueDate:     dueDate,
+		ProjectId:   projectId,
+		ParentId:    parentId,
+		Recurrence:  recurrence,
+		Assignee:    assignee,
+		Version:     1,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}, nil
 }
 
+// Occurrence is the next occurrence of the completed recurring task,
+// it is saved together with the completion of the task
+type Occurrence struct {
+	Task     Task
+	LabelIds []labels.LabelId
+}
+
+// MoveParams defines the status and the neighbours of the moved task
+// in the column of the status. Without neighbours the task is moved
+// to the end of the column.
+type MoveParams struct {
+	Status Status
+	// After is the task preceding the moved task
+	After *TaskId
+	// Before is the task following the moved task
+	Before *TaskId
+}
+
 type TasksFilter struct {
-	Title     *string
+	Title *string
+	// Query is the full-text search query over the title and
+	// the description in the web search syntax
+	Query     *string
 	Status    *Status
 	Priority  *Priority
 	DueBefore *time.Time
 	DueAfter  *time.Time
+	ProjectId *projects.ProjectId
+	ParentId  *TaskId
+	// Assignee contains the login of the assignee
+	Assignee *string
+	// Labels contains names of the labels
+	Labels      []string
+	LabelsMatch LabelsMatch
+	// ReadyToStart selects pending tasks without open blockers
+	ReadyToStart bool
+	// Expr is the parsed filter expression
+	Expr FilterExpr
+	// Archived selects archived tasks instead of the active ones
+	Archived bool
 }
 
 func (f TasksFilter) IsEmpty() bool {
-	return f.Title == nil && f.Status == nil && f.Priority == nil && f.DueBefore == nil && f.DueAfter == nil
+	return f.Title == nil && f.Query == nil && f.Status == nil && f.Priority == nil && f.DueBefore == nil && f.DueAfter == nil &&
+		f.ProjectId == nil && f.ParentId == nil && f.Assignee == nil && len(f.Labels) == 0 && !f.ReadyToStart && f.Expr == nil
 }
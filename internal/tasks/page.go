@@ -0,0 +1,234 @@
+package tasks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+type SortField string
+
+func (f SortField) String() string {
+	return string(f)
+}
+
+const (
+	SortByTitle     SortField = "title"
+	SortByDueDate   SortField = "due_date"
+	SortByPriority  SortField = "priority"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	// SortByRank orders tasks by relevance to the full-text search query
+	SortByRank SortField = "rank"
+	// SortByPosition orders tasks by their positions on the board
+	SortByPosition SortField = "position"
+)
+
+var sortFields = map[string]SortField{
+	string(SortByTitle):     SortByTitle,
+	string(SortByDueDate):   SortByDueDate,
+	string(SortByPriority):  SortByPriority,
+	string(SortByCreatedAt): SortByCreatedAt,
+	string(SortByUpdatedAt): SortByUpdatedAt,
+	string(SortByRank):      SortByRank,
+	string(SortByPosition):  SortByPosition,
+}
+
+type SortOrder struct {
+	Field SortField
+	Desc  bool
+}
+
+func (o SortOrder) String() string {
+	if o.Desc {
+		return "-" + o.Field.String()
+	}
+	return o.Field.String()
+}
+
+// Sort defines the order of the found tasks, tasks with equal
+// values of the sort fields are ordered by id
+type Sort []SortOrder
+
+func (s Sort) String() string {
+	orders := make([]string, len(s))
+	for i, o := range s {
+		orders[i] = o.String()
+	}
+	return strings.Join(orders, ",")
+}
+
+// DefaultSort orders tasks by the creation time
+var DefaultSort = Sort{{Field: SortByCreatedAt}}
+
+// DefaultSearchSort orders tasks found by the full-text search query
+// from the most relevant
+var DefaultSearchSort = Sort{{Field: SortByRank, Desc: true}}
+
+func (s Sort) HasField(field SortField) bool {
+	return slices.ContainsFunc(s, func(o SortOrder) bool { return o.Field == field })
+}
+
+// ParseSort parses comma separated fields, the field with the `-`
+// prefix is sorted in descending order
+func ParseSort(value string) (Sort, error) {
+	parts := strings.Split(value, ",")
+	sort := make(Sort, 0, len(parts))
+	for _, p := range parts {
+		var order SortOrder
+		if name, ok := strings.CutPrefix(p, "-"); ok {
+			order.Desc = true
+			p = name
+		}
+		field, ok := sortFields[p]
+		if !ok {
+			return nil, ErrInvalidSort
+		}
+		if sort.HasField(field) {
+			return nil, ErrInvalidSort
+		}
+		order.Field = field
+		sort = append(sort, order)
+	}
+	return sort, nil
+}
+
+// Cursor points to the last task of the previous page, values
+// contain fields of the task in the order of the sort
+type Cursor struct {
+	Sort   Sort
+	Values []any
+	Id     TaskId
+}
+
+func NewCursor(sort Sort, task Task) Cursor {
+	values := make([]any, len(sort))
+	for i, o := range sort {
+		switch o.Field {
+		case SortByTitle:
+			values[i] = task.Title
+		case SortByPosition:
+			values[i] = task.Position
+		case SortByDueDate:
+			values[i] = task.DueDate
+		case SortByPriority:
+			values[i] = task.Priority
+		case SortByCreatedAt:
+			values[i] = task.CreatedAt
+		case SortByUpdatedAt:
+			values[i] = task.UpdatedAt
+		case SortByRank:
+			var rank float32
+			if task.Match != nil {
+				rank = task.Match.Rank
+			}
+			values[i] = rank
+		}
+	}
+	return Cursor{sort, values, task.Id}
+}
+
+type cursorData struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+	Id     string   `json:"id"`
+}
+
+// String encodes the cursor as an opaque URL safe string
+func (c Cursor) String() string {
+	data := cursorData{
+		Sort:   c.Sort.String(),
+		Values: make([]string, len(c.Values)),
+		Id:     c.Id.String(),
+	}
+	for i, v := range c.Values {
+		switch v := v.(type) {
+		case string:
+			data.Values[i] = v
+		case Priority:
+			data.Values[i] = v.String()
+		case float32:
+			data.Values[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+		case time.Time:
+			if c.Sort[i].Field == SortByDueDate {
+				data.Values[i] = v.Format(time.DateOnly)
+			} else {
+				data.Values[i] = v.Format(time.RFC3339Nano)
+			}
+		}
+	}
+	b, _ := json.Marshal(data)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor decodes the cursor encoded by Cursor.String
+func ParseCursor(value string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var data cursorData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	sort, err := ParseSort(data.Sort)
+	if err != nil || len(data.Values) != len(sort) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := ParseTaskId(data.Id)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	values := make([]any, len(sort))
+	for i, o := range sort {
+		v := data.Values[i]
+		switch o.Field {
+		case SortByTitle, SortByPosition:
+			values[i] = v
+		case SortByPriority:
+			if values[i], err = ParsePriority(v); err != nil {
+				return Cursor{}, ErrInvalidCursor
+			}
+		case SortByDueDate:
+			if values[i], err = time.Parse(time.DateOnly, v); err != nil {
+				return Cursor{}, ErrInvalidCursor
+			}
+		case SortByCreatedAt, SortByUpdatedAt:
+			if values[i], err = time.Parse(time.RFC3339Nano, v); err != nil {
+				return Cursor{}, ErrInvalidCursor
+			}
+		case SortByRank:
+			rank, err := strconv.ParseFloat(v, 32)
+			if err != nil {
+				return Cursor{}, ErrInvalidCursor
+			}
+			values[i] = float32(rank)
+		}
+	}
+	return Cursor{sort, values, id}, nil
+}
+
+// PageParams selects the page of the found tasks, zero limit
+// means that all tasks are selected
+type PageParams struct {
+	Sort      Sort
+	Cursor    *Cursor
+	Limit     int
+	WithTotal bool
+}
+
+type TasksPage struct {
+	Tasks []Task
+	// NextCursor is nil for the last page
+	NextCursor *Cursor
+	// Total contains the count of all found tasks if it is requested
+	Total *int64
+}
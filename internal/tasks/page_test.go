@@ -0,0 +1,77 @@
+package tasks_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestParseSort(t *testing.T) {
+	cases := []struct {
+		value string
+		sort  tasks.Sort
+		err   error
+	}{
+		{
+			value: "due_date,-priority",
+			sort:  tasks.Sort{{Field: tasks.SortByDueDate}, {Field: tasks.SortByPriority, Desc: true}},
+		},
+		{
+			value: "-created_at",
+			sort:  tasks.Sort{{Field: tasks.SortByCreatedAt, Desc: true}},
+		},
+		{value: "description", err: tasks.ErrInvalidSort},
+		{value: "title,-title", err: tasks.ErrInvalidSort},
+		{value: "due_date,", err: tasks.ErrInvalidSort},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			sort, err := tasks.ParseSort(tc.value)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(tc.sort, sort) {
+				t.Fatalf("expected sort %v, got %v", tc.sort, sort)
+			}
+			if err == nil && sort.String() != tc.value {
+				t.Fatalf("expected sort string %q, got %q", tc.value, sort.String())
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	task := tasks.Task{
+		Id:        tasks.NewTaskId(),
+		Title:     "title",
+		Priority:  tasks.High,
+		DueDate:   time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2025, 2, 4, 10, 30, 15, 123456000, time.UTC),
+		UpdatedAt: time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		Match:     &tasks.SearchMatch{Rank: 0.0607927},
+	}
+	sort := tasks.Sort{
+		{Field: tasks.SortByDueDate},
+		{Field: tasks.SortByPriority, Desc: true},
+		{Field: tasks.SortByTitle},
+		{Field: tasks.SortByCreatedAt},
+		{Field: tasks.SortByUpdatedAt, Desc: true},
+		{Field: tasks.SortByRank, Desc: true},
+	}
+	cursor := tasks.NewCursor(sort, task)
+	parsed, err := tasks.ParseCursor(cursor.String())
+	if err != nil {
+		t.Fatalf("failed to parse cursor: %v", err)
+	}
+	if !reflect.DeepEqual(cursor, parsed) {
+		t.Fatalf("expected cursor %v, got %v", cursor, parsed)
+	}
+	for _, value := range []string{"", "foo", "e30"} {
+		if _, err := tasks.ParseCursor(value); !errors.Is(err, tasks.ErrInvalidCursor) {
+			t.Fatalf("expected invalid cursor error for %q, got %v", value, err)
+		}
+	}
+}
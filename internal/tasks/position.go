@@ -0,0 +1,107 @@
+package tasks
+
+import "strings"
+
+// positionDigits are digits of the position keys in the ascending order
+// of the byte-wise comparison
+const positionDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// PositionBetween returns the position key between the given keys,
+// the empty lower key is the start of the column and the empty upper key
+// is its end. Keys never end with the zero digit so there is always
+// a key between two different keys.
+func PositionBetween(lower string, upper string) (string, error) {
+	if upper == "" {
+		return positionAfter(lower), nil
+	}
+	if lower >= upper {
+		return "", ErrInvalidPosition
+	}
+	return positionMidpoint(lower, upper), nil
+}
+
+// positionAfter returns the key for the end of the column. The first digit
+// of the key is the header that defines the length of the integer part
+// following it, the integer part is incremented on every append and the
+// header is incremented when the integer part overflows, so the length of
+// the appended keys grows logarithmically with the number of appends.
+func positionAfter(lower string) string {
+	header := 0
+	if lower != "" {
+		header = strings.IndexByte(positionDigits, lower[0])
+	}
+	integer := make([]byte, header+1)
+	for i := range integer {
+		integer[i] = positionDigit(lower, i+1)
+	}
+	if positionIncrement(integer) {
+		if header == len(positionDigits)-1 {
+			return positionMidpoint(lower, "")
+		}
+		header++
+		integer = make([]byte, header+1)
+		for i := range integer {
+			integer[i] = positionDigits[0]
+		}
+		positionIncrement(integer)
+	}
+	// Integers ending with the zero digit are skipped, the second increment
+	// can't overflow since the last digit is zero
+	if integer[len(integer)-1] == positionDigits[0] {
+		positionIncrement(integer)
+	}
+	return string(positionDigits[header]) + string(integer)
+}
+
+// positionIncrement increments the integer written with the position digits
+// and reports whether it has overflowed
+func positionIncrement(integer []byte) bool {
+	for i := len(integer) - 1; i >= 0; i-- {
+		d := strings.IndexByte(positionDigits, integer[i])
+		if d < len(positionDigits)-1 {
+			integer[i] = positionDigits[d+1]
+			return false
+		}
+		integer[i] = positionDigits[0]
+	}
+	return true
+}
+
+func positionMidpoint(lower string, upper string) string {
+	if upper != "" {
+		// The common prefix is kept, missing digits of the lower key are zeros
+		n := 0
+		for n < len(upper) && positionDigit(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + positionMidpoint(lower[min(n, len(lower)):], upper[n:])
+		}
+	}
+	digitLower := 0
+	if lower != "" {
+		digitLower = strings.IndexByte(positionDigits, lower[0])
+	}
+	digitUpper := len(positionDigits)
+	if upper != "" {
+		digitUpper = strings.IndexByte(positionDigits, upper[0])
+	}
+	if digitUpper-digitLower > 1 {
+		return string(positionDigits[(digitLower+digitUpper+1)/2])
+	}
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if lower != "" {
+		rest = lower[1:]
+	}
+	return string(positionDigits[digitLower]) + positionMidpoint(rest, "")
+}
+
+func positionDigit(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return positionDigits[0]
+}
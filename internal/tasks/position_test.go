@@ -0,0 +1,84 @@
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestPositionBetween(t *testing.T) {
+	cases := []struct {
+		name  string
+		lower string
+		upper string
+		err   error
+	}{
+		{name: "empty column"},
+		{name: "column end", lower: "i"},
+		{name: "column start", upper: "i"},
+		{name: "adjacent digits", lower: "a", upper: "b"},
+		{name: "common prefix", lower: "a5", upper: "a6"},
+		{name: "shorter lower key", lower: "a", upper: "a1"},
+		{name: "last digit", lower: "z", upper: "zz"},
+		{name: "legacy keys", lower: "0000000001", upper: "0000000003"},
+		{name: "equal keys", lower: "a", upper: "a", err: tasks.ErrInvalidPosition},
+		{name: "reversed keys", lower: "b", upper: "a", err: tasks.ErrInvalidPosition},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			position, err := tasks.PositionBetween(c.lower, c.upper)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if position <= c.lower || (c.upper != "" && position >= c.upper) {
+				t.Fatalf("position %q is not between %q and %q", position, c.lower, c.upper)
+			}
+			if position[len(position)-1] == '0' {
+				t.Fatalf("position %q ends with zero", position)
+			}
+		})
+	}
+}
+
+func TestPositionBetweenRepeatedly(t *testing.T) {
+	lower, upper := "", ""
+	for i := range 200 {
+		position, err := tasks.PositionBetween(lower, upper)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if position <= lower || (upper != "" && position >= upper) {
+			t.Fatalf("position %q is not between %q and %q", position, lower, upper)
+		}
+		// Alternate insertions narrow the range from both sides
+		if i%2 == 0 {
+			lower = position
+		} else {
+			upper = position
+		}
+	}
+}
+
+func TestPositionBetweenAppends(t *testing.T) {
+	lower := ""
+	for range 100000 {
+		position, err := tasks.PositionBetween(lower, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if position <= lower {
+			t.Fatalf("position %q is not after %q", position, lower)
+		}
+		if position[len(position)-1] == '0' {
+			t.Fatalf("position %q ends with zero", position)
+		}
+		if len(position) > 5 {
+			t.Fatalf("position %q is too long after appends", position)
+		}
+		lower = position
+	}
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+)
+
+// PruneMode defines what happens to the pruned tasks
+type PruneMode string
+
+func (m PruneMode) String() string {
+	return string(m)
+}
+
+func (m PruneMode) IsValid() bool {
+	return m == PruneToTrash || m == PruneToArchive
+}
+
+const (
+	// PruneToTrash moves tasks to the trash, they are purged
+	// after the trash retention
+	PruneToTrash PruneMode = "trash"
+	// PruneToArchive keeps tasks in the archive, they are never purged
+	PruneToArchive PruneMode = "archive"
+)
+
+// PrunePolicy defines which overdue tasks are removed from the active
+// list by the periodic pruning, subtasks are pruned along with their parents
+type PrunePolicy struct {
+	Mode PruneMode
+	// Retention is how long not completed tasks are kept after the due date
+	Retention time.Duration
+	// Priorities limits the pruning to the tasks with the given
+	// priorities, tasks of any priority are pruned when empty
+	Priorities       []Priority
+	SkipWithComments bool
+	SkipWithLabels   bool
+	// DryRun only reports the tasks that would be pruned
+	DryRun bool
+}
+
+func NewPrunePolicy(
+	mode PruneMode,
+	retention time.Duration,
+	priorities []Priority,
+	skipWithComments bool,
+	skipWithLabels bool,
+	dryRun bool,
+) (PrunePolicy, error) {
+	if !mode.IsValid() {
+		return PrunePolicy{}, fmt.Errorf("%w: unknown mode %q", ErrInvalidPrunePolicy, mode)
+	}
+	if retention < 0 {
+		return PrunePolicy{}, fmt.Errorf("%w: negative retention %s", ErrInvalidPrunePolicy, retention)
+	}
+	for _, p := range priorities {
+		if !p.IsValid() {
+			return PrunePolicy{}, fmt.Errorf("%w: unknown priority %q", ErrInvalidPrunePolicy, p)
+		}
+	}
+	return PrunePolicy{
+		Mode:             mode,
+		Retention:        retention,
+		Priorities:       priorities,
+		SkipWithComments: skipWithComments,
+		SkipWithLabels:   skipWithLabels,
+		DryRun:           dryRun,
+	}, nil
+}
+
+// DefaultPrunePolicy moves tasks that are overdue for a week to the trash
+func DefaultPrunePolicy() PrunePolicy {
+	return PrunePolicy{
+		Mode:      PruneToTrash,
+		Retention: 7 * 24 * time.Hour,
+	}
+}
+
+// OverdueFilter selects not completed tasks with the due date before
+// the given one
+type OverdueFilter struct {
+	DueBefore        time.Time
+	Priorities       []Priority
+	SkipWithComments bool
+	SkipWithLabels   bool
+}
+
+// Filter returns the filter of the tasks to be pruned at the moment
+func (p PrunePolicy) Filter(now time.Time) OverdueFilter {
+	return OverdueFilter{
+		DueBefore:        now.Add(-p.Retention),
+		Priorities:       p.Priorities,
+		SkipWithComments: p.SkipWithComments,
+		SkipWithLabels:   p.SkipWithLabels,
+	}
+}
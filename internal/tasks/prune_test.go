@@ -0,0 +1,78 @@
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestNewPrunePolicy(t *testing.T) {
+	cases := []struct {
+		name       string
+		mode       tasks.PruneMode
+		retention  time.Duration
+		priorities []tasks.Priority
+		err        error
+	}{
+		{
+			name:      "all priorities",
+			mode:      tasks.PruneToTrash,
+			retention: 24 * time.Hour,
+		},
+		{
+			name:       "low priority only",
+			mode:       tasks.PruneToTrash,
+			retention:  24 * time.Hour,
+			priorities: []tasks.Priority{tasks.Low},
+		},
+		{
+			name:      "archive",
+			mode:      tasks.PruneToArchive,
+			retention: 24 * time.Hour,
+		},
+		{
+			name:      "unknown mode",
+			mode:      "delete",
+			retention: 24 * time.Hour,
+			err:       tasks.ErrInvalidPrunePolicy,
+		},
+		{
+			name:      "negative retention",
+			mode:      tasks.PruneToTrash,
+			retention: -time.Hour,
+			err:       tasks.ErrInvalidPrunePolicy,
+		},
+		{
+			name:       "unknown priority",
+			mode:       tasks.PruneToTrash,
+			retention:  24 * time.Hour,
+			priorities: []tasks.Priority{"urgent"},
+			err:        tasks.ErrInvalidPrunePolicy,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := tasks.NewPrunePolicy(c.mode, c.retention, c.priorities, false, false, false)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestPrunePolicyFilter(t *testing.T) {
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	policy, err := tasks.NewPrunePolicy(tasks.PruneToTrash, 72*time.Hour, []tasks.Priority{tasks.Low}, true, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := policy.Filter(now)
+	if want := time.Date(2025, 2, 7, 12, 0, 0, 0, time.UTC); !filter.DueBefore.Equal(want) {
+		t.Fatalf("expected due before %s, got %s", want, filter.DueBefore)
+	}
+	if len(filter.Priorities) != 1 || filter.Priorities[0] != tasks.Low || !filter.SkipWithComments || filter.SkipWithLabels {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+}
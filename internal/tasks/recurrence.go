@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+var frequencies = map[string]Frequency{
+	string(Daily):   Daily,
+	string(Weekly):  Weekly,
+	string(Monthly): Monthly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayCodes = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// Recurrence is a subset of the iCalendar RRULE that describes how
+// the task repeats, e.g. `FREQ=DAILY;INTERVAL=3`, `FREQ=WEEKLY;BYDAY=MO,TH`
+// or `FREQ=MONTHLY;BYMONTHDAY=15`
+type Recurrence struct {
+	Frequency Frequency
+	// Interval between occurrences in units of the frequency
+	Interval int
+	// Weekdays of the weekly recurrence starting from Monday,
+	// empty means the weekday of the due date
+	Weekdays []time.Weekday
+	// MonthDay of the monthly recurrence, days beyond the end of the month
+	// are moved to its last day, zero means the day of the due date
+	MonthDay int
+}
+
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	seen := make(map[string]struct{}, 4)
+	for _, part := range strings.Split(strings.ToUpper(rule), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Recurrence{}, ErrInvalidRecurrence
+		}
+		if _, ok := seen[key]; ok {
+			return Recurrence{}, ErrInvalidRecurrence
+		}
+		seen[key] = struct{}{}
+		switch key {
+		case "FREQ":
+			if r.Frequency, ok = frequencies[value]; !ok {
+				return Recurrence{}, ErrInvalidRecurrence
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return Recurrence{}, ErrInvalidRecurrence
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdays[code]
+				if !ok || slices.Contains(r.Weekdays, day) {
+					return Recurrence{}, ErrInvalidRecurrence
+				}
+				r.Weekdays = append(r.Weekdays, day)
+			}
+			slices.SortFunc(r.Weekdays, func(a, b time.Weekday) int {
+				return weekdayOffset(a) - weekdayOffset(b)
+			})
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > 31 {
+				return Recurrence{}, ErrInvalidRecurrence
+			}
+			r.MonthDay = day
+		default:
+			return Recurrence{}, ErrInvalidRecurrence
+		}
+	}
+	if r.Frequency == "" ||
+		(len(r.Weekdays) > 0 && r.Frequency != Weekly) ||
+		(r.MonthDay > 0 && r.Frequency != Monthly) {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	return r, nil
+}
+
+func (r Recurrence) String() string {
+	b := strings.Builder{}
+	b.WriteString("FREQ=")
+	b.WriteString(string(r.Frequency))
+	if r.Interval > 1 {
+		b.WriteString(";INTERVAL=")
+		b.WriteString(strconv.Itoa(r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		b.WriteString(";BYDAY=")
+		for i, d := range r.Weekdays {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(weekdayCodes[d])
+		}
+	}
+	if r.MonthDay > 0 {
+		b.WriteString(";BYMONTHDAY=")
+		b.WriteString(strconv.Itoa(r.MonthDay))
+	}
+	return b.String()
+}
+
+// Next returns the first occurrence after the due date
+func (r Recurrence) Next(due time.Time) time.Time {
+	interval := max(r.Interval, 1)
+	switch r.Frequency {
+	case Weekly:
+		return r.nextWeekly(due, interval)
+	case Monthly:
+		return r.nextMonthly(due, interval)
+	default:
+		return due.AddDate(0, 0, interval)
+	}
+}
+
+func (r Recurrence) nextWeekly(due time.Time, interval int) time.Time {
+	days := r.Weekdays
+	if len(days) == 0 {
+		days = []time.Weekday{due.Weekday()}
+	}
+	weekStart := due.AddDate(0, 0, -weekdayOffset(due.Weekday()))
+	// Rest of the current week
+	for d := weekdayOffset(due.Weekday()) + 1; d < 7; d++ {
+		next := weekStart.AddDate(0, 0, d)
+		if slices.Contains(days, next.Weekday()) {
+			return next
+		}
+	}
+	// Weekdays are sorted, so the first one opens the next period
+	return weekStart.AddDate(0, 0, 7*interval+weekdayOffset(days[0]))
+}
+
+func (r Recurrence) nextMonthly(due time.Time, interval int) time.Time {
+	day := r.MonthDay
+	if day == 0 {
+		day = due.Day()
+	}
+	if d := clampMonthDay(due.Year(), due.Month(), day); d > due.Day() {
+		return time.Date(due.Year(), due.Month(), d, 0, 0, 0, 0, due.Location())
+	}
+	month := time.Date(due.Year(), due.Month()+time.Month(interval), 1, 0, 0, 0, 0, due.Location())
+	return time.Date(
+		month.Year(),
+		month.Month(),
+		clampMonthDay(month.Year(), month.Month(), day),
+		0, 0, 0, 0,
+		due.Location(),
+	)
+}
+
+// weekdayOffset returns the number of days since Monday
+func weekdayOffset(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+func clampMonthDay(year int, month time.Month, day int) int {
+	return min(day, time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day())
+}
@@ -0,0 +1,87 @@
+package tasks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	cases := []struct {
+		rule     string
+		expected string
+		err      bool
+	}{
+		{rule: "FREQ=DAILY", expected: "FREQ=DAILY"},
+		{rule: "freq=daily;interval=3", expected: "FREQ=DAILY;INTERVAL=3"},
+		{rule: "FREQ=WEEKLY;BYDAY=TH,MO", expected: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=31", expected: "FREQ=MONTHLY;BYMONTHDAY=31"},
+		{rule: "", err: true},
+		{rule: "FREQ=YEARLY", err: true},
+		{rule: "INTERVAL=2", err: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", err: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", err: true},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,MO", err: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", err: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.rule, func(t *testing.T) {
+			r, err := tasks.ParseRecurrence(c.rule)
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected error, got %q", r.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.String() != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, r.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2025-02-05 is Wednesday
+	due := time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		rule     string
+		due      time.Time
+		expected string
+	}{
+		{rule: "FREQ=DAILY", due: due, expected: "2025-02-06"},
+		{rule: "FREQ=DAILY;INTERVAL=10", due: due, expected: "2025-02-15"},
+		{rule: "FREQ=WEEKLY", due: due, expected: "2025-02-12"},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,FR", due: due, expected: "2025-02-07"},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,TU", due: due, expected: "2025-02-10"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", due: due, expected: "2025-02-17"},
+		{rule: "FREQ=MONTHLY", due: due, expected: "2025-03-05"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=20", due: due, expected: "2025-02-20"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=1;INTERVAL=3", due: due, expected: "2025-05-01"},
+		{
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=31",
+			due:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: "2025-02-28",
+		},
+		{
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=31",
+			due:      time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			expected: "2025-03-31",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.rule, func(t *testing.T) {
+			r, err := tasks.ParseRecurrence(c.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next := r.Next(c.due).Format(time.DateOnly); next != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, next)
+			}
+		})
+	}
+}
@@ -2,16 +2,21 @@ package tasks
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 )
 
 type Repo struct {
@@ -28,15 +33,29 @@ func NewRepo(
 	return &Repo{log, pool, queries}
 }
 
-func (r *Repo) SaveTask(ctx context.Context, task Task) error {
-	return r.queries.InsertTask(ctx, db.InsertTaskParams{
+func (r *Repo) SaveTask(ctx context.Context, owner string, task Task, labelIds []labels.LabelId) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	if err := r.insertTask(ctx, queries, owner, task, labelIds); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// insertTask inserts the task with its labels and records its creation
+func (r *Repo) insertTask(ctx context.Context, queries *db.Queries, owner string, task Task, labelIds []labels.LabelId) error {
+	if err := queries.InsertTask(ctx, db.InsertTaskParams{
 		ID: pgtype.UUID{
 			Bytes: task.Id,
 			Valid: true,
 		},
 		Title:       task.Title,
 		Description: r.descriptionToPg(task.Description),
-		Status:      db.TaskStatus(task.Status),
+		Status:      task.Status.String(),
 		Priority:    db.TaskPriority(task.Priority),
 		DueDate: pgtype.Date{
 			Time:  task.DueDate,
@@ -50,37 +69,339 @@ func (r *Repo) SaveTask(ctx context.Context, task Task) error {
 			Time:  task.UpdatedAt.UTC(),
 			Valid: true,
 		},
+		Owner:      owner,
+		ProjectID:  r.projectIdToPg(task.ProjectId),
+		ParentID:   r.parentIdToPg(task.ParentId),
+		Recurrence: r.recurrenceToPg(task.Recurrence),
+		Assignee:   r.assigneeToPg(task.Assignee),
+		Position:   task.Position,
+		DueTime:    r.dueTimeToPg(task.DueTime),
+		TimeZone:   r.timeZoneToPg(task.TimeZone),
+	}); err != nil {
+		if isParentViolation(err) {
+			return ErrParentTaskNotFound
+		}
+		if isAssigneeViolation(err) {
+			return ErrAssigneeNotFound
+		}
+		return err
+	}
+	if err := r.setTaskLabels(ctx, queries, owner, task.Id, labelIds); err != nil {
+		return err
+	}
+	created, err := r.tasksSnapshot(ctx, queries, []TaskId{task.Id})
+	if err != nil {
+		return err
+	}
+	return r.saveTaskEvents(ctx, queries, owner, TaskCreated, nil, created, task.CreatedAt)
+}
+
+// saveOccurrence inserts the next occurrence of the recurring task
+// on behalf of its owner, nil occurrence is ignored
+func (r *Repo) saveOccurrence(ctx context.Context, queries *db.Queries, next *Occurrence) error {
+	if next == nil {
+		return nil
+	}
+	return r.insertTask(ctx, queries, next.Task.Owner, next.Task, next.LabelIds)
+}
+
+func (r *Repo) TaskById(ctx context.Context, login string, id TaskId) (Task, error) {
+	row, err := r.queries.TaskById(ctx, db.TaskByIdParams{
+		ID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Owner: login,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return Task{}, err
+	}
+	task, err := r.taskFromPg(row)
+	if err != nil {
+		return Task{}, err
+	}
+	tasks := []Task{task}
+	if err := r.loadRelations(ctx, tasks); err != nil {
+		return Task{}, err
+	}
+	return tasks[0], nil
 }
 
-func (r *Repo) UpdateTaskById(ctx context.Context, id TaskId, params TaskParams) error {
-	rowsAffected, err := r.queries.UpdateTask(ctx, db.UpdateTaskParams{
+// UpdateTaskById updates the task if its version is not changed,
+// the empty position keeps the task in its place. The next occurrence
+// of the recurring task is saved in the same transaction.
+func (r *Repo) UpdateTaskById(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	version int64,
+	params TaskParams,
+	position string,
+	next *Occurrence,
+) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	if err := r.checkVersion(ctx, queries, id, version); err != nil {
+		return err
+	}
+	before, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	// The time zone of the task is kept when it is absent
+	var timeZone pgtype.Text
+	if params.TimeZone != nil {
+		timeZone = r.timeZoneToPg(*params.TimeZone)
+	}
+	rowsAffected, err := queries.UpdateTask(ctx, db.UpdateTaskParams{
 		ID: pgtype.UUID{
 			Bytes: id,
 			Valid: true,
 		},
 		Title:       params.Title,
 		Description: r.descriptionToPg(params.Description),
-		Status:      db.TaskStatus(params.Status),
+		Status:      params.Status.String(),
 		Priority:    db.TaskPriority(params.Priority),
 		DueDate: pgtype.Date{
 			Time:  params.DueDate,
 			Valid: true,
 		},
+		ProjectID:  r.projectIdToPg(params.ProjectId),
+		ParentID:   r.parentIdToPg(params.ParentId),
+		Recurrence: r.recurrenceToPg(params.Recurrence),
+		Assignee:   r.assigneeToPg(params.Assignee),
+		Owner:      login,
+		DueTime:    r.dueTimeToPg(params.DueTime),
+		TimeZone:   timeZone,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+		Position: pgtype.Text{
+			String: position,
+			Valid:  position != "",
+		},
 	})
+	if isParentViolation(err) {
+		return ErrParentTaskNotFound
+	}
+	if isAssigneeViolation(err) {
+		return ErrAssigneeNotFound
+	}
 	if err != nil {
 		return err
 	}
 	if rowsAffected == 0 {
 		return ErrTaskNotFound
 	}
-	return nil
+	if params.LabelIds != nil {
+		if err := r.setTaskLabels(ctx, queries, login, id, params.LabelIds); err != nil {
+			return err
+		}
+	}
+	after, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, login, TaskUpdated, before, after, now); err != nil {
+		return err
+	}
+	if err := r.saveOccurrence(ctx, queries, next); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ReopenTaskById moves the completed task back to in progress at the
+// given position, the reopening is recorded with the given reason
+func (r *Repo) ReopenTaskById(ctx context.Context, login string, id TaskId, version int64, position string, reason *string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	if err := r.checkVersion(ctx, queries, id, version); err != nil {
+		return err
+	}
+	before, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	rowsAffected, err := queries.ReopenTask(ctx, db.ReopenTaskParams{
+		Status:   InProgress.String(),
+		Position: position,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+		ID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		DoneStatus: Done.String(),
+		Owner:      login,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTaskIsNotDone
+	}
+	after, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	if err := r.saveTaskEvent(ctx, queries, login, TaskReopened, &before[0], &after[0], reason, now); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// MoveTaskById sets the status and the position of the task
+// if its version is not changed. The update time is kept when
+// only the position changes, so reordering of completed tasks
+// does not count as their completion. The next occurrence of
+// the recurring task is saved in the same transaction.
+func (r *Repo) MoveTaskById(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	version int64,
+	status Status,
+	position string,
+	next *Occurrence,
+) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	if err := r.checkVersion(ctx, queries, id, version); err != nil {
+		return err
+	}
+	before, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	rowsAffected, err := queries.MoveTask(ctx, db.MoveTaskParams{
+		ID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Status:   status.String(),
+		Position: position,
+		Owner:    login,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTaskNotFound
+	}
+	after, err := r.tasksSnapshot(ctx, queries, []TaskId{id})
+	if err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, login, TaskUpdated, before, after, now); err != nil {
+		return err
+	}
+	if err := r.saveOccurrence(ctx, queries, next); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// PrevTaskPosition returns the greatest position in the status column
+// of the tasks visible to the user before the given one, nil position
+// means the end of the column and the empty result means that there are
+// no such tasks. Tasks in the trash and in the archive are not in the column.
+func (r *Repo) PrevTaskPosition(ctx context.Context, login string, status Status, position *string, exclude TaskId) (string, error) {
+	before := pgtype.Text{}
+	if position != nil {
+		before = pgtype.Text{
+			String: *position,
+			Valid:  true,
+		}
+	}
+	prev, err := r.queries.PrevTaskPosition(ctx, db.PrevTaskPositionParams{
+		Status: status.String(),
+		ID: pgtype.UUID{
+			Bytes: exclude,
+			Valid: true,
+		},
+		Login:  login,
+		Before: before,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return prev, err
+}
+
+// NextTaskPosition returns the least position in the status column
+// of the tasks visible to the user after the given one, the empty result
+// means that there are no such tasks
+func (r *Repo) NextTaskPosition(ctx context.Context, login string, status Status, position string, exclude TaskId) (string, error) {
+	next, err := r.queries.NextTaskPosition(ctx, db.NextTaskPositionParams{
+		Status: status.String(),
+		ID: pgtype.UUID{
+			Bytes: exclude,
+			Valid: true,
+		},
+		Login: login,
+		After: position,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return next, err
 }
 
-func (r *Repo) RemoveTaskById(ctx context.Context, id TaskId) error {
-	rowsAffected, err := r.queries.DeleteTask(ctx, pgtype.UUID{
+// RemoveTaskById moves the task with its subtasks to the trash if
+// the task version is not changed
+func (r *Repo) RemoveTaskById(ctx context.Context, login string, id TaskId, version int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	if err := r.checkVersion(ctx, queries, id, version); err != nil {
+		return err
+	}
+	taskId := pgtype.UUID{
 		Bytes: id,
 		Valid: true,
+	}
+	removed, err := r.tasksTreeSnapshot(ctx, queries, []pgtype.UUID{taskId})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	deletedAt := pgtype.Timestamp{
+		Time:  now.UTC(),
+		Valid: true,
+	}
+	rowsAffected, err := queries.DeleteTask(ctx, db.DeleteTaskParams{
+		ID:        taskId,
+		Owner:     login,
+		DeletedAt: deletedAt,
 	})
 	if err != nil {
 		return err
@@ -88,16 +409,149 @@ func (r *Repo) RemoveTaskById(ctx context.Context, id TaskId) error {
 	if rowsAffected == 0 {
 		return ErrTaskNotFound
 	}
-	return nil
+	if err := queries.DeleteTasks(ctx, db.DeleteTasksParams{
+		DeletedAt: deletedAt,
+		TaskIds:   tasksIdsToPg(removed),
+	}); err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, login, TaskDeleted, removed, nil, now); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DeletedTasks returns tasks in the trash visible to the user,
+// recently deleted tasks go first
+func (r *Repo) DeletedTasks(ctx context.Context, login string) ([]Task, error) {
+	rows, err := r.queries.DeletedTasks(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]Task, len(rows))
+	for i, row := range rows {
+		if tasks[i], err = r.taskFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.loadRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *Repo) DeletedTaskById(ctx context.Context, login string, id TaskId) (Task, error) {
+	row, err := r.queries.DeletedTaskById(ctx, db.DeletedTaskByIdParams{
+		ID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Owner: login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return Task{}, err
+	}
+	return r.taskFromPg(row)
+}
+
+// RestoreTaskById moves the task out of the trash together with
+// the subtasks deleted along with it, the parent task should not be
+// in the trash
+func (r *Repo) RestoreTaskById(ctx context.Context, login string, id TaskId) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	rows, err := queries.DeletedTasksTree(ctx, pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return ErrTaskNotFound
+	}
+	for _, row := range rows {
+		if TaskId(row.ID.Bytes) != id || !row.ParentID.Valid {
+			continue
+		}
+		deleted, err := queries.IsTaskDeleted(ctx, row.ParentID)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			return ErrParentTaskIsDeleted
+		}
+	}
+	ids := make([]pgtype.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	if err := queries.RestoreTasks(ctx, ids); err != nil {
+		return err
+	}
+	restored, err := r.snapshotFromPg(ctx, queries, rows)
+	if err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, login, TaskRestored, nil, restored, time.Now()); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// PurgeTasksDeletedBefore permanently removes tasks that are in
+// the trash since before the date and returns ids of the removed
+// attachments, archived subtasks of the removed tasks are kept
+func (r *Repo) PurgeTasksDeletedBefore(ctx context.Context, date time.Time) ([]AttachmentId, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	deletedAt := pgtype.Timestamp{
+		Time:  date.UTC(),
+		Valid: true,
+	}
+	ids, err := queries.DeletedTasksIds(ctx, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	// Archived subtasks are kept, so they are detached from the purged
+	// tasks before the removal cascades to them
+	if err := queries.DetachArchivedSubtasks(ctx, ids); err != nil {
+		return nil, err
+	}
+	attachments, err := queries.TasksTreeAttachmentsIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := queries.PurgeDeletedTasks(ctx, deletedAt); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return attachmentsIdsFromPg(attachments), nil
 }
 
-func (r *Repo) SaveTasks(ctx context.Context, tasks []Task) error {
+func (r *Repo) SaveTasks(ctx context.Context, owner string, tasks []Task) error {
 	if len(tasks) == 0 {
 		return nil
 	}
 	q := strings.Builder{}
 	q.WriteString(`INSERT INTO task
-(id, title, description, status, priority, due_date, created_at, updated_at)
+(id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, parent_id, recurrence, assignee, position, due_time, time_zone)
 VALUES `)
 	var args []any
 	push := func(arg any) {
@@ -137,72 +591,85 @@ VALUES `)
 			Time:  t.UpdatedAt.UTC(),
 			Valid: true,
 		})
+		q.WriteByte(',')
+		push(owner)
+		q.WriteByte(',')
+		push(r.projectIdToPg(t.ProjectId))
+		q.WriteByte(',')
+		push(r.parentIdToPg(t.ParentId))
+		q.WriteByte(',')
+		push(r.recurrenceToPg(t.Recurrence))
+		q.WriteByte(',')
+		push(r.assigneeToPg(t.Assignee))
+		q.WriteByte(',')
+		push(t.Position)
+		q.WriteByte(',')
+		push(r.dueTimeToPg(t.DueTime))
+		q.WriteString(",coalesce(")
+		push(r.timeZoneToPg(t.TimeZone))
+		q.WriteString(`::text, (SELECT "user".time_zone FROM "user" WHERE login = `)
+		push(owner)
+		q.WriteString("))")
 		q.WriteByte(')')
 	}
 	q.WriteByte(';')
-	_, err := r.pool.Exec(ctx, q.String(), args...)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	if _, err := tx.Exec(ctx, q.String(), args...); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return ErrTaskIdsConflict
 		}
+		if isParentViolation(err) {
+			return ErrParentTaskNotFound
+		}
+		if isAssigneeViolation(err) {
+			return ErrAssigneeNotFound
+		}
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, r.queries.WithTx(tx), owner, TaskCreated, nil, tasks, time.Now()); err != nil {
+		return err
 	}
-	return err
+	return tx.Commit(ctx)
 }
 
-func (r *Repo) FindTasks(ctx context.Context, f TasksFilter) ([]Task, error) {
-	q := strings.Builder{}
-	q.WriteString(`SELECT id, title, description, status, priority, due_date, created_at, updated_at FROM task`)
-	var args []any
-	push := func(arg any) {
-		args = append(args, arg)
-		q.WriteByte('$')
-		q.WriteString(strconv.Itoa(len(args)))
+// FindTasks returns tasks matching the filter in the order of the page sort,
+// tasks are selected after the page cursor
+func (r *Repo) FindTasks(ctx context.Context, login string, f TasksFilter, page PageParams) ([]Task, error) {
+	q := &tasksQuery{}
+	q.WriteString(`SELECT id, owner, title, description, status, priority, due_date, project_id, parent_id, recurrence, assignee, version, position, created_at, updated_at, archived_at, due_time, time_zone`)
+	if f.Query != nil {
+		q.WriteString(", " + searchRank + ", ts_headline('english', title, query, ")
+		q.push(titleHeadlineOptions)
+		q.WriteString("), ts_headline('english', description, query, ")
+		q.push(descriptionHeadlineOptions)
+		q.WriteByte(')')
 	}
-	isFirst := true
-	prepare := func() {
-		if isFirst {
-			q.WriteString(" WHERE ")
-			isFirst = false
-		} else {
-			q.WriteString(" AND ")
-		}
+	r.writeTasksFrom(q, f)
+	q.WriteString(" WHERE ")
+	r.writeTasksFilter(q, login, f)
+	if page.Cursor != nil {
+		r.writeTasksCursor(q, page.Cursor)
 	}
-	if !f.IsEmpty() {
-		if f.Title != nil {
-			prepare()
-			q.WriteString("title ILIKE ")
-			push("%" + *f.Title + "%")
-		}
-		if f.Status != nil {
-			prepare()
-			q.WriteString("status = ")
-			push(*f.Status)
-		}
-		if f.Priority != nil {
-			prepare()
-			q.WriteString("priority = ")
-			push(*f.Priority)
-		}
-		if f.DueAfter != nil {
-			prepare()
-			q.WriteString("due_date > ")
-			push(pgtype.Date{
-				Time:  *f.DueAfter,
-				Valid: true,
-			})
-		}
-		if f.DueBefore != nil {
-			prepare()
-			q.WriteString("due_date < ")
-			push(pgtype.Date{
-				Time:  *f.DueBefore,
-				Valid: true,
-			})
+	q.WriteString(" ORDER BY ")
+	for _, o := range page.Sort {
+		q.WriteString(sortColumns[o.Field])
+		if o.Desc {
+			q.WriteString(" DESC")
 		}
+		q.WriteString(", ")
+	}
+	q.WriteString("id")
+	if page.Limit > 0 {
+		q.WriteString(" LIMIT ")
+		q.push(page.Limit)
 	}
 	q.WriteByte(';')
-	rows, err := r.pool.Query(ctx, q.String(), args...)
+	rows, err := r.pool.Query(ctx, q.String(), q.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -210,59 +677,457 @@ func (r *Repo) FindTasks(ctx context.Context, f TasksFilter) ([]Task, error) {
 	var items []Task
 	for rows.Next() {
 		var row db.Task
-		if err := rows.Scan(
+		dest := []any{
 			&row.ID,
+			&row.Owner,
 			&row.Title,
 			&row.Description,
 			&row.Status,
 			&row.Priority,
 			&row.DueDate,
+			&row.ProjectID,
+			&row.ParentID,
+			&row.Recurrence,
+			&row.Assignee,
+			&row.Version,
+			&row.Position,
 			&row.CreatedAt,
 			&row.UpdatedAt,
-		); err != nil {
+			&row.ArchivedAt,
+			&row.DueTime,
+			&row.TimeZone,
+		}
+		var match SearchMatch
+		var description pgtype.Text
+		if f.Query != nil {
+			dest = append(dest, &match.Rank, &match.Title, &description)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, err
 		}
-		task, err := NewTask(
-			row.ID.Bytes,
-			row.Title,
-			r.descriptionFromPg(row.Description),
-			Status(row.Status),
-			Priority(row.Priority),
-			row.DueDate.Time,
-			row.CreatedAt.Time,
-			row.UpdatedAt.Time,
-		)
+		task, err := r.taskFromPg(row)
 		if err != nil {
 			return nil, err
 		}
+		if f.Query != nil {
+			match.Description = r.descriptionFromPg(description)
+			task.Match = &match
+		}
 		items = append(items, task)
 	}
-	return items, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *Repo) CountTasks(ctx context.Context, login string, f TasksFilter) (int64, error) {
+	q := &tasksQuery{}
+	q.WriteString(`SELECT count(*)`)
+	r.writeTasksFrom(q, f)
+	q.WriteString(" WHERE ")
+	r.writeTasksFilter(q, login, f)
+	q.WriteByte(';')
+	var count int64
+	err := r.pool.QueryRow(ctx, q.String(), q.args...).Scan(&count)
+	return count, err
+}
+
+// tasksQuery builds the query with positional arguments
+type tasksQuery struct {
+	strings.Builder
+	args []any
+}
+
+// arg adds the argument and returns its placeholder
+func (q *tasksQuery) arg(arg any) string {
+	q.args = append(q.args, arg)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *tasksQuery) push(arg any) {
+	q.WriteString(q.arg(arg))
+}
+
+var sortColumns = map[SortField]string{
+	SortByTitle:     "title",
+	SortByDueDate:   "due_date",
+	SortByPriority:  "priority",
+	SortByCreatedAt: "created_at",
+	SortByUpdatedAt: "updated_at",
+	SortByRank:      searchRank,
+	SortByPosition:  "position",
+}
+
+const searchRank = "ts_rank(task_search_vector(title, description), query)"
+
+const (
+	titleHeadlineOptions       = "HighlightAll=true, StartSel=" + HighlightStart + ", StopSel=" + HighlightStop
+	descriptionHeadlineOptions = "MaxFragments=2, StartSel=" + HighlightStart + ", StopSel=" + HighlightStop
+)
+
+// writeTasksFrom writes the source of tasks, the full-text search query
+// is joined as the query relation
+func (r *Repo) writeTasksFrom(q *tasksQuery, f TasksFilter) {
+	q.WriteString(" FROM task")
+	if f.Query != nil {
+		q.WriteString(" CROSS JOIN websearch_to_tsquery('english', ")
+		q.push(*f.Query)
+		q.WriteString(") AS query")
+	}
+}
+
+// writeTasksFilter writes conditions of the tasks visible to the user
+// and matching the filter
+func (r *Repo) writeTasksFilter(q *tasksQuery, login string, f TasksFilter) {
+	l := q.arg(login)
+	if f.Archived {
+		q.WriteString("archived_at IS NOT NULL")
+	} else {
+		q.WriteString("archived_at IS NULL")
+	}
+	q.WriteString(" AND deleted_at IS NULL AND (owner = " + l + " OR assignee = " + l +
+		" OR project_id IN (SELECT project_id FROM project_member WHERE login = " + l + "))")
+	if !f.IsEmpty() {
+		if f.Title != nil {
+			q.WriteString(" AND title ILIKE ")
+			q.push("%" + *f.Title + "%")
+		}
+		if f.Query != nil {
+			q.WriteString(" AND task_search_vector(title, description) @@ query")
+		}
+		if f.Status != nil {
+			q.WriteString(" AND status = ")
+			q.push(*f.Status)
+		}
+		if f.Priority != nil {
+			q.WriteString(" AND priority = ")
+			q.push(*f.Priority)
+		}
+		if f.DueAfter != nil {
+			q.WriteString(" AND due_date > ")
+			q.push(pgtype.Date{
+				Time:  *f.DueAfter,
+				Valid: true,
+			})
+		}
+		if f.DueBefore != nil {
+			q.WriteString(" AND due_date < ")
+			q.push(pgtype.Date{
+				Time:  *f.DueBefore,
+				Valid: true,
+			})
+		}
+		if f.ProjectId != nil {
+			q.WriteString(" AND project_id = ")
+			q.push(r.projectIdToPg(f.ProjectId))
+		}
+		if f.ParentId != nil {
+			q.WriteString(" AND parent_id = ")
+			q.push(r.parentIdToPg(f.ParentId))
+		}
+		if f.Assignee != nil {
+			q.WriteString(" AND assignee = ")
+			q.push(*f.Assignee)
+		}
+		if f.ReadyToStart {
+			q.WriteString(" AND status = ")
+			q.push(Pending.String())
+			q.WriteString(` AND NOT EXISTS (SELECT 1 FROM task_dependency
+JOIN task AS blocker ON blocker.id = task_dependency.blocked_by_id
+WHERE task_dependency.task_id = task.id AND blocker.status != `)
+			q.push(Done.String())
+			q.WriteString(" AND blocker.deleted_at IS NULL AND blocker.archived_at IS NULL)")
+		}
+		if len(f.Labels) > 0 {
+			const labelsSubquery = ` FROM task_label JOIN label ON label.id = task_label.label_id
+WHERE task_label.task_id = task.id AND label.name = ANY(`
+			if f.LabelsMatch == AllLabels {
+				q.WriteString(" AND (SELECT count(DISTINCT label.name)")
+				q.WriteString(labelsSubquery)
+				q.push(f.Labels)
+				q.WriteString(")) = ")
+				q.push(len(uniqueLabelNames(f.Labels)))
+			} else {
+				q.WriteString(" AND EXISTS (SELECT 1")
+				q.WriteString(labelsSubquery)
+				q.push(f.Labels)
+				q.WriteString("))")
+			}
+		}
+		if f.Expr != nil {
+			q.WriteString(" AND ")
+			r.writeFilterExpr(q, l, f.Expr)
+		}
+	}
+}
+
+var filterColumns = map[FilterField]string{
+	FilterByStatus:   "status",
+	FilterByPriority: "priority",
+	FilterByDue:      "due_date",
+	FilterByCreated:  "created_at::date",
+	FilterByUpdated:  "updated_at::date",
+	FilterByTitle:    "title",
+	FilterByAssignee: "assignee",
+	FilterByProject:  "project_id",
+	FilterByParent:   "parent_id",
+}
+
+var filterOps = map[FilterOp]string{
+	OpEq:  " = ",
+	OpLt:  " < ",
+	OpLte: " <= ",
+	OpGt:  " > ",
+	OpGte: " >= ",
+}
+
+// writeFilterExpr compiles the filter expression, conditions never
+// evaluate to NULL so negation selects tasks without the value of
+// the optional field. The placeholder of the login argument replaces
+// the `me` assignee.
+func (r *Repo) writeFilterExpr(q *tasksQuery, loginArg string, expr FilterExpr) {
+	switch e := expr.(type) {
+	case AndExpr:
+		r.writeFilterOperands(q, loginArg, " AND ", e.Operands)
+	case OrExpr:
+		r.writeFilterOperands(q, loginArg, " OR ", e.Operands)
+	case NotExpr:
+		q.WriteString("NOT ")
+		r.writeFilterOperands(q, loginArg, "", []FilterExpr{e.Operand})
+	case CompareExpr:
+		r.writeFilterCompare(q, loginArg, e)
+	}
+}
+
+func (r *Repo) writeFilterOperands(q *tasksQuery, loginArg string, sep string, operands []FilterExpr) {
+	q.WriteByte('(')
+	for i, o := range operands {
+		if i > 0 {
+			q.WriteString(sep)
+		}
+		r.writeFilterExpr(q, loginArg, o)
+	}
+	q.WriteByte(')')
+}
+
+func (r *Repo) writeFilterCompare(q *tasksQuery, loginArg string, e CompareExpr) {
+	column := filterColumns[e.Field]
+	switch e.Field {
+	case FilterByLabel:
+		q.WriteString(`EXISTS (SELECT 1 FROM task_label JOIN label ON label.id = task_label.label_id
+WHERE task_label.task_id = task.id AND label.name = `)
+		q.push(e.Value)
+		q.WriteByte(')')
+		return
+	case FilterByTitle:
+		q.WriteString("title ILIKE ")
+		q.push("%" + e.Value.(string) + "%")
+		return
+	}
+	if e.Value == nil {
+		q.WriteString(column + " IS NULL")
+		return
+	}
+	var value any
+	switch v := e.Value.(type) {
+	case time.Time:
+		value = pgtype.Date{
+			Time:  v,
+			Valid: true,
+		}
+	case projects.ProjectId:
+		value = r.projectIdToPg(&v)
+	case TaskId:
+		value = r.parentIdToPg(&v)
+	case Status:
+		value = v.String()
+	case Priority:
+		value = v.String()
+	default:
+		value = e.Value
+	}
+	q.WriteByte('(')
+	if filterFields[e.Field].optional {
+		q.WriteString(column + " IS NOT NULL AND ")
+	}
+	q.WriteString(column + filterOps[e.Op])
+	if e.Field == FilterByAssignee && value == "me" {
+		q.WriteString(loginArg)
+	} else {
+		q.push(value)
+	}
+	q.WriteByte(')')
+}
+
+// writeTasksCursor writes the keyset condition that selects tasks
+// following the cursor in the order of its sort
+func (r *Repo) writeTasksCursor(q *tasksQuery, c *Cursor) {
+	placeholders := make([]string, len(c.Values))
+	for i, v := range c.Values {
+		placeholders[i] = q.arg(r.sortValueToPg(c.Sort[i].Field, v))
+	}
+	id := q.arg(pgtype.UUID{
+		Bytes: c.Id,
+		Valid: true,
+	})
+	q.WriteString(" AND (")
+	for i := 0; i <= len(c.Sort); i++ {
+		if i > 0 {
+			q.WriteString(" OR ")
+		}
+		q.WriteByte('(')
+		for j := 0; j < i; j++ {
+			q.WriteString(sortColumns[c.Sort[j].Field])
+			q.WriteString(" = ")
+			q.WriteString(placeholders[j])
+			q.WriteString(" AND ")
+		}
+		if i == len(c.Sort) {
+			q.WriteString("id > ")
+			q.WriteString(id)
+		} else {
+			q.WriteString(sortColumns[c.Sort[i].Field])
+			if c.Sort[i].Desc {
+				q.WriteString(" < ")
+			} else {
+				q.WriteString(" > ")
+			}
+			q.WriteString(placeholders[i])
+		}
+		q.WriteByte(')')
+	}
+	q.WriteByte(')')
+}
+
+func (r *Repo) sortValueToPg(field SortField, value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		if field == SortByDueDate {
+			return pgtype.Date{
+				Time:  v,
+				Valid: true,
+			}
+		}
+		return pgtype.Timestamp{
+			Time:  v,
+			Valid: true,
+		}
+	case Priority:
+		return v.String()
+	}
+	return value
 }
 
-func (r *Repo) AllTasks(ctx context.Context) ([]Task, error) {
-	rows, err := r.queries.AllTasks(ctx)
+func (r *Repo) AllTasks(ctx context.Context, login string) ([]Task, error) {
+	rows, err := r.queries.AllTasks(ctx, login)
 	if err != nil {
 		return nil, err
 	}
 	tasks := make([]Task, len(rows))
 	for i, row := range rows {
-		if tasks[i], err = NewTask(
-			row.ID.Bytes,
-			row.Title,
-			r.descriptionFromPg(row.Description),
-			Status(row.Status),
-			Priority(row.Priority),
-			row.DueDate.Time,
-			row.CreatedAt.Time,
-			row.UpdatedAt.Time,
-		); err != nil {
+		if tasks[i], err = r.taskFromPg(row); err != nil {
 			return nil, err
 		}
 	}
+	if err := r.loadRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
+func (r *Repo) CountOpenSubtasks(ctx context.Context, id TaskId) (int64, error) {
+	return r.queries.CountOpenSubtasks(ctx, db.CountOpenSubtasksParams{
+		ParentID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Status: Done.String(),
+	})
+}
+
+// IsTaskDescendant reports whether the task is the ancestor itself or
+// one of its subtasks at any depth.
+func (r *Repo) IsTaskDescendant(ctx context.Context, id TaskId, ancestorId TaskId) (bool, error) {
+	return r.queries.IsTaskDescendant(ctx, db.IsTaskDescendantParams{
+		ID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		AncestorID: pgtype.UUID{
+			Bytes: ancestorId,
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) SaveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error {
+	return r.queries.InsertTaskDependency(ctx, db.InsertTaskDependencyParams{
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		BlockedByID: pgtype.UUID{
+			Bytes: blockerId,
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) RemoveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error {
+	rowsAffected, err := r.queries.DeleteTaskDependency(ctx, db.DeleteTaskDependencyParams{
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		BlockedByID: pgtype.UUID{
+			Bytes: blockerId,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrBlockerNotFound
+	}
+	return nil
+}
+
+// BlockersIds returns distinct ids of the tasks that block any of
+// the given tasks.
+func (r *Repo) BlockersIds(ctx context.Context, ids []TaskId) ([]TaskId, error) {
+	pgIds := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIds[i] = pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		}
+	}
+	rows, err := r.queries.TasksBlockersIds(ctx, pgIds)
+	if err != nil {
+		return nil, err
+	}
+	blockers := make([]TaskId, len(rows))
+	for i, row := range rows {
+		blockers[i] = row.Bytes
+	}
+	return blockers, nil
+}
+
+func (r *Repo) CountOpenBlockers(ctx context.Context, id TaskId) (int64, error) {
+	return r.queries.CountOpenBlockers(ctx, db.CountOpenBlockersParams{
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Status: Done.String(),
+	})
+}
+
 func (r *Repo) TasksCountByStatus(ctx context.Context) (map[Status]int64, error) {
 	rows, err := r.queries.CountTasksByStatus(ctx)
 	if err != nil {
@@ -276,22 +1141,579 @@ func (r *Repo) TasksCountByStatus(ctx context.Context) (map[Status]int64, error)
 }
 
 func (r *Repo) AverageCompletionTime(ctx context.Context) (float64, error) {
-	return r.queries.AverageTaskCompletionTime(ctx)
+	return r.queries.AverageTaskCompletionTime(ctx, Done.String())
 }
 
 func (r *Repo) CountCompletedAndOverdueTasks(ctx context.Context, date time.Time) (int64, int64, error) {
-	row, err := r.queries.CountCompletedAndOverdueTasks(ctx, pgtype.Timestamp{
-		Time:  date.UTC(),
-		Valid: true,
+	row, err := r.queries.CountCompletedAndOverdueTasks(ctx, db.CountCompletedAndOverdueTasksParams{
+		UpdatedAt: pgtype.Timestamp{
+			Time:  date.UTC(),
+			Valid: true,
+		},
+		DoneStatus: Done.String(),
 	})
 	return row.CompletedCount, row.OverdueCount, err
 }
 
-func (r *Repo) RemoveOverdueTasksWithDueDateBefore(ctx context.Context, date time.Time) error {
-	return r.queries.DeleteOverdueTasks(ctx, pgtype.Date{
-		Time:  date,
+// OverdueTasks returns the tasks matching the filter with their subtasks
+func (r *Repo) OverdueTasks(ctx context.Context, filter OverdueFilter) ([]Task, error) {
+	ids, err := r.queries.OverdueTasksIds(ctx, r.overdueFilterToPg(filter))
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := r.queries.TasksTree(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]Task, len(rows))
+	for i, row := range rows {
+		if tasks[i], err = r.taskFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.loadRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// RemoveOverdueTasks moves the tasks matching the filter with their
+// subtasks to the trash
+func (r *Repo) RemoveOverdueTasks(ctx context.Context, filter OverdueFilter) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	ids, err := queries.OverdueTasksIds(ctx, r.overdueFilterToPg(filter))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	removed, err := r.tasksTreeSnapshot(ctx, queries, ids)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := queries.DeleteTasks(ctx, db.DeleteTasksParams{
+		DeletedAt: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+		TaskIds: tasksIdsToPg(removed),
+	}); err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, SystemActor, TaskDeleted, removed, nil, now); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ArchiveOverdueTasks moves the tasks matching the filter with their
+// subtasks to the archive
+func (r *Repo) ArchiveOverdueTasks(ctx context.Context, filter OverdueFilter) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.rollback(ctx, tx)
+	queries := r.queries.WithTx(tx)
+	ids, err := queries.OverdueTasksIds(ctx, r.overdueFilterToPg(filter))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	archived, err := r.tasksTreeSnapshot(ctx, queries, ids)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := queries.ArchiveTasks(ctx, db.ArchiveTasksParams{
+		ArchivedAt: pgtype.Timestamp{
+			Time:  now.UTC(),
+			Valid: true,
+		},
+		TaskIds: tasksIdsToPg(archived),
+	}); err != nil {
+		return err
+	}
+	if err := r.saveTaskEvents(ctx, queries, SystemActor, TaskArchived, archived, nil, now); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *Repo) overdueFilterToPg(filter OverdueFilter) db.OverdueTasksIdsParams {
+	priorities := make([]string, len(filter.Priorities))
+	for i, p := range filter.Priorities {
+		priorities[i] = p.String()
+	}
+	return db.OverdueTasksIdsParams{
+		DoneStatus: Done.String(),
+		DueBefore: pgtype.Timestamp{
+			Time:  filter.DueBefore.UTC(),
+			Valid: true,
+		},
+		Priorities:       priorities,
+		SkipWithComments: filter.SkipWithComments,
+		SkipWithLabels:   filter.SkipWithLabels,
+	}
+}
+
+func (r *Repo) SaveTaskAttachment(ctx context.Context, id TaskId, attachment Attachment) error {
+	return r.queries.InsertTaskAttachment(ctx, db.InsertTaskAttachmentParams{
+		ID: pgtype.UUID{
+			Bytes: attachment.Id,
+			Valid: true,
+		},
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Uploader:    attachment.Uploader,
+		CreatedAt: pgtype.Timestamp{
+			Time:  attachment.CreatedAt.UTC(),
+			Valid: true,
+		},
+	})
+}
+
+func (r *Repo) TaskAttachmentById(ctx context.Context, id TaskId, attachmentId AttachmentId) (Attachment, error) {
+	row, err := r.queries.TaskAttachmentById(ctx, db.TaskAttachmentByIdParams{
+		ID: pgtype.UUID{
+			Bytes: attachmentId,
+			Valid: true,
+		},
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Attachment{}, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return Attachment{}, err
+	}
+	return r.attachmentFromPg(row)
+}
+
+func (r *Repo) RemoveTaskAttachment(ctx context.Context, id TaskId, attachmentId AttachmentId) error {
+	rowsAffected, err := r.queries.DeleteTaskAttachment(ctx, db.DeleteTaskAttachmentParams{
+		ID: pgtype.UUID{
+			Bytes: attachmentId,
+			Valid: true,
+		},
+		TaskID: pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func (r *Repo) TaskEvents(ctx context.Context, id TaskId) ([]TaskEvent, error) {
+	rows, err := r.queries.TaskEvents(ctx, pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]TaskEvent, len(rows))
+	for i, row := range rows {
+		if events[i], err = r.taskEventFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// setTaskLabels replaces labels of the task with the given ones.
+// Labels that are already attached to the task are kept, new labels
+// should belong to the user.
+func (r *Repo) setTaskLabels(
+	ctx context.Context,
+	queries *db.Queries,
+	login string,
+	id TaskId,
+	labelIds []labels.LabelId,
+) error {
+	taskId := pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+	ids := make([]pgtype.UUID, 0, len(labelIds))
+	unique := make(map[labels.LabelId]struct{}, len(labelIds))
+	for _, labelId := range labelIds {
+		if _, ok := unique[labelId]; ok {
+			continue
+		}
+		unique[labelId] = struct{}{}
+		ids = append(ids, pgtype.UUID{
+			Bytes: labelId,
+			Valid: true,
+		})
+	}
+	if err := queries.DeleteTaskLabelsExcept(ctx, db.DeleteTaskLabelsExceptParams{
+		TaskID:   taskId,
+		LabelIds: ids,
+	}); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := queries.InsertTaskLabels(ctx, db.InsertTaskLabelsParams{
+		TaskID:   taskId,
+		LabelIds: ids,
+		Owner:    login,
+	}); err != nil {
+		return err
+	}
+	count, err := queries.CountTaskLabels(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if count != int64(len(ids)) {
+		return labels.ErrLabelNotFound
+	}
+	return nil
+}
+
+func (r *Repo) loadRelations(ctx context.Context, tasks []Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]pgtype.UUID, len(tasks))
+	indexes := make(map[TaskId]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = pgtype.UUID{
+			Bytes: t.Id,
+			Valid: true,
+		}
+		indexes[t.Id] = i
+	}
+	if err := r.loadLabels(ctx, r.queries, tasks, ids, indexes); err != nil {
+		return err
+	}
+	if err := r.loadBlockers(ctx, tasks, ids, indexes); err != nil {
+		return err
+	}
+	return r.loadAttachments(ctx, tasks, ids, indexes)
+}
+
+func (r *Repo) loadLabels(
+	ctx context.Context,
+	queries *db.Queries,
+	tasks []Task,
+	ids []pgtype.UUID,
+	indexes map[TaskId]int,
+) error {
+	rows, err := queries.TaskLabels(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		label, err := labels.NewLabel(
+			row.ID.Bytes,
+			row.Name,
+			row.Owner,
+			row.CreatedAt.Time,
+			row.UpdatedAt.Time,
+		)
+		if err != nil {
+			return err
+		}
+		i := indexes[TaskId(row.TaskID.Bytes)]
+		tasks[i].Labels = append(tasks[i].Labels, label)
+	}
+	return nil
+}
+
+func (r *Repo) loadBlockers(ctx context.Context, tasks []Task, ids []pgtype.UUID, indexes map[TaskId]int) error {
+	rows, err := r.queries.TaskBlockers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		i := indexes[TaskId(row.TaskID.Bytes)]
+		tasks[i].BlockedBy = append(tasks[i].BlockedBy, Blocker{
+			Id:     row.ID.Bytes,
+			Status: Status(row.Status),
+		})
+	}
+	return nil
+}
+
+func (r *Repo) loadAttachments(ctx context.Context, tasks []Task, ids []pgtype.UUID, indexes map[TaskId]int) error {
+	rows, err := r.queries.TaskAttachments(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		attachment, err := r.attachmentFromPg(row)
+		if err != nil {
+			return err
+		}
+		i := indexes[TaskId(row.TaskID.Bytes)]
+		tasks[i].Attachments = append(tasks[i].Attachments, attachment)
+	}
+	return nil
+}
+
+// checkVersion locks the task row until the end of the transaction
+// and ensures that the task has the expected version
+func (r *Repo) checkVersion(ctx context.Context, queries *db.Queries, id TaskId, version int64) error {
+	current, err := queries.TaskVersion(ctx, pgtype.UUID{
+		Bytes: id,
 		Valid: true,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if current != version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// tasksSnapshot loads the current state of the tasks with their labels
+// to be recorded in the task history
+func (r *Repo) tasksSnapshot(ctx context.Context, queries *db.Queries, ids []TaskId) ([]Task, error) {
+	pgIds := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIds[i] = pgtype.UUID{
+			Bytes: id,
+			Valid: true,
+		}
+	}
+	rows, err := queries.TasksByIds(ctx, pgIds)
+	if err != nil {
+		return nil, err
+	}
+	return r.snapshotFromPg(ctx, queries, rows)
+}
+
+// tasksTreeSnapshot is the same as tasksSnapshot but includes
+// the tasks subtasks
+func (r *Repo) tasksTreeSnapshot(ctx context.Context, queries *db.Queries, ids []pgtype.UUID) ([]Task, error) {
+	rows, err := queries.TasksTree(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.snapshotFromPg(ctx, queries, rows)
+}
+
+func (r *Repo) snapshotFromPg(ctx context.Context, queries *db.Queries, rows []db.Task) ([]Task, error) {
+	tasks := make([]Task, len(rows))
+	ids := make([]pgtype.UUID, len(rows))
+	indexes := make(map[TaskId]int, len(rows))
+	for i, row := range rows {
+		task, err := r.taskFromPg(row)
+		if err != nil {
+			return nil, err
+		}
+		tasks[i] = task
+		ids[i] = row.ID
+		indexes[task.Id] = i
+	}
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+	if err := r.loadLabels(ctx, queries, tasks, ids, indexes); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// saveTaskEvents records the difference between the tasks states,
+// before or after is empty when the tasks are created or deleted
+func (r *Repo) saveTaskEvents(
+	ctx context.Context,
+	queries *db.Queries,
+	actor string,
+	kind TaskEventKind,
+	before []Task,
+	after []Task,
+	createdAt time.Time,
+) error {
+	previous := make(map[TaskId]*Task, len(before))
+	for i := range before {
+		previous[before[i].Id] = &before[i]
+	}
+	for i := range after {
+		if err := r.saveTaskEvent(ctx, queries, actor, kind, previous[after[i].Id], &after[i], nil, createdAt); err != nil {
+			return err
+		}
+		delete(previous, after[i].Id)
+	}
+	for i := range before {
+		if _, ok := previous[before[i].Id]; !ok {
+			continue
+		}
+		if err := r.saveTaskEvent(ctx, queries, actor, kind, &before[i], nil, nil, createdAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveTaskEvent records the task change, updates without changes
+// are skipped
+func (r *Repo) saveTaskEvent(
+	ctx context.Context,
+	queries *db.Queries,
+	actor string,
+	kind TaskEventKind,
+	before *Task,
+	after *Task,
+	reason *string,
+	createdAt time.Time,
+) error {
+	var taskId TaskId
+	if after != nil {
+		taskId = after.Id
+	} else {
+		taskId = before.Id
+	}
+	changes := DiffTasks(before, after)
+	if kind == TaskUpdated && len(changes) == 0 {
+		return nil
+	}
+	event := NewTaskEvent(NewTaskEventId(), taskId, actor, kind, changes, reason, createdAt)
+	data, err := json.Marshal(r.changesToPg(event.Changes))
+	if err != nil {
+		return err
+	}
+	return queries.InsertTaskEvent(ctx, db.InsertTaskEventParams{
+		ID: pgtype.UUID{
+			Bytes: event.Id,
+			Valid: true,
+		},
+		TaskID: pgtype.UUID{
+			Bytes: event.TaskId,
+			Valid: true,
+		},
+		Actor:   event.Actor,
+		Kind:    db.TaskEventKind(event.Kind),
+		Changes: data,
+		CreatedAt: pgtype.Timestamp{
+			Time:  event.CreatedAt.UTC(),
+			Valid: true,
+		},
+		Reason: r.reasonToPg(event.Reason),
+	})
+}
+
+func (r *Repo) rollback(ctx context.Context, tx pgx.Tx) {
+	if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		r.log.Error(ctx, "failed to rollback transaction", sl.Err(err))
+	}
+}
+
+func (r *Repo) taskFromPg(row db.Task) (Task, error) {
+	recurrence, err := r.recurrenceFromPg(row.Recurrence)
+	if err != nil {
+		return Task{}, err
+	}
+	task, err := NewTask(
+		row.ID.Bytes,
+		row.Owner,
+		row.Title,
+		r.descriptionFromPg(row.Description),
+		Status(row.Status),
+		Priority(row.Priority),
+		row.DueDate.Time,
+		r.projectIdFromPg(row.ProjectID),
+		r.parentIdFromPg(row.ParentID),
+		recurrence,
+		r.assigneeFromPg(row.Assignee),
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+	if err != nil {
+		return Task{}, err
+	}
+	task.DueTime = r.dueTimeFromPg(row.DueTime)
+	task.TimeZone = row.TimeZone
+	task.Version = row.Version
+	task.Position = row.Position
+	if row.DeletedAt.Valid {
+		task.DeletedAt = &row.DeletedAt.Time
+	}
+	if row.ArchivedAt.Valid {
+		task.ArchivedAt = &row.ArchivedAt.Time
+	}
+	return task, nil
+}
+
+func (r *Repo) attachmentFromPg(row db.TaskAttachment) (Attachment, error) {
+	return NewAttachment(
+		row.ID.Bytes,
+		row.Name,
+		row.ContentType,
+		row.Size,
+		row.Uploader,
+		row.CreatedAt.Time,
+	)
+}
+
+// fieldChange is the JSON representation of the task field change
+// in the task history
+type fieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
+
+func (r *Repo) changesToPg(changes []FieldChange) []fieldChange {
+	items := make([]fieldChange, len(changes))
+	for i, c := range changes {
+		items[i] = fieldChange(c)
+	}
+	return items
+}
+
+func (r *Repo) taskEventFromPg(row db.TaskEvent) (TaskEvent, error) {
+	var items []fieldChange
+	if err := json.Unmarshal(row.Changes, &items); err != nil {
+		return TaskEvent{}, err
+	}
+	changes := make([]FieldChange, len(items))
+	for i, c := range items {
+		changes[i] = FieldChange(c)
+	}
+	return NewTaskEvent(
+		row.ID.Bytes,
+		row.TaskID.Bytes,
+		row.Actor,
+		TaskEventKind(row.Kind),
+		changes,
+		r.reasonFromPg(row.Reason),
+		row.CreatedAt.Time,
+	), nil
 }
 
 func (r *Repo) descriptionToPg(d *string) pgtype.Text {
@@ -309,3 +1731,152 @@ func (r *Repo) descriptionFromPg(t pgtype.Text) *string {
 	}
 	return nil
 }
+
+func (r *Repo) dueTimeToPg(dueTime *DueTime) pgtype.Time {
+	var t pgtype.Time
+	if dueTime != nil {
+		t.Microseconds = dueTime.Duration().Microseconds()
+		t.Valid = true
+	}
+	return t
+}
+
+func (r *Repo) dueTimeFromPg(t pgtype.Time) *DueTime {
+	if !t.Valid {
+		return nil
+	}
+	dueTime := DueTime(time.Duration(t.Microseconds) * time.Microsecond)
+	return &dueTime
+}
+
+// timeZoneToPg maps the absent time zone to NULL, so the default
+// time zone of the owner is used
+func (r *Repo) timeZoneToPg(timeZone string) pgtype.Text {
+	return pgtype.Text{
+		String: timeZone,
+		Valid:  timeZone != "",
+	}
+}
+
+func (r *Repo) projectIdToPg(id *projects.ProjectId) pgtype.UUID {
+	var u pgtype.UUID
+	if id != nil {
+		u.Bytes = *id
+		u.Valid = true
+	}
+	return u
+}
+
+func (r *Repo) projectIdFromPg(u pgtype.UUID) *projects.ProjectId {
+	if u.Valid {
+		id := projects.ProjectId(u.Bytes)
+		return &id
+	}
+	return nil
+}
+
+func (r *Repo) parentIdToPg(id *TaskId) pgtype.UUID {
+	var u pgtype.UUID
+	if id != nil {
+		u.Bytes = *id
+		u.Valid = true
+	}
+	return u
+}
+
+func (r *Repo) parentIdFromPg(u pgtype.UUID) *TaskId {
+	if u.Valid {
+		id := TaskId(u.Bytes)
+		return &id
+	}
+	return nil
+}
+
+func (r *Repo) recurrenceToPg(rec *Recurrence) pgtype.Text {
+	var t pgtype.Text
+	if rec != nil {
+		t.String = rec.String()
+		t.Valid = true
+	}
+	return t
+}
+
+func (r *Repo) recurrenceFromPg(t pgtype.Text) (*Recurrence, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	rec, err := ParseRecurrence(t.String)
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *Repo) assigneeToPg(login *string) pgtype.Text {
+	var t pgtype.Text
+	if login != nil {
+		t.String = *login
+		t.Valid = true
+	}
+	return t
+}
+
+func (r *Repo) assigneeFromPg(t pgtype.Text) *string {
+	if t.Valid {
+		return &t.String
+	}
+	return nil
+}
+
+func (r *Repo) reasonToPg(reason *string) pgtype.Text {
+	var t pgtype.Text
+	if reason != nil {
+		t.String = *reason
+		t.Valid = true
+	}
+	return t
+}
+
+func (r *Repo) reasonFromPg(t pgtype.Text) *string {
+	if t.Valid {
+		return &t.String
+	}
+	return nil
+}
+
+func isParentViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "task_parent_id_fkey"
+}
+
+func isAssigneeViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "task_assignee_fkey"
+}
+
+func tasksIdsToPg(tasks []Task) []pgtype.UUID {
+	ids := make([]pgtype.UUID, len(tasks))
+	for i, t := range tasks {
+		ids[i] = pgtype.UUID{
+			Bytes: t.Id,
+			Valid: true,
+		}
+	}
+	return ids
+}
+
+func attachmentsIdsFromPg(ids []pgtype.UUID) []AttachmentId {
+	attachments := make([]AttachmentId, len(ids))
+	for i, id := range ids {
+		attachments[i] = id.Bytes
+	}
+	return attachments
+}
+
+func uniqueLabelNames(names []string) map[string]struct{} {
+	unique := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		unique[n] = struct{}{}
+	}
+	return unique
+}
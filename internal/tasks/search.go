@@ -0,0 +1,15 @@
+package tasks
+
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchMatch describes how the task matches the full-text search query,
+// matched words of the snippets are wrapped with HighlightStart and
+// HighlightStop, the rest of the text is not escaped
+type SearchMatch struct {
+	Rank        float32
+	Title       string
+	Description *string
+}
@@ -4,71 +4,316 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"time"
 
+	"github.com/x0k/skillrock-tasks-service/internal/auth"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/tz"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 	"github.com/x0k/skillrock-tasks-service/internal/shared"
 )
 
 type TasksRepo interface {
-	SaveTask(ctx context.Context, task Task) error
-	FindTasks(ctx context.Context, filter TasksFilter) ([]Task, error)
-	UpdateTaskById(ctx context.Context, id TaskId, params TaskParams) error
-	RemoveTaskById(ctx context.Context, id TaskId) error
-	SaveTasks(ctx context.Context, tasks []Task) error
-	AllTasks(ctx context.Context) ([]Task, error)
-	RemoveOverdueTasksWithDueDateBefore(ctx context.Context, date time.Time) error
+	SaveTask(ctx context.Context, owner string, task Task, labelIds []labels.LabelId) error
+	TaskById(ctx context.Context, login string, id TaskId) (Task, error)
+	FindTasks(ctx context.Context, login string, filter TasksFilter, page PageParams) ([]Task, error)
+	CountTasks(ctx context.Context, login string, filter TasksFilter) (int64, error)
+	UpdateTaskById(ctx context.Context, login string, id TaskId, version int64, params TaskParams, position string, next *Occurrence) error
+	ReopenTaskById(ctx context.Context, login string, id TaskId, version int64, position string, reason *string) error
+	MoveTaskById(ctx context.Context, login string, id TaskId, version int64, status Status, position string, next *Occurrence) error
+	PrevTaskPosition(ctx context.Context, login string, status Status, position *string, exclude TaskId) (string, error)
+	NextTaskPosition(ctx context.Context, login string, status Status, position string, exclude TaskId) (string, error)
+	RemoveTaskById(ctx context.Context, login string, id TaskId, version int64) error
+	DeletedTasks(ctx context.Context, login string) ([]Task, error)
+	DeletedTaskById(ctx context.Context, login string, id TaskId) (Task, error)
+	RestoreTaskById(ctx context.Context, login string, id TaskId) error
+	PurgeTasksDeletedBefore(ctx context.Context, date time.Time) ([]AttachmentId, error)
+	SaveTasks(ctx context.Context, owner string, tasks []Task) error
+	AllTasks(ctx context.Context, login string) ([]Task, error)
+	OverdueTasks(ctx context.Context, filter OverdueFilter) ([]Task, error)
+	RemoveOverdueTasks(ctx context.Context, filter OverdueFilter) error
+	ArchiveOverdueTasks(ctx context.Context, filter OverdueFilter) error
+	CountOpenSubtasks(ctx context.Context, id TaskId) (int64, error)
+	IsTaskDescendant(ctx context.Context, id TaskId, ancestorId TaskId) (bool, error)
+	SaveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error
+	RemoveTaskBlocker(ctx context.Context, id TaskId, blockerId TaskId) error
+	BlockersIds(ctx context.Context, ids []TaskId) ([]TaskId, error)
+	CountOpenBlockers(ctx context.Context, id TaskId) (int64, error)
+	SaveTaskAttachment(ctx context.Context, id TaskId, attachment Attachment) error
+	TaskAttachmentById(ctx context.Context, id TaskId, attachmentId AttachmentId) (Attachment, error)
+	RemoveTaskAttachment(ctx context.Context, id TaskId, attachmentId AttachmentId) error
+	TaskEvents(ctx context.Context, id TaskId) ([]TaskEvent, error)
+}
+
+type ProjectsRepo interface {
+	ProjectRole(ctx context.Context, login string, id projects.ProjectId) (projects.Role, error)
+}
+
+type UsersRepo interface {
+	UserByLogin(ctx context.Context, login string) (*auth.User, error)
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Remove(ctx context.Context, key string) error
 }
 
 type Service struct {
-	log           *logger.Logger
-	tasksRepo     TasksRepo
-	pruneDuration time.Duration
+	log          *logger.Logger
+	tasksRepo    TasksRepo
+	projectsRepo ProjectsRepo
+	usersRepo    UsersRepo
+	blobStore    BlobStore
+	workflow     *Workflow
+	prunePolicy  PrunePolicy
+	// trashRetention is how long deleted tasks are kept in the trash
+	trashRetention time.Duration
 }
 
 func NewService(
 	log *logger.Logger,
 	repo TasksRepo,
+	projectsRepo ProjectsRepo,
+	usersRepo UsersRepo,
+	blobStore BlobStore,
+	workflow *Workflow,
+	prunePolicy PrunePolicy,
+	trashRetention time.Duration,
 ) *Service {
-	return &Service{log, repo, 7 * 24 * time.Hour}
+	return &Service{log, repo, projectsRepo, usersRepo, blobStore, workflow, prunePolicy, trashRetention}
 }
 
-func (s *Service) CreateTask(ctx context.Context, params TaskParams) *shared.ServiceError {
+func (s *Service) CreateTask(ctx context.Context, owner string, params TaskParams) *shared.ServiceError {
 	now := time.Now()
 	task, err := NewTask(
 		NewTaskId(),
+		owner,
 		params.Title,
 		params.Description,
 		params.Status,
 		params.Priority,
 		params.DueDate,
+		params.ProjectId,
+		params.ParentId,
+		params.Recurrence,
+		params.Assignee,
 		now,
 		now,
 	)
 	if err != nil {
 		return shared.NewServiceError(err, "failed to create task")
 	}
-	if err := s.tasksRepo.SaveTask(ctx, task); err != nil {
+	task.DueTime = params.DueTime
+	if params.TimeZone != nil {
+		task.TimeZone = *params.TimeZone
+	}
+	if err := s.checkStatus(task.Status); err != nil {
+		return err
+	}
+	if err := s.checkTimeZone(task.TimeZone); err != nil {
+		return err
+	}
+	if err := s.checkAssignee(ctx, task.Assignee); err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, owner, task.ProjectId); err != nil {
+		return err
+	}
+	if err := s.checkParentTask(ctx, owner, task.ParentId); err != nil {
+		return err
+	}
+	if task.Position, err = s.lastPosition(ctx, owner, task.Status, task.Id); err != nil {
+		return shared.NewUnexpectedError(err, "failed to find position of task")
+	}
+	err = s.tasksRepo.SaveTask(ctx, owner, task, params.LabelIds)
+	if errors.Is(err, labels.ErrLabelNotFound) {
+		return shared.NewServiceError(err, "some of the task labels are not found")
+	}
+	if errors.Is(err, ErrParentTaskNotFound) {
+		return shared.NewServiceError(err, "parent task not found")
+	}
+	if errors.Is(err, ErrAssigneeNotFound) {
+		return shared.NewServiceError(err, "assignee not found")
+	}
+	if err != nil {
 		return shared.NewUnexpectedError(err, "failed to save task")
 	}
 	return nil
 }
 
-func (s *Service) FindTasks(ctx context.Context, filter TasksFilter) ([]Task, *shared.ServiceError) {
-	tasks, err := s.tasksRepo.FindTasks(ctx, filter)
+// CheckTasksQuery checks that the filter and the sort can be used to find tasks
+func (s *Service) CheckTasksQuery(filter TasksFilter, sort Sort) *shared.ServiceError {
+	if filter.Status != nil {
+		if sErr := s.checkStatus(*filter.Status); sErr != nil {
+			return sErr
+		}
+	}
+	for _, status := range filterStatuses(filter.Expr) {
+		if sErr := s.checkStatus(status); sErr != nil {
+			return sErr
+		}
+	}
+	if filter.Query == nil && sort.HasField(SortByRank) {
+		return shared.NewServiceError(ErrInvalidSort, "tasks can be sorted by rank only with the search query")
+	}
+	return nil
+}
+
+// FindTasks returns the page of tasks matching the filter, the page limit
+// is bounded by MaxPageLimit and tasks are ordered by DefaultSort or
+// by DefaultSearchSort for the full-text search if the page sort is empty
+func (s *Service) FindTasks(
+	ctx context.Context,
+	login string,
+	filter TasksFilter,
+	page PageParams,
+) (TasksPage, *shared.ServiceError) {
+	if sErr := s.CheckTasksQuery(filter, page.Sort); sErr != nil {
+		return TasksPage{}, sErr
+	}
+	if len(page.Sort) == 0 {
+		if filter.Query != nil {
+			page.Sort = DefaultSearchSort
+		} else {
+			page.Sort = DefaultSort
+		}
+	}
+	if page.Cursor != nil && page.Cursor.Sort.String() != page.Sort.String() {
+		return TasksPage{}, shared.NewServiceError(ErrInvalidCursor, "the cursor was created for another sort")
+	}
+	limit := page.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	limit = min(limit, MaxPageLimit)
+	// One more task is loaded to find out whether the next page exists
+	page.Limit = limit + 1
+	tasks, err := s.tasksRepo.FindTasks(ctx, login, filter, page)
 	if err != nil {
-		return tasks, shared.NewUnexpectedError(err, "failed to filter tasks")
+		return TasksPage{}, shared.NewUnexpectedError(err, "failed to filter tasks")
 	}
-	return tasks, nil
+	result := TasksPage{Tasks: tasks}
+	if len(tasks) > limit {
+		result.Tasks = tasks[:limit]
+		cursor := NewCursor(page.Sort, result.Tasks[limit-1])
+		result.NextCursor = &cursor
+	}
+	if page.WithTotal {
+		total, err := s.tasksRepo.CountTasks(ctx, login, filter)
+		if err != nil {
+			return TasksPage{}, shared.NewUnexpectedError(err, "failed to count tasks")
+		}
+		result.Total = &total
+	}
+	return result, nil
+}
+
+// ArchivedTasks returns the page of archived tasks matching the filter,
+// the page is built as in FindTasks
+func (s *Service) ArchivedTasks(
+	ctx context.Context,
+	login string,
+	filter TasksFilter,
+	page PageParams,
+) (TasksPage, *shared.ServiceError) {
+	filter.Archived = true
+	return s.FindTasks(ctx, login, filter, page)
+}
+
+func (s *Service) TaskById(ctx context.Context, login string, id TaskId) (Task, *shared.ServiceError) {
+	return s.taskById(ctx, login, id)
 }
 
-func (s *Service) UpdateTaskById(ctx context.Context, id TaskId, params TaskParams) *shared.ServiceError {
-	err := s.tasksRepo.UpdateTaskById(ctx, id, params)
+// UpdateTaskById updates the task, the optional version protects
+// the task from overwriting of concurrent changes. Completed tasks
+// can't be updated until they are reopened.
+func (s *Service) UpdateTaskById(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	version *int64,
+	params TaskParams,
+) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := checkVersion(task, version); sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	if task.Status == Done {
+		return shared.NewServiceError(ErrTaskIsAlreadyDone, "the task to be updated has already been completed")
+	}
+	if sErr := s.checkTransition(task.Status, params.Status); sErr != nil {
+		return sErr
+	}
+	if params.TimeZone != nil {
+		if sErr := s.checkTimeZone(*params.TimeZone); sErr != nil {
+			return sErr
+		}
+	}
+	if !sameAssignee(task.Assignee, params.Assignee) {
+		if sErr := s.checkAssignee(ctx, params.Assignee); sErr != nil {
+			return sErr
+		}
+	}
+	if !sameProject(task.ProjectId, params.ProjectId) {
+		if sErr := s.checkProjectAccess(ctx, login, params.ProjectId); sErr != nil {
+			return sErr
+		}
+	}
+	if !sameTask(task.ParentId, params.ParentId) {
+		if sErr := s.checkParentChange(ctx, login, id, params.ParentId); sErr != nil {
+			return sErr
+		}
+	}
+	if params.Status == Done && task.Status != Done {
+		if sErr := s.checkSubtasksDone(ctx, id); sErr != nil {
+			return sErr
+		}
+	}
+	if params.Status == InProgress && task.Status != InProgress {
+		if sErr := s.checkBlockersDone(ctx, id); sErr != nil {
+			return sErr
+		}
+	}
+	// The task with the changed status goes to the end of the new column
+	var position string
+	if params.Status != task.Status {
+		var err error
+		if position, err = s.lastPosition(ctx, login, params.Status, id); err != nil {
+			return shared.NewUnexpectedError(err, "failed to find position of task")
+		}
+	}
+	var next *Occurrence
+	if params.Status == Done && task.Status != Done && params.Recurrence != nil {
+		if next, sErr = s.nextOccurrence(ctx, task, params); sErr != nil {
+			return sErr
+		}
+	}
+	err := s.tasksRepo.UpdateTaskById(ctx, login, id, task.Version, params, position, next)
 	if errors.Is(err, ErrTaskNotFound) {
 		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", id.String()))
 	}
-	if errors.Is(err, ErrTaskIsAlreadyDone) {
-		return shared.NewServiceError(err, "the task to be updated has already been completed")
+	if errors.Is(err, ErrVersionMismatch) {
+		return shared.NewServiceError(err, "the task has been modified concurrently")
+	}
+	if errors.Is(err, labels.ErrLabelNotFound) {
+		return shared.NewServiceError(err, "some of the task labels are not found")
+	}
+	if errors.Is(err, ErrParentTaskNotFound) {
+		return shared.NewServiceError(err, "parent task not found")
+	}
+	if errors.Is(err, ErrAssigneeNotFound) {
+		return shared.NewServiceError(err, "assignee not found")
 	}
 	if err != nil {
 		return shared.NewUnexpectedError(err, "failed to update task")
@@ -76,37 +321,825 @@ func (s *Service) UpdateTaskById(ctx context.Context, id TaskId, params TaskPara
 	return nil
 }
 
-func (s *Service) RemoveTaskById(ctx context.Context, id TaskId) *shared.ServiceError {
-	err := s.tasksRepo.RemoveTaskById(ctx, id)
+// ReopenTaskById moves the completed task back to in progress,
+// subtasks of completed tasks can't be reopened before their parent
+func (s *Service) ReopenTaskById(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	version *int64,
+	reason *string,
+) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := checkVersion(task, version); sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	if task.Status != Done {
+		return shared.NewServiceError(ErrTaskIsNotDone, "only completed tasks can be reopened")
+	}
+	if task.ParentId != nil {
+		parent, sErr := s.taskById(ctx, login, *task.ParentId)
+		if sErr != nil {
+			return sErr
+		}
+		if parent.Status == Done {
+			return shared.NewServiceError(ErrParentTaskIsDone, "the parent task should be reopened first")
+		}
+	}
+	if sErr := s.checkBlockersDone(ctx, id); sErr != nil {
+		return sErr
+	}
+	position, err := s.lastPosition(ctx, login, InProgress, id)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to find position of task")
+	}
+	err = s.tasksRepo.ReopenTaskById(ctx, login, id, task.Version, position, reason)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", id.String()))
+	}
+	if errors.Is(err, ErrVersionMismatch) {
+		return shared.NewServiceError(err, "the task has been modified concurrently")
+	}
+	if errors.Is(err, ErrTaskIsNotDone) {
+		return shared.NewServiceError(err, "only completed tasks can be reopened")
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to reopen task")
+	}
+	return nil
+}
+
+// MoveTaskById sets the status of the task and places it between
+// the neighbours in the column of the status, the optional version
+// protects the task from overwriting of concurrent changes
+func (s *Service) MoveTaskById(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	version *int64,
+	params MoveParams,
+) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := checkVersion(task, version); sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTransition(task.Status, params.Status); sErr != nil {
+		return sErr
+	}
+	if params.Status == Done && task.Status != Done {
+		if sErr := s.checkSubtasksDone(ctx, id); sErr != nil {
+			return sErr
+		}
+	}
+	if params.Status == InProgress && task.Status != InProgress {
+		if sErr := s.checkBlockersDone(ctx, id); sErr != nil {
+			return sErr
+		}
+	}
+	position, sErr := s.movePosition(ctx, login, id, params)
+	if sErr != nil {
+		return sErr
+	}
+	var next *Occurrence
+	if params.Status == Done && task.Status != Done && task.Recurrence != nil {
+		if next, sErr = s.nextOccurrence(ctx, task, TaskParams{
+			Title:       task.Title,
+			Description: task.Description,
+			Status:      params.Status,
+			Priority:    task.Priority,
+			DueDate:     task.DueDate,
+			DueTime:     task.DueTime,
+			TimeZone:    &task.TimeZone,
+			ProjectId:   task.ProjectId,
+			ParentId:    task.ParentId,
+			Recurrence:  task.Recurrence,
+			Assignee:    task.Assignee,
+		}); sErr != nil {
+			return sErr
+		}
+	}
+	err := s.tasksRepo.MoveTaskById(ctx, login, id, task.Version, params.Status, position, next)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", id.String()))
+	}
+	if errors.Is(err, ErrVersionMismatch) {
+		return shared.NewServiceError(err, "the task has been modified concurrently")
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to move task")
+	}
+	return nil
+}
+
+// RemoveTaskById moves the task to the trash, the optional version
+// protects the task from removal after concurrent changes
+func (s *Service) RemoveTaskById(ctx context.Context, login string, id TaskId, version *int64) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := checkVersion(task, version); sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	err := s.tasksRepo.RemoveTaskById(ctx, login, id, task.Version)
 	if errors.Is(err, ErrTaskNotFound) {
 		return shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", id.String()))
 	}
+	if errors.Is(err, ErrVersionMismatch) {
+		return shared.NewServiceError(err, "the task has been modified concurrently")
+	}
 	if err != nil {
 		return shared.NewUnexpectedError(err, "failed to remove task")
 	}
 	return nil
 }
 
-func (s *Service) ExportTasks(ctx context.Context) ([]Task, *shared.ServiceError) {
-	if tasks, err := s.tasksRepo.AllTasks(ctx); err != nil {
+// Trash returns deleted tasks that are not purged yet
+func (s *Service) Trash(ctx context.Context, login string) ([]Task, *shared.ServiceError) {
+	tasks, err := s.tasksRepo.DeletedTasks(ctx, login)
+	if err != nil {
+		return nil, shared.NewUnexpectedError(err, "failed to load deleted tasks")
+	}
+	return tasks, nil
+}
+
+// RestoreTaskById moves the task out of the trash with the subtasks
+// that were deleted along with it
+func (s *Service) RestoreTaskById(ctx context.Context, login string, id TaskId) *shared.ServiceError {
+	task, err := s.tasksRepo.DeletedTaskById(ctx, login, id)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("deleted task with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load deleted task")
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	err = s.tasksRepo.RestoreTaskById(ctx, login, id)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("deleted task with id %q not found", id.String()))
+	}
+	if errors.Is(err, ErrParentTaskIsDeleted) {
+		return shared.NewServiceError(err, "the parent task should be restored first")
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to restore task")
+	}
+	return nil
+}
+
+func (s *Service) Subtasks(ctx context.Context, login string, id TaskId) ([]Task, *shared.ServiceError) {
+	if _, sErr := s.taskById(ctx, login, id); sErr != nil {
+		return nil, sErr
+	}
+	tasks, err := s.tasksRepo.FindTasks(ctx, login, TasksFilter{ParentId: &id}, PageParams{Sort: DefaultSort})
+	if err != nil {
+		return tasks, shared.NewUnexpectedError(err, "failed to load subtasks")
+	}
+	return tasks, nil
+}
+
+// TaskHistory returns changes of the task in chronological order
+func (s *Service) TaskHistory(ctx context.Context, login string, id TaskId) ([]TaskEvent, *shared.ServiceError) {
+	if _, sErr := s.taskById(ctx, login, id); sErr != nil {
+		return nil, sErr
+	}
+	events, err := s.tasksRepo.TaskEvents(ctx, id)
+	if err != nil {
+		return events, shared.NewUnexpectedError(err, "failed to load task history")
+	}
+	return events, nil
+}
+
+func (s *Service) AddTaskBlocker(ctx context.Context, login string, id TaskId, blockerId TaskId) *shared.ServiceError {
+	if id == blockerId {
+		return shared.NewServiceError(ErrInvalidBlocker, "task can't block itself")
+	}
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	_, err := s.tasksRepo.TaskById(ctx, login, blockerId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(
+			ErrBlockerNotFound,
+			fmt.Sprintf("blocker task with id %q not found", blockerId.String()),
+		)
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load blocker task")
+	}
+	if sErr := s.checkDependencyCycle(ctx, id, blockerId); sErr != nil {
+		return sErr
+	}
+	if err := s.tasksRepo.SaveTaskBlocker(ctx, id, blockerId); err != nil {
+		return shared.NewUnexpectedError(err, "failed to save task blocker")
+	}
+	return nil
+}
+
+func (s *Service) RemoveTaskBlocker(ctx context.Context, login string, id TaskId, blockerId TaskId) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	err := s.tasksRepo.RemoveTaskBlocker(ctx, id, blockerId)
+	if errors.Is(err, ErrBlockerNotFound) {
+		return shared.NewServiceError(
+			err,
+			fmt.Sprintf("task %q is not blocked by %q", id.String(), blockerId.String()),
+		)
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove task blocker")
+	}
+	return nil
+}
+
+func (s *Service) AddTaskAttachment(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	name string,
+	contentType string,
+	size int64,
+	content io.Reader,
+) (Attachment, *shared.ServiceError) {
+	attachment, err := NewAttachment(NewAttachmentId(), name, contentType, size, login, time.Now())
+	if err != nil {
+		return Attachment{}, shared.NewServiceError(err, "failed to create attachment")
+	}
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return Attachment{}, sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return Attachment{}, sErr
+	}
+	if err := s.blobStore.Put(ctx, attachment.Id.String(), content); err != nil {
+		return Attachment{}, shared.NewUnexpectedError(err, "failed to store attachment content")
+	}
+	if err := s.tasksRepo.SaveTaskAttachment(ctx, id, attachment); err != nil {
+		s.removeBlobs(ctx, []AttachmentId{attachment.Id})
+		return Attachment{}, shared.NewUnexpectedError(err, "failed to save attachment")
+	}
+	return attachment, nil
+}
+
+// TaskAttachment returns the attachment with its content,
+// the caller is responsible for closing the content
+func (s *Service) TaskAttachment(
+	ctx context.Context,
+	login string,
+	id TaskId,
+	attachmentId AttachmentId,
+) (Attachment, io.ReadCloser, *shared.ServiceError) {
+	if _, sErr := s.taskById(ctx, login, id); sErr != nil {
+		return Attachment{}, nil, sErr
+	}
+	attachment, err := s.tasksRepo.TaskAttachmentById(ctx, id, attachmentId)
+	if errors.Is(err, ErrAttachmentNotFound) {
+		return Attachment{}, nil, shared.NewServiceError(err, fmt.Sprintf("attachment with id %q not found", attachmentId.String()))
+	}
+	if err != nil {
+		return Attachment{}, nil, shared.NewUnexpectedError(err, "failed to load attachment")
+	}
+	content, err := s.blobStore.Get(ctx, attachment.Id.String())
+	if err != nil {
+		return Attachment{}, nil, shared.NewUnexpectedError(err, "failed to load attachment content")
+	}
+	return attachment, content, nil
+}
+
+func (s *Service) RemoveTaskAttachment(ctx context.Context, login string, id TaskId, attachmentId AttachmentId) *shared.ServiceError {
+	task, sErr := s.taskById(ctx, login, id)
+	if sErr != nil {
+		return sErr
+	}
+	if sErr := s.checkTaskAccess(ctx, login, task); sErr != nil {
+		return sErr
+	}
+	err := s.tasksRepo.RemoveTaskAttachment(ctx, id, attachmentId)
+	if errors.Is(err, ErrAttachmentNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("attachment with id %q not found", attachmentId.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove attachment")
+	}
+	s.removeBlobs(ctx, []AttachmentId{attachmentId})
+	return nil
+}
+
+func (s *Service) ExportTasks(ctx context.Context, login string) ([]Task, *shared.ServiceError) {
+	if tasks, err := s.tasksRepo.AllTasks(ctx, login); err != nil {
 		return tasks, shared.NewUnexpectedError(err, "failed to load tasks")
 	} else {
 		return tasks, nil
 	}
 }
 
-func (s *Service) ImportTasks(ctx context.Context, tasks []Task) *shared.ServiceError {
-	if err := s.tasksRepo.SaveTasks(ctx, tasks); err != nil {
+func (s *Service) ImportTasks(ctx context.Context, owner string, tasks []Task) *shared.ServiceError {
+	imported := make(map[TaskId]struct{}, len(tasks))
+	for _, t := range tasks {
+		if err := s.checkStatus(t.Status); err != nil {
+			return err
+		}
+		if err := s.checkTimeZone(t.TimeZone); err != nil {
+			return err
+		}
+		imported[t.Id] = struct{}{}
+	}
+	for _, t := range tasks {
+		if t.ParentId == nil {
+			continue
+		}
+		if _, ok := imported[*t.ParentId]; ok {
+			continue
+		}
+		if err := s.checkParentTask(ctx, owner, t.ParentId); err != nil {
+			return err
+		}
+	}
+	assignees := make(map[string]struct{})
+	for _, t := range tasks {
+		if t.Assignee == nil {
+			continue
+		}
+		if _, ok := assignees[*t.Assignee]; ok {
+			continue
+		}
+		if err := s.checkAssignee(ctx, t.Assignee); err != nil {
+			return err
+		}
+		assignees[*t.Assignee] = struct{}{}
+	}
+	checked := make(map[projects.ProjectId]struct{})
+	for _, t := range tasks {
+		if t.ProjectId == nil {
+			continue
+		}
+		if _, ok := checked[*t.ProjectId]; ok {
+			continue
+		}
+		if err := s.checkProjectAccess(ctx, owner, t.ProjectId); err != nil {
+			return err
+		}
+		checked[*t.ProjectId] = struct{}{}
+	}
+	// Imported tasks are placed at the ends of their columns in the import order
+	positions := make(map[Status]string)
+	for i := range tasks {
+		status := tasks[i].Status
+		lower, ok := positions[status]
+		if !ok {
+			var err error
+			if lower, err = s.tasksRepo.PrevTaskPosition(ctx, owner, status, nil, tasks[i].Id); err != nil {
+				return shared.NewUnexpectedError(err, "failed to find positions of tasks")
+			}
+		}
+		position, err := PositionBetween(lower, "")
+		if err != nil {
+			return shared.NewUnexpectedError(err, "failed to find positions of tasks")
+		}
+		tasks[i].Position = position
+		positions[status] = position
+	}
+	err := s.tasksRepo.SaveTasks(ctx, owner, tasks)
+	if errors.Is(err, ErrParentTaskNotFound) {
+		return shared.NewServiceError(err, "parent task not found")
+	}
+	if errors.Is(err, ErrAssigneeNotFound) {
+		return shared.NewServiceError(err, "assignee not found")
+	}
+	if err != nil {
 		return shared.NewUnexpectedError(err, "failed to save tasks")
 	}
 	return nil
 }
 
+// PruneOverdueTasks moves long overdue tasks selected by the prune
+// policy to the trash or to the archive depending on the policy mode,
+// in the dry run mode the tasks are only logged
 func (s *Service) PruneOverdueTasks(ctx context.Context) *shared.ServiceError {
-	date := time.Now().Add(-s.pruneDuration)
-	err := s.tasksRepo.RemoveOverdueTasksWithDueDateBefore(ctx, date)
-	if err != nil {
+	filter := s.prunePolicy.Filter(time.Now())
+	if s.prunePolicy.DryRun {
+		tasks, err := s.tasksRepo.OverdueTasks(ctx, filter)
+		if err != nil {
+			return shared.NewUnexpectedError(err, "failed to load overdue tasks")
+		}
+		ids := make([]string, len(tasks))
+		for i, t := range tasks {
+			ids[i] = t.Id.String()
+		}
+		s.log.Info(
+			ctx, "dry run: overdue tasks would be pruned",
+			slog.String("mode", s.prunePolicy.Mode.String()),
+			slog.Int("count", len(tasks)),
+			slog.Any("task_ids", ids),
+		)
+		return nil
+	}
+	if s.prunePolicy.Mode == PruneToArchive {
+		if err := s.tasksRepo.ArchiveOverdueTasks(ctx, filter); err != nil {
+			return shared.NewUnexpectedError(err, "failed to archive overdue tasks")
+		}
+		return nil
+	}
+	if err := s.tasksRepo.RemoveOverdueTasks(ctx, filter); err != nil {
 		return shared.NewUnexpectedError(err, "failed to remove overdue tasks")
 	}
 	return nil
 }
+
+// PruneCandidates returns tasks of all users that would be moved to
+// the trash or archived, depending on the prune mode, by the pruning
+// at the moment
+func (s *Service) PruneCandidates(ctx context.Context) ([]Task, *shared.ServiceError) {
+	tasks, err := s.tasksRepo.OverdueTasks(ctx, s.prunePolicy.Filter(time.Now()))
+	if err != nil {
+		return nil, shared.NewUnexpectedError(err, "failed to load overdue tasks")
+	}
+	return tasks, nil
+}
+
+// PurgeDeletedTasks permanently removes tasks that are in the trash
+// longer than the retention period
+func (s *Service) PurgeDeletedTasks(ctx context.Context) *shared.ServiceError {
+	date := time.Now().Add(-s.trashRetention)
+	attachments, err := s.tasksRepo.PurgeTasksDeletedBefore(ctx, date)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to purge deleted tasks")
+	}
+	s.removeBlobs(ctx, attachments)
+	return nil
+}
+
+func (s *Service) taskById(ctx context.Context, login string, id TaskId) (Task, *shared.ServiceError) {
+	task, err := s.tasksRepo.TaskById(ctx, login, id)
+	if errors.Is(err, ErrTaskNotFound) {
+		return task, shared.NewServiceError(err, fmt.Sprintf("task with id %q not found", id.String()))
+	}
+	if err != nil {
+		return task, shared.NewUnexpectedError(err, "failed to load task")
+	}
+	return task, nil
+}
+
+// checkTaskAccess ensures that the user is allowed to modify the task.
+// Tasks of a project are modified according to the role of the user in
+// the project, even by their owner and assignee, other tasks are modified
+// only by their owner and assignee.
+func (s *Service) checkTaskAccess(ctx context.Context, login string, task Task) *shared.ServiceError {
+	if task.ProjectId != nil {
+		sErr := s.checkProjectAccess(ctx, login, task.ProjectId)
+		if sErr != nil && errors.Is(sErr.Err, projects.ErrProjectNotFound) {
+			return shared.NewServiceError(shared.ErrForbidden, "only members of the task project can modify the task")
+		}
+		return sErr
+	}
+	if task.Owner == login || (task.Assignee != nil && *task.Assignee == login) {
+		return nil
+	}
+	return shared.NewServiceError(shared.ErrForbidden, "only the task owner can modify the task")
+}
+
+// checkProjectAccess ensures that the user is allowed to modify tasks
+// of the project.
+func (s *Service) checkProjectAccess(ctx context.Context, login string, projectId *projects.ProjectId) *shared.ServiceError {
+	if projectId == nil {
+		return nil
+	}
+	role, err := s.projectsRepo.ProjectRole(ctx, login, *projectId)
+	if errors.Is(err, projects.ErrProjectNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("project with id %q not found", projectId.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load project role")
+	}
+	if !role.CanWrite() {
+		return shared.NewServiceError(
+			shared.ErrForbidden,
+			fmt.Sprintf("%q role is not allowed to modify project tasks", role.String()),
+		)
+	}
+	return nil
+}
+
+// checkAssignee ensures that the assignee is a registered user.
+func (s *Service) checkAssignee(ctx context.Context, assignee *string) *shared.ServiceError {
+	if assignee == nil {
+		return nil
+	}
+	_, err := s.usersRepo.UserByLogin(ctx, *assignee)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return shared.NewServiceError(
+			ErrAssigneeNotFound,
+			fmt.Sprintf("user with login %q not found", *assignee),
+		)
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load assignee")
+	}
+	return nil
+}
+
+// checkParentTask ensures that the parent task exists and the user
+// is allowed to modify it.
+func (s *Service) checkParentTask(ctx context.Context, login string, parentId *TaskId) *shared.ServiceError {
+	if parentId == nil {
+		return nil
+	}
+	parent, err := s.tasksRepo.TaskById(ctx, login, *parentId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return shared.NewServiceError(
+			ErrParentTaskNotFound,
+			fmt.Sprintf("parent task with id %q not found", parentId.String()),
+		)
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to load parent task")
+	}
+	return s.checkTaskAccess(ctx, login, parent)
+}
+
+// checkParentChange ensures that the new parent does not turn
+// the task hierarchy into a cycle.
+func (s *Service) checkParentChange(ctx context.Context, login string, id TaskId, parentId *TaskId) *shared.ServiceError {
+	if parentId == nil {
+		return nil
+	}
+	if sErr := s.checkParentTask(ctx, login, parentId); sErr != nil {
+		return sErr
+	}
+	isDescendant, err := s.tasksRepo.IsTaskDescendant(ctx, *parentId, id)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to check task hierarchy")
+	}
+	if isDescendant {
+		return shared.NewServiceError(ErrInvalidParentTask, "task can't be a subtask of itself or its subtasks")
+	}
+	return nil
+}
+
+func (s *Service) checkSubtasksDone(ctx context.Context, id TaskId) *shared.ServiceError {
+	count, err := s.tasksRepo.CountOpenSubtasks(ctx, id)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to count open subtasks")
+	}
+	if count > 0 {
+		return shared.NewServiceError(
+			ErrTaskHasOpenSubtasks,
+			fmt.Sprintf("task has %d open subtasks", count),
+		)
+	}
+	return nil
+}
+
+// checkDependencyCycle ensures that the task is not among the transitive
+// blockers of the new blocker, otherwise the new dependency closes a cycle.
+func (s *Service) checkDependencyCycle(ctx context.Context, id TaskId, blockerId TaskId) *shared.ServiceError {
+	visited := map[TaskId]struct{}{blockerId: {}}
+	frontier := []TaskId{blockerId}
+	for len(frontier) > 0 {
+		blockers, err := s.tasksRepo.BlockersIds(ctx, frontier)
+		if err != nil {
+			return shared.NewUnexpectedError(err, "failed to load task blockers")
+		}
+		var next []TaskId
+		for _, b := range blockers {
+			if b == id {
+				return shared.NewServiceError(
+					ErrDependencyCycle,
+					fmt.Sprintf("task %q already depends on %q", blockerId.String(), id.String()),
+				)
+			}
+			if _, ok := visited[b]; ok {
+				continue
+			}
+			visited[b] = struct{}{}
+			next = append(next, b)
+		}
+		frontier = next
+	}
+	return nil
+}
+
+func (s *Service) checkBlockersDone(ctx context.Context, id TaskId) *shared.ServiceError {
+	count, err := s.tasksRepo.CountOpenBlockers(ctx, id)
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to count open blockers")
+	}
+	if count > 0 {
+		return shared.NewServiceError(
+			ErrTaskIsBlocked,
+			fmt.Sprintf("task is blocked by %d open tasks", count),
+		)
+	}
+	return nil
+}
+
+func sameTask(a, b *TaskId) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func checkVersion(task Task, version *int64) *shared.ServiceError {
+	if version == nil || *version == task.Version {
+		return nil
+	}
+	return shared.NewServiceError(
+		ErrVersionMismatch,
+		fmt.Sprintf("task version is %d, but %d is expected", task.Version, *version),
+	)
+}
+
+func (s *Service) checkStatus(status Status) *shared.ServiceError {
+	if s.workflow.HasStatus(status) {
+		return nil
+	}
+	return shared.NewServiceError(ErrInvalidStatus, fmt.Sprintf("unknown status %q", status.String()))
+}
+
+// checkTimeZone ensures that the time zone is a known IANA time zone,
+// the empty time zone is accepted
+func (s *Service) checkTimeZone(timeZone string) *shared.ServiceError {
+	if timeZone == "" {
+		return nil
+	}
+	if err := tz.Validate(timeZone); err != nil {
+		return shared.NewServiceError(err, fmt.Sprintf("unknown time zone %q", timeZone))
+	}
+	return nil
+}
+
+func (s *Service) checkTransition(from Status, to Status) *shared.ServiceError {
+	if sErr := s.checkStatus(to); sErr != nil {
+		return sErr
+	}
+	if s.workflow.CanTransition(from, to) {
+		return nil
+	}
+	return shared.NewServiceError(
+		ErrInvalidTransition,
+		fmt.Sprintf("status can't be changed from %q to %q", from.String(), to.String()),
+	)
+}
+
+func sameAssignee(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameProject(a, b *projects.ProjectId) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// lastPosition returns the position after the last task in the status column
+// of the user board
+func (s *Service) lastPosition(ctx context.Context, login string, status Status, id TaskId) (string, error) {
+	last, err := s.tasksRepo.PrevTaskPosition(ctx, login, status, nil, id)
+	if err != nil {
+		return "", err
+	}
+	return PositionBetween(last, "")
+}
+
+// movePosition returns the position between the neighbours of the moved task,
+// the missing neighbour is the closest task in the column
+func (s *Service) movePosition(ctx context.Context, login string, id TaskId, params MoveParams) (string, *shared.ServiceError) {
+	var lower, upper string
+	var err error
+	if params.After != nil {
+		after, sErr := s.neighbour(ctx, login, id, *params.After, params.Status)
+		if sErr != nil {
+			return "", sErr
+		}
+		lower = after.Position
+	}
+	if params.Before != nil {
+		before, sErr := s.neighbour(ctx, login, id, *params.Before, params.Status)
+		if sErr != nil {
+			return "", sErr
+		}
+		upper = before.Position
+		if params.After == nil {
+			lower, err = s.tasksRepo.PrevTaskPosition(ctx, login, params.Status, &upper, id)
+		}
+	} else if params.After != nil {
+		upper, err = s.tasksRepo.NextTaskPosition(ctx, login, params.Status, lower, id)
+	} else {
+		lower, err = s.tasksRepo.PrevTaskPosition(ctx, login, params.Status, nil, id)
+	}
+	if err != nil {
+		return "", shared.NewUnexpectedError(err, "failed to find neighbour positions")
+	}
+	position, err := PositionBetween(lower, upper)
+	if err != nil {
+		return "", shared.NewServiceError(err, "the task after should precede the task before")
+	}
+	return position, nil
+}
+
+// neighbour loads the neighbour of the moved task from the column of the status
+func (s *Service) neighbour(ctx context.Context, login string, id TaskId, neighbourId TaskId, status Status) (Task, *shared.ServiceError) {
+	if neighbourId == id {
+		return Task{}, shared.NewServiceError(ErrInvalidPosition, "the task can't be its own neighbour")
+	}
+	task, err := s.tasksRepo.TaskById(ctx, login, neighbourId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return task, shared.NewServiceError(ErrInvalidPosition, fmt.Sprintf("neighbour task with id %q not found", neighbourId.String()))
+	}
+	if err != nil {
+		return task, shared.NewUnexpectedError(err, "failed to load neighbour task")
+	}
+	if task.Status != status {
+		return task, shared.NewServiceError(ErrInvalidPosition, fmt.Sprintf("neighbour task with id %q has another status", neighbourId.String()))
+	}
+	return task, nil
+}
+
+// nextOccurrence creates a pending copy of the recurring task being completed
+// with the due date shifted by the recurrence. The copy keeps labels of
+// the task owner since only they can be attached on behalf of the owner.
+func (s *Service) nextOccurrence(ctx context.Context, task Task, params TaskParams) (*Occurrence, *shared.ServiceError) {
+	now := time.Now()
+	next, err := NewTask(
+		NewTaskId(),
+		task.Owner,
+		params.Title,
+		params.Description,
+		Pending,
+		params.Priority,
+		params.Recurrence.Next(params.DueDate),
+		params.ProjectId,
+		params.ParentId,
+		params.Recurrence,
+		params.Assignee,
+		now,
+		now,
+	)
+	if err != nil {
+		return nil, shared.NewUnexpectedError(err, "failed to create next occurrence of the task")
+	}
+	next.DueTime = params.DueTime
+	next.TimeZone = task.TimeZone
+	if params.TimeZone != nil {
+		next.TimeZone = *params.TimeZone
+	}
+	if next.Position, err = s.lastPosition(ctx, next.Owner, next.Status, next.Id); err != nil {
+		return nil, shared.NewUnexpectedError(err, "failed to find position of next occurrence of the task")
+	}
+	labelIds := make([]labels.LabelId, 0, len(task.Labels))
+	for _, l := range task.Labels {
+		if l.Owner == task.Owner {
+			labelIds = append(labelIds, l.Id)
+		}
+	}
+	return &Occurrence{
+		Task:     next,
+		LabelIds: labelIds,
+	}, nil
+}
+
+// removeBlobs deletes contents of the removed attachments. Failures are
+// only logged since the attachments are already gone from the database.
+func (s *Service) removeBlobs(ctx context.Context, ids []AttachmentId) {
+	for _, id := range ids {
+		if err := s.blobStore.Remove(ctx, id.String()); err != nil {
+			s.log.Error(
+				ctx, "failed to remove attachment content",
+				slog.String("attachment_id", id.String()),
+				sl.Err(err),
+			)
+		}
+	}
+}
@@ -9,65 +9,1047 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/auth"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/tz"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 	"github.com/x0k/skillrock-tasks-service/internal/shared"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 )
 
-func newTestService(t *testing.T, setup func(repo *tasks.MockTasksRepo)) *tasks.Service {
+const owner = "owner"
+
+const trashRetention = 30 * 24 * time.Hour
+
+type serviceMocks struct {
+	tasksRepo    *tasks.MockTasksRepo
+	projectsRepo *tasks.MockProjectsRepo
+	usersRepo    *tasks.MockUsersRepo
+	blobStore    *tasks.MockBlobStore
+}
+
+func newTestService(t *testing.T, setup func(serviceMocks)) *tasks.Service {
+	return newTestServiceWithPrunePolicy(t, tasks.DefaultPrunePolicy(), setup)
+}
+
+func newTestServiceWithPrunePolicy(t *testing.T, prunePolicy tasks.PrunePolicy, setup func(serviceMocks)) *tasks.Service {
 	var buf bytes.Buffer
 	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	})))
-	repo := tasks.NewMockTasksRepo(t)
+	tasksRepo := tasks.NewMockTasksRepo(t)
+	projectsRepo := tasks.NewMockProjectsRepo(t)
+	usersRepo := tasks.NewMockUsersRepo(t)
+	blobStore := tasks.NewMockBlobStore(t)
 	if setup != nil {
-		setup(repo)
+		setup(serviceMocks{
+			tasksRepo:    tasksRepo,
+			projectsRepo: projectsRepo,
+			usersRepo:    usersRepo,
+			blobStore:    blobStore,
+		})
+	}
+	return tasks.NewService(
+		log,
+		tasksRepo,
+		projectsRepo,
+		usersRepo,
+		blobStore,
+		tasks.DefaultWorkflow(),
+		prunePolicy,
+		trashRetention,
+	)
+}
+
+func TestServiceCreateTask(t *testing.T) {
+	title := "title"
+	dueDate := time.Now().Add(time.Hour)
+	params := tasks.TaskParams{
+		Title:    title,
+		DueDate:  dueDate,
+		Status:   tasks.Pending,
+		Priority: tasks.Low,
+	}
+	projectId := projects.NewProjectId()
+	paramsWithProject := params
+	paramsWithProject.ProjectId = &projectId
+	paramsWithLabels := params
+	paramsWithLabels.LabelIds = []labels.LabelId{labels.NewLabelId()}
+	assignee := "assignee"
+	paramsWithAssignee := params
+	paramsWithAssignee.Assignee = &assignee
+	dueTime, _ := tasks.NewDueTime(17, 0)
+	timeZone := "Europe/Berlin"
+	paramsWithDueTime := params
+	paramsWithDueTime.DueTime = &dueTime
+	paramsWithDueTime.TimeZone = &timeZone
+	unknownTimeZone := "Mars/Olympus"
+	paramsWithUnknownTimeZone := params
+	paramsWithUnknownTimeZone.TimeZone = &unknownTimeZone
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		params  tasks.TaskParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Pending, (*string)(nil), mock.Anything).Return("i", nil)
+				paramsMatcher := mock.MatchedBy(func(t tasks.Task) bool {
+					return t.Title == title && t.DueDate.Equal(dueDate) &&
+						t.Status == tasks.Pending && t.Priority == tasks.Low && t.Position > "i"
+				})
+				sm.tasksRepo.EXPECT().SaveTask(mock.Anything, owner, paramsMatcher, params.LabelIds).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name: "with assignee",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.usersRepo.EXPECT().UserByLogin(mock.Anything, assignee).Return(auth.NewUser(assignee, nil), nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+				assigneeMatcher := mock.MatchedBy(func(t tasks.Task) bool {
+					return t.Owner == owner && t.Assignee != nil && *t.Assignee == assignee
+				})
+				sm.tasksRepo.EXPECT().SaveTask(mock.Anything, owner, assigneeMatcher, params.LabelIds).Return(nil)
+			}),
+			params: paramsWithAssignee,
+		},
+		{
+			name: "unknown assignee",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.usersRepo.EXPECT().UserByLogin(mock.Anything, assignee).Return(nil, auth.ErrUserNotFound)
+			}),
+			params: paramsWithAssignee,
+			err:    shared.NewServiceError(tasks.ErrAssigneeNotFound, ""),
+		},
+		{
+			name: "with due time",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+				dueTimeMatcher := mock.MatchedBy(func(t tasks.Task) bool {
+					return t.DueTime != nil && *t.DueTime == dueTime && t.TimeZone == timeZone
+				})
+				sm.tasksRepo.EXPECT().SaveTask(mock.Anything, owner, dueTimeMatcher, params.LabelIds).Return(nil)
+			}),
+			params: paramsWithDueTime,
+		},
+		{
+			name:    "unknown time zone",
+			service: newTestService(t, func(sm serviceMocks) {}),
+			params:  paramsWithUnknownTimeZone,
+			err:     shared.NewServiceError(tz.ErrInvalidTimeZone, ""),
+		},
+		{
+			name: "unknown label",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+				sm.tasksRepo.EXPECT().SaveTask(mock.Anything, owner, mock.Anything, paramsWithLabels.LabelIds).
+					Return(labels.ErrLabelNotFound)
+			}),
+			params: paramsWithLabels,
+			err:    shared.NewServiceError(labels.ErrLabelNotFound, ""),
+		},
+		{
+			name: "unknown project",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).
+					Return(projects.Viewer, projects.ErrProjectNotFound)
+			}),
+			params: paramsWithProject,
+			err:    shared.NewServiceError(projects.ErrProjectNotFound, ""),
+		},
+		{
+			name: "read only project",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).
+					Return(projects.Viewer, nil)
+			}),
+			params: paramsWithProject,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+				sm.tasksRepo.EXPECT().SaveTask(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.CreateTask(t.Context(), owner, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceFindTask(t *testing.T) {
+	var filter tasks.TasksFilter
+	unexpectedErr := errors.New("unexpected error")
+	now := time.Now()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	next := task
+	next.Id = tasks.NewTaskId()
+	sort := tasks.Sort{{Field: tasks.SortByDueDate}, {Field: tasks.SortByPriority, Desc: true}}
+	cursor := tasks.NewCursor(sort, task)
+	defaultCursor := tasks.NewCursor(tasks.DefaultSort, task)
+	total := int64(2)
+	query := "tests"
+	searchFilter := tasks.TasksFilter{Query: &query}
+	found := task
+	found.Match = &tasks.SearchMatch{Rank: 0.5, Title: "<mark>tests</mark>"}
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		filter  tasks.TasksFilter
+		page    tasks.PageParams
+		result  tasks.TasksPage
+		err     *shared.ServiceError
+	}{
+		{
+			name: "empty filter",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, owner, filter, tasks.PageParams{
+					Sort:  tasks.DefaultSort,
+					Limit: tasks.DefaultPageLimit + 1,
+				}).Return([]tasks.Task{task}, nil)
+			}),
+			result: tasks.TasksPage{Tasks: []tasks.Task{task}},
+		},
+		{
+			name: "next page",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, owner, filter, tasks.PageParams{
+					Sort:  sort,
+					Limit: 2,
+				}).Return([]tasks.Task{task, next}, nil)
+			}),
+			page:   tasks.PageParams{Sort: sort, Limit: 1},
+			result: tasks.TasksPage{Tasks: []tasks.Task{task}, NextCursor: &cursor},
+		},
+		{
+			name: "total count",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, owner, filter, tasks.PageParams{
+					Sort:      tasks.DefaultSort,
+					Cursor:    &defaultCursor,
+					Limit:     tasks.MaxPageLimit + 1,
+					WithTotal: true,
+				}).Return([]tasks.Task{next}, nil)
+				sm.tasksRepo.EXPECT().CountTasks(mock.Anything, owner, filter).Return(total, nil)
+			}),
+			page: tasks.PageParams{
+				Cursor:    &defaultCursor,
+				Limit:     tasks.MaxPageLimit + 1,
+				WithTotal: true,
+			},
+			result: tasks.TasksPage{Tasks: []tasks.Task{next}, Total: &total},
+		},
+		{
+			name: "search query",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, owner, searchFilter, tasks.PageParams{
+					Sort:  tasks.DefaultSearchSort,
+					Limit: tasks.DefaultPageLimit + 1,
+				}).Return([]tasks.Task{found}, nil)
+			}),
+			filter: searchFilter,
+			result: tasks.TasksPage{Tasks: []tasks.Task{found}},
+		},
+		{
+			name:    "sort by rank without search query",
+			service: newTestService(t, func(sm serviceMocks) {}),
+			page:    tasks.PageParams{Sort: tasks.DefaultSearchSort},
+			err:     shared.NewServiceError(tasks.ErrInvalidSort, ""),
+		},
+		{
+			name:    "unknown status in filter expression",
+			service: newTestService(t, func(sm serviceMocks) {}),
+			filter: tasks.TasksFilter{Expr: tasks.NotExpr{Operand: tasks.CompareExpr{
+				Field: tasks.FilterByStatus,
+				Op:    tasks.OpEq,
+				Value: tasks.Status("unknown"),
+			}}},
+			err: shared.NewServiceError(tasks.ErrInvalidStatus, ""),
+		},
+		{
+			name:    "cursor of another sort",
+			service: newTestService(t, func(sm serviceMocks) {}),
+			page:    tasks.PageParams{Cursor: &cursor},
+			err:     shared.NewServiceError(tasks.ErrInvalidCursor, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := c.service.FindTasks(t.Context(), owner, c.filter, c.page)
+			if err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(c.result, result) {
+				t.Fatalf("expected page %v, but got %v", c.result, result)
+			}
+		})
+	}
+}
+
+func TestServiceArchivedTasks(t *testing.T) {
+	now := time.Now()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Medium,
+		now,
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	task.ArchivedAt = &now
+	priority := tasks.Medium
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		filter  tasks.TasksFilter
+		result  tasks.TasksPage
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, owner, tasks.TasksFilter{
+					Priority: &priority,
+					Archived: true,
+				}, tasks.PageParams{
+					Sort:  tasks.DefaultSort,
+					Limit: tasks.DefaultPageLimit + 1,
+				}).Return([]tasks.Task{task}, nil)
+			}),
+			filter: tasks.TasksFilter{Priority: &priority},
+			result: tasks.TasksPage{Tasks: []tasks.Task{task}},
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().FindTasks(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := c.service.ArchivedTasks(t.Context(), owner, c.filter, tasks.PageParams{})
+			if err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(c.result, result) {
+				t.Fatalf("expected page %v, but got %v", c.result, result)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateTaskById(t *testing.T) {
+	now := time.Now()
+	projectId := projects.NewProjectId()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	sharedTask := task
+	sharedTask.Owner = "other"
+	sharedTask.ProjectId = &projectId
+	ownedSharedTask := sharedTask
+	ownedSharedTask.Owner = owner
+	doneTask := task
+	doneTask.Status = tasks.Done
+	params := tasks.TaskParams{
+		Title:    "new title",
+		Status:   tasks.Pending,
+		Priority: tasks.High,
+		DueDate:  time.Now().Add(time.Hour),
+	}
+	sharedParams := params
+	sharedParams.ProjectId = &projectId
+	parent := task
+	parent.Id = tasks.NewTaskId()
+	subtaskParams := params
+	subtaskParams.ParentId = &parent.Id
+	doneParams := params
+	doneParams.Status = tasks.Done
+	startParams := params
+	startParams.Status = tasks.InProgress
+	blockParams := params
+	blockParams.Status = tasks.Blocked
+	reviewParams := params
+	reviewParams.Status = tasks.InReview
+	unknownStatusParams := params
+	unknownStatusParams.Status = tasks.Status("unknown")
+	lastBlocked, pErr := tasks.PositionBetween("x", "")
+	if pErr != nil {
+		t.Fatal("failed to prepare position")
+	}
+	weekly, rErr := tasks.ParseRecurrence("FREQ=WEEKLY")
+	if rErr != nil {
+		t.Fatal("failed to prepare recurrence")
+	}
+	recurringParams := doneParams
+	recurringParams.Recurrence = &weekly
+	assignee := "assignee"
+	assignedTask := sharedTask
+	assignedTask.Assignee = &assignee
+	assignedParams := sharedParams
+	assignedParams.Assignee = &assignee
+	staleVersion := task.Version - 1
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		login   string
+		taskId  tasks.TaskId
+		version *int64
+		params  tasks.TaskParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, params, "", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: params,
+		},
+		{
+			name: "matching version",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, params, "", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId:  task.Id,
+			version: &task.Version,
+			params:  params,
+		},
+		{
+			name: "stale version",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+			}),
+			taskId:  task.Id,
+			version: &staleVersion,
+			params:  params,
+			err:     shared.NewServiceError(tasks.ErrVersionMismatch, ""),
+		},
+		{
+			name: "concurrent update",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, params, "", (*tasks.Occurrence)(nil)).
+					Return(tasks.ErrVersionMismatch)
+			}),
+			taskId: task.Id,
+			params: params,
+			err:    shared.NewServiceError(tasks.ErrVersionMismatch, ""),
+		},
+		{
+			name: "allowed transition",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Blocked, (*string)(nil), task.Id).Return("x", nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, blockParams, lastBlocked, (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: blockParams,
+		},
+		{
+			name: "forbidden transition",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+			}),
+			taskId: task.Id,
+			params: reviewParams,
+			err:    shared.NewServiceError(tasks.ErrInvalidTransition, ""),
+		},
+		{
+			name: "unknown status",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+			}),
+			taskId: task.Id,
+			params: unknownStatusParams,
+			err:    shared.NewServiceError(tasks.ErrInvalidStatus, ""),
+		},
+		{
+			name: "project editor",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, sharedTask.Id).Return(sharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Editor, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, sharedTask.Id, sharedTask.Version, sharedParams, "", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: sharedTask.Id,
+			params: sharedParams,
+		},
+		{
+			name: "project viewer",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, sharedTask.Id).Return(sharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Viewer, nil)
+			}),
+			taskId: sharedTask.Id,
+			params: sharedParams,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "project viewer owner",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, ownedSharedTask.Id).Return(ownedSharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Viewer, nil)
+			}),
+			taskId: ownedSharedTask.Id,
+			params: sharedParams,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "assignee",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, assignee, assignedTask.Id).Return(assignedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, assignee, projectId).Return(projects.Editor, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, assignee, assignedTask.Id, assignedTask.Version, assignedParams, "", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			login:  assignee,
+			taskId: assignedTask.Id,
+			params: assignedParams,
+		},
+		{
+			name: "project viewer assignee",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, assignee, assignedTask.Id).Return(assignedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, assignee, projectId).Return(projects.Viewer, nil)
+			}),
+			login:  assignee,
+			taskId: assignedTask.Id,
+			params: assignedParams,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "assignee outside project",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, assignee, assignedTask.Id).Return(assignedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, assignee, projectId).Return(projects.Viewer, projects.ErrProjectNotFound)
+			}),
+			login:  assignee,
+			taskId: assignedTask.Id,
+			params: assignedParams,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "unknown assignee",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.usersRepo.EXPECT().UserByLogin(mock.Anything, assignee).Return(nil, auth.ErrUserNotFound)
+			}),
+			taskId: task.Id,
+			params: assignedParams,
+			err:    shared.NewServiceError(tasks.ErrAssigneeNotFound, ""),
+		},
+		{
+			name: "new parent",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, parent.Id).Return(parent, nil)
+				sm.tasksRepo.EXPECT().IsTaskDescendant(mock.Anything, parent.Id, task.Id).Return(false, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, subtaskParams, "", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: subtaskParams,
+		},
+		{
+			name: "unknown parent",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, parent.Id).Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			taskId: task.Id,
+			params: subtaskParams,
+			err:    shared.NewServiceError(tasks.ErrParentTaskNotFound, ""),
+		},
+		{
+			name: "parent cycle",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, parent.Id).Return(parent, nil)
+				sm.tasksRepo.EXPECT().IsTaskDescendant(mock.Anything, parent.Id, task.Id).Return(true, nil)
+			}),
+			taskId: task.Id,
+			params: subtaskParams,
+			err:    shared.NewServiceError(tasks.ErrInvalidParentTask, ""),
+		},
+		{
+			name: "done recurring task",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenSubtasks(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Done, (*string)(nil), task.Id).Return("", nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Pending, (*string)(nil), mock.Anything).Return("", nil)
+				nextMatcher := mock.MatchedBy(func(next *tasks.Occurrence) bool {
+					return next != nil &&
+						next.Task.Id != task.Id &&
+						next.Task.Owner == owner &&
+						next.Task.Status == tasks.Pending &&
+						next.Task.DueDate.Equal(recurringParams.DueDate.AddDate(0, 0, 7)) &&
+						next.Task.Recurrence == recurringParams.Recurrence &&
+						len(next.LabelIds) == 0
+				})
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, recurringParams, mock.Anything, nextMatcher).Return(nil)
+			}),
+			taskId: task.Id,
+			params: recurringParams,
+		},
+		{
+			name: "next occurrence failure",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenSubtasks(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Done, (*string)(nil), task.Id).Return("", nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Pending, (*string)(nil), mock.Anything).Return("", unexpectedErr)
+			}),
+			taskId: task.Id,
+			params: recurringParams,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+		{
+			name: "already done task",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, doneTask.Id).Return(doneTask, nil)
+			}),
+			taskId: doneTask.Id,
+			params: doneParams,
+			err:    shared.NewServiceError(tasks.ErrTaskIsAlreadyDone, ""),
+		},
+		{
+			name: "done with open subtasks",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenSubtasks(mock.Anything, task.Id).Return(2, nil)
+			}),
+			taskId: task.Id,
+			params: doneParams,
+			err:    shared.NewServiceError(tasks.ErrTaskHasOpenSubtasks, ""),
+		},
+		{
+			name: "done without open subtasks",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenSubtasks(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Done, (*string)(nil), task.Id).Return("", nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, doneParams, mock.Anything, (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: doneParams,
+		},
+		{
+			name: "start blocked task",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(1, nil)
+			}),
+			taskId: task.Id,
+			params: startParams,
+			err:    shared.NewServiceError(tasks.ErrTaskIsBlocked, ""),
+		},
+		{
+			name: "start unblocked task",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.InProgress, (*string)(nil), task.Id).Return("", nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, owner, task.Id, task.Version, startParams, mock.Anything, (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: startParams,
+		},
+		{
+			name: "task not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, mock.Anything, mock.Anything).Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			err: shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, mock.Anything, mock.Anything).Return(task, nil)
+				sm.tasksRepo.EXPECT().UpdateTaskById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			taskId: task.Id,
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			login := c.login
+			if login == "" {
+				login = owner
+			}
+			if err := c.service.UpdateTaskById(t.Context(), login, c.taskId, c.version, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestRemoveTaskById(t *testing.T) {
+	now := time.Now()
+	projectId := projects.NewProjectId()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	sharedTask := task
+	sharedTask.Owner = "other"
+	sharedTask.ProjectId = &projectId
+	staleVersion := task.Version - 1
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		taskId  tasks.TaskId
+		version *int64
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().RemoveTaskById(mock.Anything, owner, task.Id, task.Version).Return(nil)
+			}),
+			taskId: task.Id,
+		},
+		{
+			name: "stale version",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+			}),
+			taskId:  task.Id,
+			version: &staleVersion,
+			err:     shared.NewServiceError(tasks.ErrVersionMismatch, ""),
+		},
+		{
+			name: "project viewer",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, sharedTask.Id).Return(sharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Viewer, nil)
+			}),
+			taskId: sharedTask.Id,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "task not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, mock.Anything, mock.Anything).Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			err: shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, mock.Anything, mock.Anything).Return(task, nil)
+				sm.tasksRepo.EXPECT().RemoveTaskById(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			taskId: task.Id,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RemoveTaskById(t.Context(), owner, c.taskId, c.version); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceRestoreTaskById(t *testing.T) {
+	now := time.Now()
+	projectId := projects.NewProjectId()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	task.DeletedAt = &now
+	sharedTask := task
+	sharedTask.Owner = "other"
+	sharedTask.ProjectId = &projectId
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		taskId  tasks.TaskId
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().DeletedTaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().RestoreTaskById(mock.Anything, owner, task.Id).Return(nil)
+			}),
+			taskId: task.Id,
+		},
+		{
+			name: "deleted parent",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().DeletedTaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().RestoreTaskById(mock.Anything, owner, task.Id).Return(tasks.ErrParentTaskIsDeleted)
+			}),
+			taskId: task.Id,
+			err:    shared.NewServiceError(tasks.ErrParentTaskIsDeleted, ""),
+		},
+		{
+			name: "project viewer",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().DeletedTaskById(mock.Anything, owner, sharedTask.Id).Return(sharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Viewer, nil)
+			}),
+			taskId: sharedTask.Id,
+			err:    shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "task not in trash",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().DeletedTaskById(mock.Anything, mock.Anything, mock.Anything).Return(tasks.Task{}, tasks.ErrTaskNotFound)
+			}),
+			err: shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().DeletedTaskById(mock.Anything, mock.Anything, mock.Anything).Return(task, nil)
+				sm.tasksRepo.EXPECT().RestoreTaskById(mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			taskId: task.Id,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.RestoreTaskById(t.Context(), owner, c.taskId); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
 	}
-	return tasks.NewService(
-		log,
-		repo,
-	)
 }
 
-func TestServiceCreateTask(t *testing.T) {
-	title := "title"
-	dueDate := time.Now().Add(time.Hour)
-	params := tasks.TaskParams{
-		Title:    title,
-		DueDate:  dueDate,
-		Status:   tasks.Pending,
-		Priority: tasks.Low,
+func TestServiceReopenTaskById(t *testing.T) {
+	now := time.Now()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Done,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	openTask := task
+	openTask.Status = tasks.InProgress
+	parent := task
+	parent.Id = tasks.NewTaskId()
+	subtask := task
+	subtask.Id = tasks.NewTaskId()
+	subtask.ParentId = &parent.Id
+	reason := "closed by mistake"
+	lastInProgress, pErr := tasks.PositionBetween("x", "")
+	if pErr != nil {
+		t.Fatal("failed to prepare position")
 	}
 	unexpectedErr := errors.New("unexpected err")
 	cases := []struct {
 		name    string
 		service *tasks.Service
-		params  tasks.TaskParams
+		taskId  tasks.TaskId
+		reason  *string
 		err     *shared.ServiceError
 	}{
 		{
-			name: "valid params",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				paramsMatcher := mock.MatchedBy(func(t tasks.Task) bool {
-					return t.Title == title && t.DueDate.Equal(dueDate) &&
-						t.Status == tasks.Pending && t.Priority == tasks.Low
-				})
-				repo.EXPECT().SaveTask(mock.Anything, paramsMatcher).Return(nil)
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.InProgress, (*string)(nil), task.Id).Return("x", nil)
+				sm.tasksRepo.EXPECT().ReopenTaskById(mock.Anything, owner, task.Id, task.Version, lastInProgress, &reason).Return(nil)
 			}),
-			params: params,
+			taskId: task.Id,
+			reason: &reason,
+		},
+		{
+			name: "task is not done",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, openTask.Id).Return(openTask, nil)
+			}),
+			taskId: openTask.Id,
+			err:    shared.NewServiceError(tasks.ErrTaskIsNotDone, ""),
+		},
+		{
+			name: "parent task is done",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, subtask.Id).Return(subtask, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, parent.Id).Return(parent, nil)
+			}),
+			taskId: subtask.Id,
+			err:    shared.NewServiceError(tasks.ErrParentTaskIsDone, ""),
+		},
+		{
+			name: "open blockers",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(1, nil)
+			}),
+			taskId: task.Id,
+			err:    shared.NewServiceError(tasks.ErrTaskIsBlocked, ""),
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().SaveTask(mock.Anything, mock.Anything).Return(unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.InProgress, (*string)(nil), task.Id).Return("", nil)
+				sm.tasksRepo.EXPECT().ReopenTaskById(mock.Anything, owner, task.Id, task.Version, mock.Anything, (*string)(nil)).Return(unexpectedErr)
 			}),
-			params: params,
+			taskId: task.Id,
 			err:    shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			if err := c.service.CreateTask(t.Context(), c.params); err != nil {
+			if err := c.service.ReopenTaskById(t.Context(), owner, c.taskId, nil, c.reason); err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
 					err.Expected != c.err.Expected ||
@@ -76,53 +1058,146 @@ func TestServiceCreateTask(t *testing.T) {
 				}
 				return
 			}
+			if c.err != nil {
+				t.Fatalf("expected error %v", c.err)
+			}
 		})
 	}
 }
 
-func TestServiceFindTask(t *testing.T) {
-	var filter tasks.TasksFilter
-	unexpectedErr := errors.New("unexpected error")
+func TestServiceMoveTaskById(t *testing.T) {
 	now := time.Now()
 	task, tErr := tasks.NewTask(
 		tasks.NewTaskId(),
+		owner,
 		"title",
 		nil,
 		tasks.Pending,
 		tasks.Low,
 		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
 		now,
 		now,
 	)
 	if tErr != nil {
 		t.Fatal("failed to prepare task")
 	}
+	task.Position = "m"
+	doneTask := task
+	doneTask.Status = tasks.Done
+	after := task
+	after.Id = tasks.NewTaskId()
+	after.Status = tasks.InProgress
+	after.Position = "b"
+	before := after
+	before.Id = tasks.NewTaskId()
+	before.Position = "d"
+	pendingNeighbour := after
+	pendingNeighbour.Status = tasks.Pending
+	unexpectedErr := errors.New("unexpected err")
 	cases := []struct {
 		name    string
 		service *tasks.Service
-		filter  tasks.TasksFilter
-		tasks   []tasks.Task
+		taskId  tasks.TaskId
+		params  tasks.MoveParams
 		err     *shared.ServiceError
 	}{
 		{
-			name: "empty filter",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().FindTasks(mock.Anything, filter).Return([]tasks.Task{task}, nil)
+			name: "between neighbours",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, after.Id).Return(after, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, before.Id).Return(before, nil)
+				sm.tasksRepo.EXPECT().MoveTaskById(mock.Anything, owner, task.Id, task.Version, tasks.InProgress, "c", (*tasks.Occurrence)(nil)).Return(nil)
 			}),
-			tasks: []tasks.Task{task},
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.InProgress, After: &after.Id, Before: &before.Id},
+		},
+		{
+			name: "after neighbour",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, after.Id).Return(after, nil)
+				sm.tasksRepo.EXPECT().NextTaskPosition(mock.Anything, owner, tasks.InProgress, after.Position, task.Id).Return(before.Position, nil)
+				sm.tasksRepo.EXPECT().MoveTaskById(mock.Anything, owner, task.Id, task.Version, tasks.InProgress, "c", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.InProgress, After: &after.Id},
+		},
+		{
+			name: "before neighbour",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, before.Id).Return(before, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.InProgress, &before.Position, task.Id).Return(after.Position, nil)
+				sm.tasksRepo.EXPECT().MoveTaskById(mock.Anything, owner, task.Id, task.Version, tasks.InProgress, "c", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.InProgress, Before: &before.Id},
+		},
+		{
+			name: "end of column",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Pending, (*string)(nil), task.Id).Return("01", nil)
+				sm.tasksRepo.EXPECT().MoveTaskById(mock.Anything, owner, task.Id, task.Version, tasks.Pending, "02", (*tasks.Occurrence)(nil)).Return(nil)
+			}),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.Pending},
+		},
+		{
+			name: "neighbour of another status",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, pendingNeighbour.Id).Return(pendingNeighbour, nil)
+			}),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.InProgress, After: &pendingNeighbour.Id},
+			err:    shared.NewServiceError(tasks.ErrInvalidPosition, ""),
+		},
+		{
+			name: "neighbours out of order",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().CountOpenBlockers(mock.Anything, task.Id).Return(0, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, before.Id).Return(before, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, after.Id).Return(after, nil)
+			}),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.InProgress, After: &before.Id, Before: &after.Id},
+			err:    shared.NewServiceError(tasks.ErrInvalidPosition, ""),
+		},
+		{
+			name: "invalid transition",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, doneTask.Id).Return(doneTask, nil)
+			}),
+			taskId: doneTask.Id,
+			params: tasks.MoveParams{Status: tasks.Pending},
+			err:    shared.NewServiceError(tasks.ErrInvalidTransition, ""),
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().FindTasks(mock.Anything, mock.Anything).Return(nil, unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+				sm.tasksRepo.EXPECT().MoveTaskById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
 			}),
-			err: shared.NewUnexpectedError(unexpectedErr, ""),
+			taskId: task.Id,
+			params: tasks.MoveParams{Status: tasks.Pending},
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			tasks, err := c.service.FindTasks(t.Context(), c.filter)
-			if err != nil {
+			if err := c.service.MoveTaskById(t.Context(), owner, c.taskId, nil, c.params); err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
 					err.Expected != c.err.Expected ||
@@ -131,55 +1206,99 @@ func TestServiceFindTask(t *testing.T) {
 				}
 				return
 			}
-			if !reflect.DeepEqual(c.tasks, tasks) {
-				t.Fatalf("expected tasks %v, but got %v", c.tasks, tasks)
+			if c.err != nil {
+				t.Fatalf("expected error %v", c.err)
 			}
 		})
 	}
 }
 
-func TestServiceUpdateTaskById(t *testing.T) {
-	taskId := tasks.NewTaskId()
-	params := tasks.TaskParams{
-		Title:    "new title",
-		Status:   tasks.Pending,
-		Priority: tasks.High,
-		DueDate:  time.Now().Add(time.Hour),
+func TestServiceAddTaskBlocker(t *testing.T) {
+	now := time.Now()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
 	}
+	blocker := task
+	blocker.Id = tasks.NewTaskId()
+	intermediateId := tasks.NewTaskId()
 	unexpectedErr := errors.New("unexpected error")
 	cases := []struct {
-		name    string
-		service *tasks.Service
-		taskId  tasks.TaskId
-		params  tasks.TaskParams
-		err     *shared.ServiceError
+		name      string
+		service   *tasks.Service
+		blockerId tasks.TaskId
+		err       *shared.ServiceError
 	}{
 		{
 			name: "happy path",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().UpdateTaskById(mock.Anything, taskId, params).Return(nil)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, blocker.Id).Return(blocker, nil)
+				sm.tasksRepo.EXPECT().BlockersIds(mock.Anything, []tasks.TaskId{blocker.Id}).
+					Return([]tasks.TaskId{intermediateId}, nil)
+				sm.tasksRepo.EXPECT().BlockersIds(mock.Anything, []tasks.TaskId{intermediateId}).
+					Return(nil, nil)
+				sm.tasksRepo.EXPECT().SaveTaskBlocker(mock.Anything, task.Id, blocker.Id).Return(nil)
 			}),
-			taskId: taskId,
-			params: params,
+			blockerId: blocker.Id,
 		},
 		{
-			name: "task not found",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().UpdateTaskById(mock.Anything, mock.Anything, mock.Anything).Return(tasks.ErrTaskNotFound)
+			name:      "self blocking",
+			service:   newTestService(t, nil),
+			blockerId: task.Id,
+			err:       shared.NewServiceError(tasks.ErrInvalidBlocker, ""),
+		},
+		{
+			name: "blocker not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, blocker.Id).
+					Return(tasks.Task{}, tasks.ErrTaskNotFound)
 			}),
-			err: shared.NewServiceError(tasks.ErrTaskNotFound, ""),
+			blockerId: blocker.Id,
+			err:       shared.NewServiceError(tasks.ErrBlockerNotFound, ""),
+		},
+		{
+			name: "dependency cycle",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, blocker.Id).Return(blocker, nil)
+				sm.tasksRepo.EXPECT().BlockersIds(mock.Anything, []tasks.TaskId{blocker.Id}).
+					Return([]tasks.TaskId{intermediateId}, nil)
+				sm.tasksRepo.EXPECT().BlockersIds(mock.Anything, []tasks.TaskId{intermediateId}).
+					Return([]tasks.TaskId{task.Id}, nil)
+			}),
+			blockerId: blocker.Id,
+			err:       shared.NewServiceError(tasks.ErrDependencyCycle, ""),
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().UpdateTaskById(mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, blocker.Id).Return(blocker, nil)
+				sm.tasksRepo.EXPECT().BlockersIds(mock.Anything, mock.Anything).Return(nil, unexpectedErr)
 			}),
-			err: shared.NewUnexpectedError(unexpectedErr, ""),
+			blockerId: blocker.Id,
+			err:       shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			if err := c.service.UpdateTaskById(t.Context(), c.taskId, c.params); err != nil {
+			if err := c.service.AddTaskBlocker(t.Context(), owner, task.Id, c.blockerId); err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
 					err.Expected != c.err.Expected ||
@@ -192,40 +1311,46 @@ func TestServiceUpdateTaskById(t *testing.T) {
 	}
 }
 
-func TestRemoveTaskById(t *testing.T) {
+func TestServiceTaskHistory(t *testing.T) {
 	taskId := tasks.NewTaskId()
+	events := []tasks.TaskEvent{
+		tasks.NewTaskEvent(tasks.NewTaskEventId(), taskId, owner, tasks.TaskCreated, nil, nil, time.Now()),
+	}
 	unexpectedErr := errors.New("unexpected err")
 	cases := []struct {
 		name    string
 		service *tasks.Service
-		taskId  tasks.TaskId
+		events  []tasks.TaskEvent
 		err     *shared.ServiceError
 	}{
 		{
 			name: "happy path",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().RemoveTaskById(mock.Anything, taskId).Return(nil)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, taskId).Return(tasks.Task{Id: taskId}, nil)
+				sm.tasksRepo.EXPECT().TaskEvents(mock.Anything, taskId).Return(events, nil)
 			}),
-			taskId: taskId,
+			events: events,
 		},
 		{
 			name: "task not found",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().RemoveTaskById(mock.Anything, mock.Anything).Return(tasks.ErrTaskNotFound)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, taskId).Return(tasks.Task{}, tasks.ErrTaskNotFound)
 			}),
 			err: shared.NewServiceError(tasks.ErrTaskNotFound, ""),
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().RemoveTaskById(mock.Anything, mock.Anything).Return(unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, taskId).Return(tasks.Task{Id: taskId}, nil)
+				sm.tasksRepo.EXPECT().TaskEvents(mock.Anything, taskId).Return(nil, unexpectedErr)
 			}),
 			err: shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			if err := c.service.RemoveTaskById(t.Context(), c.taskId); err != nil {
+			events, err := c.service.TaskHistory(t.Context(), owner, taskId)
+			if err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
 					err.Expected != c.err.Expected ||
@@ -234,6 +1359,9 @@ func TestRemoveTaskById(t *testing.T) {
 				}
 				return
 			}
+			if !reflect.DeepEqual(c.events, events) {
+				t.Fatalf("expected events %v, but got %v", c.events, events)
+			}
 		})
 	}
 }
@@ -242,11 +1370,16 @@ func TestServiceExportTasks(t *testing.T) {
 	now := time.Now()
 	task, tErr := tasks.NewTask(
 		tasks.NewTaskId(),
+		owner,
 		"title",
 		nil,
 		tasks.Pending,
 		tasks.Low,
 		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
 		now,
 		now,
 	)
@@ -262,22 +1395,22 @@ func TestServiceExportTasks(t *testing.T) {
 	}{
 		{
 			name: "happy path",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().AllTasks(mock.Anything).Return([]tasks.Task{task}, nil)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().AllTasks(mock.Anything, owner).Return([]tasks.Task{task}, nil)
 			}),
 			tasks: []tasks.Task{task},
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().AllTasks(mock.Anything).Return(nil, unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().AllTasks(mock.Anything, owner).Return(nil, unexpectedErr)
 			}),
 			err: shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			tasks, err := c.service.ExportTasks(t.Context())
+			tasks, err := c.service.ExportTasks(t.Context(), owner)
 			if err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
@@ -298,11 +1431,16 @@ func TestServiceImportTasks(t *testing.T) {
 	now := time.Now()
 	task, tErr := tasks.NewTask(
 		tasks.NewTaskId(),
+		owner,
 		"title",
 		nil,
 		tasks.Pending,
 		tasks.Low,
 		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
 		now,
 		now,
 	)
@@ -319,22 +1457,26 @@ func TestServiceImportTasks(t *testing.T) {
 	}{
 		{
 			name: "happy path",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().SaveTasks(mock.Anything, ts).Return(nil)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().PrevTaskPosition(mock.Anything, owner, tasks.Pending, (*string)(nil), task.Id).Return("i", nil)
+				tasksMatcher := mock.MatchedBy(func(ts []tasks.Task) bool {
+					return len(ts) == 1 && ts[0].Id == task.Id && ts[0].Position > "i"
+				})
+				sm.tasksRepo.EXPECT().SaveTasks(mock.Anything, owner, tasksMatcher).Return(nil)
 			}),
 			tasks: ts,
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().SaveTasks(mock.Anything, mock.Anything).Return(unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().SaveTasks(mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
 			}),
 			err: shared.NewUnexpectedError(unexpectedErr, ""),
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			if err := c.service.ImportTasks(t.Context(), c.tasks); err != nil {
+			if err := c.service.ImportTasks(t.Context(), owner, c.tasks); err != nil {
 				if c.err == nil ||
 					!errors.Is(err.Err, c.err.Err) ||
 					err.Expected != c.err.Expected ||
@@ -348,6 +1490,19 @@ func TestServiceImportTasks(t *testing.T) {
 }
 
 func TestServicePruneOverdueTasks(t *testing.T) {
+	policy, pErr := tasks.NewPrunePolicy(tasks.PruneToTrash, 24*time.Hour, []tasks.Priority{tasks.Low}, true, true, false)
+	if pErr != nil {
+		t.Fatal("failed to prepare prune policy")
+	}
+	dryRunPolicy := policy
+	dryRunPolicy.DryRun = true
+	archivePolicy := policy
+	archivePolicy.Mode = tasks.PruneToArchive
+	matchesPolicy := mock.MatchedBy(func(f tasks.OverdueFilter) bool {
+		return time.Since(f.DueBefore) >= 24*time.Hour &&
+			reflect.DeepEqual(f.Priorities, []tasks.Priority{tasks.Low}) &&
+			f.SkipWithComments && f.SkipWithLabels
+	})
 	unexpectedErr := errors.New("unexpected error")
 	cases := []struct {
 		name    string
@@ -356,18 +1511,27 @@ func TestServicePruneOverdueTasks(t *testing.T) {
 	}{
 		{
 			name: "happy path",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().
-					RemoveOverdueTasksWithDueDateBefore(mock.Anything, mock.AnythingOfType("time.Time")).
-					Return(nil)
+			service: newTestServiceWithPrunePolicy(t, policy, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().RemoveOverdueTasks(mock.Anything, matchesPolicy).Return(nil)
+			}),
+		},
+		{
+			name: "archive",
+			service: newTestServiceWithPrunePolicy(t, archivePolicy, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().ArchiveOverdueTasks(mock.Anything, matchesPolicy).Return(nil)
+			}),
+		},
+		{
+			name: "dry run",
+			service: newTestServiceWithPrunePolicy(t, dryRunPolicy, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().OverdueTasks(mock.Anything, matchesPolicy).
+					Return([]tasks.Task{{Id: tasks.NewTaskId()}}, nil)
 			}),
 		},
 		{
 			name: "unexpected error",
-			service: newTestService(t, func(repo *tasks.MockTasksRepo) {
-				repo.EXPECT().
-					RemoveOverdueTasksWithDueDateBefore(mock.Anything, mock.Anything).
-					Return(unexpectedErr)
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().RemoveOverdueTasks(mock.Anything, mock.Anything).Return(unexpectedErr)
 			}),
 			err: shared.NewUnexpectedError(unexpectedErr, ""),
 		},
@@ -386,3 +1550,148 @@ func TestServicePruneOverdueTasks(t *testing.T) {
 		})
 	}
 }
+
+func TestServicePurgeDeletedTasks(t *testing.T) {
+	attachmentId := tasks.NewAttachmentId()
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *tasks.Service
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().
+					PurgeTasksDeletedBefore(mock.Anything, mock.MatchedBy(func(date time.Time) bool {
+						return time.Since(date) >= trashRetention
+					})).
+					Return(nil, nil)
+			}),
+		},
+		{
+			name: "with attachments",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().
+					PurgeTasksDeletedBefore(mock.Anything, mock.Anything).
+					Return([]tasks.AttachmentId{attachmentId}, nil)
+				sm.blobStore.EXPECT().Remove(mock.Anything, attachmentId.String()).Return(unexpectedErr)
+			}),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().
+					PurgeTasksDeletedBefore(mock.Anything, mock.Anything).
+					Return(nil, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.PurgeDeletedTasks(t.Context()); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceAddTaskAttachment(t *testing.T) {
+	now := time.Now()
+	projectId := projects.NewProjectId()
+	task, tErr := tasks.NewTask(
+		tasks.NewTaskId(),
+		owner,
+		"title",
+		nil,
+		tasks.Pending,
+		tasks.Low,
+		now.Add(time.Hour),
+		nil,
+		nil,
+		nil,
+		nil,
+		now,
+		now,
+	)
+	if tErr != nil {
+		t.Fatal("failed to prepare task")
+	}
+	sharedTask := task
+	sharedTask.Owner = "other"
+	sharedTask.ProjectId = &projectId
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name     string
+		service  *tasks.Service
+		taskId   tasks.TaskId
+		fileName string
+		err      *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.blobStore.EXPECT().Put(mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+				attachmentMatcher := mock.MatchedBy(func(a tasks.Attachment) bool {
+					return a.Name == "file.txt" && a.Uploader == owner && a.Size == 4
+				})
+				sm.tasksRepo.EXPECT().SaveTaskAttachment(mock.Anything, task.Id, attachmentMatcher).Return(nil)
+			}),
+			taskId:   task.Id,
+			fileName: "file.txt",
+		},
+		{
+			name:     "empty name",
+			service:  newTestService(t, nil),
+			taskId:   task.Id,
+			fileName: "",
+			err:      shared.NewServiceError(tasks.ErrInvalidAttachmentName, ""),
+		},
+		{
+			name: "project viewer",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, sharedTask.Id).Return(sharedTask, nil)
+				sm.projectsRepo.EXPECT().ProjectRole(mock.Anything, owner, projectId).Return(projects.Viewer, nil)
+			}),
+			taskId:   sharedTask.Id,
+			fileName: "file.txt",
+			err:      shared.NewServiceError(shared.ErrForbidden, ""),
+		},
+		{
+			name: "failed to save attachment",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksRepo.EXPECT().TaskById(mock.Anything, owner, task.Id).Return(task, nil)
+				sm.blobStore.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				sm.tasksRepo.EXPECT().SaveTaskAttachment(mock.Anything, mock.Anything, mock.Anything).Return(unexpectedErr)
+				sm.blobStore.EXPECT().Remove(mock.Anything, mock.Anything).Return(nil)
+			}),
+			taskId:   task.Id,
+			fileName: "file.txt",
+			err:      shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			content := bytes.NewBufferString("test")
+			if _, err := c.service.AddTaskAttachment(
+				t.Context(), owner, c.taskId, c.fileName, "text/plain", int64(content.Len()), content,
+			); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
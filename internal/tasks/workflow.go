@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Workflow defines task statuses and allowed transitions between them.
+// Pending, in progress and done statuses are required since the service
+// relies on them: new occurrences of recurring tasks are pending, blockers
+// are checked on start and done tasks are completed and can't be changed.
+type Workflow struct {
+	transitions map[Status]map[Status]struct{}
+}
+
+// NewWorkflow creates a workflow from the map of statuses to the statuses
+// they can be changed to, every status should be a key of the map
+func NewWorkflow(transitions map[Status][]Status) (*Workflow, error) {
+	w := &Workflow{make(map[Status]map[Status]struct{}, len(transitions))}
+	for from, targets := range transitions {
+		if len(from) == 0 {
+			return nil, fmt.Errorf("%w: empty status", ErrInvalidWorkflow)
+		}
+		to := make(map[Status]struct{}, len(targets))
+		for _, t := range targets {
+			if _, ok := transitions[t]; !ok {
+				return nil, fmt.Errorf("%w: unknown status %q in transitions of %q", ErrInvalidWorkflow, t, from)
+			}
+			to[t] = struct{}{}
+		}
+		w.transitions[from] = to
+	}
+	for _, s := range []Status{Pending, InProgress, Done} {
+		if _, ok := w.transitions[s]; !ok {
+			return nil, fmt.Errorf("%w: status %q is required", ErrInvalidWorkflow, s)
+		}
+	}
+	if len(w.transitions[Done]) > 0 {
+		return nil, fmt.Errorf("%w: status %q can't have transitions", ErrInvalidWorkflow, Done)
+	}
+	return w, nil
+}
+
+// DefaultWorkflow allows to put a task on hold and to review it
+// before completion
+func DefaultWorkflow() *Workflow {
+	w, err := NewWorkflow(map[Status][]Status{
+		Pending:    {InProgress, Blocked, Done},
+		InProgress: {Pending, Blocked, InReview, Done},
+		Blocked:    {Pending, InProgress},
+		InReview:   {InProgress, Done},
+		Done:       {},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (w *Workflow) HasStatus(s Status) bool {
+	_, ok := w.transitions[s]
+	return ok
+}
+
+// CanTransition reports whether the task status can be changed,
+// keeping the status is always allowed
+func (w *Workflow) CanTransition(from Status, to Status) bool {
+	if from == to {
+		return w.HasStatus(from)
+	}
+	_, ok := w.transitions[from][to]
+	return ok
+}
+
+func (w *Workflow) Statuses() []Status {
+	statuses := make([]Status, 0, len(w.transitions))
+	for s := range w.transitions {
+		statuses = append(statuses, s)
+	}
+	slices.Sort(statuses)
+	return statuses
+}
@@ -0,0 +1,88 @@
+package tasks_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func TestNewWorkflow(t *testing.T) {
+	cases := []struct {
+		name        string
+		transitions map[tasks.Status][]tasks.Status
+		err         error
+	}{
+		{
+			name: "minimal",
+			transitions: map[tasks.Status][]tasks.Status{
+				tasks.Pending:    {tasks.InProgress},
+				tasks.InProgress: {tasks.Done},
+				tasks.Done:       nil,
+			},
+		},
+		{
+			name: "missing required status",
+			transitions: map[tasks.Status][]tasks.Status{
+				tasks.Pending: {tasks.Done},
+				tasks.Done:    nil,
+			},
+			err: tasks.ErrInvalidWorkflow,
+		},
+		{
+			name: "unknown target status",
+			transitions: map[tasks.Status][]tasks.Status{
+				tasks.Pending:    {tasks.Blocked},
+				tasks.InProgress: {tasks.Done},
+				tasks.Done:       nil,
+			},
+			err: tasks.ErrInvalidWorkflow,
+		},
+		{
+			name: "reopen of done task",
+			transitions: map[tasks.Status][]tasks.Status{
+				tasks.Pending:    {tasks.InProgress},
+				tasks.InProgress: {tasks.Done},
+				tasks.Done:       {tasks.InProgress},
+			},
+			err: tasks.ErrInvalidWorkflow,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tasks.NewWorkflow(tc.transitions)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	w := tasks.DefaultWorkflow()
+	expected := []tasks.Status{tasks.Blocked, tasks.Done, tasks.InProgress, tasks.InReview, tasks.Pending}
+	if statuses := w.Statuses(); !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected statuses %v, got %v", expected, statuses)
+	}
+	cases := []struct {
+		from tasks.Status
+		to   tasks.Status
+		ok   bool
+	}{
+		{tasks.Pending, tasks.Pending, true},
+		{tasks.Pending, tasks.Blocked, true},
+		{tasks.Blocked, tasks.InProgress, true},
+		{tasks.InProgress, tasks.InReview, true},
+		{tasks.InReview, tasks.Done, true},
+		{tasks.Pending, tasks.InReview, false},
+		{tasks.Blocked, tasks.Done, false},
+		{tasks.Done, tasks.InProgress, false},
+		{tasks.Status("unknown"), tasks.Status("unknown"), false},
+	}
+	for _, tc := range cases {
+		if ok := w.CanTransition(tc.from, tc.to); ok != tc.ok {
+			t.Errorf("expected transition from %q to %q to be %v", tc.from, tc.to, tc.ok)
+		}
+	}
+}
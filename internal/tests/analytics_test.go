@@ -11,6 +11,7 @@ import (
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/x0k/skillrock-tasks-service/internal/analytics"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
@@ -18,6 +19,11 @@ import (
 )
 
 func newAnalyticsServer(t *testing.T) (*httptest.Server, *analytics.Controller) {
+	server, c, _ := newAnalyticsServerWithPool(t)
+	return server, c
+}
+
+func newAnalyticsServerWithPool(t *testing.T) (*httptest.Server, *analytics.Controller, *pgxpool.Pool) {
 	var buf bytes.Buffer
 	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
@@ -48,7 +54,7 @@ func newAnalyticsServer(t *testing.T) (*httptest.Server, *analytics.Controller)
 			),
 		),
 	)
-	return httptest.NewServer(adaptor.FiberApp(app)), c
+	return httptest.NewServer(adaptor.FiberApp(app)), c, pool
 }
 
 func TestReport(t *testing.T) {
@@ -71,8 +77,45 @@ func TestReport(t *testing.T) {
 		AverageCompletionTimeInDays: "1.00",
 		AmountOfCompletedTasks:      0,
 		AmountOfOverdueTasks:        0,
+		TasksCountByStatus: map[string]int64{
+			"pending":     2,
+			"in_progress": 2,
+			"done":        1,
+		},
 	}
 	if !reflect.DeepEqual(expected, actual) {
 		t.Fatalf("expected %v, but got %v", expected, actual)
 	}
 }
+
+func TestReportAfterReopen(t *testing.T) {
+	server, c, pool := newAnalyticsServerWithPool(t)
+	defer server.Close()
+	execSql(t, pool, `
+INSERT INTO task
+  (id, title, status, priority, due_date, created_at, updated_at, owner, position)
+VALUES
+  ('66666666-6666-6666-6666-666666666666', 'Release notes', 'done', 'low', CURRENT_DATE,
+   (now() AT TIME ZONE 'UTC') - interval '1 day', now() AT TIME ZONE 'UTC', 'login', 'c');`)
+
+	service := newTasksService(newTestLogger(t), pool, t.TempDir(), tasks.DefaultPrunePolicy())
+	id, err := tasks.ParseTaskId("66666666-6666-6666-6666-666666666666")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sErr := service.ReopenTaskById(t.Context(), "login", id, nil, nil); sErr != nil {
+		t.Fatal(sErr)
+	}
+	if sErr := service.MoveTaskById(t.Context(), "login", id, nil, tasks.MoveParams{Status: tasks.Done}); sErr != nil {
+		t.Fatal(sErr)
+	}
+
+	c.GenerateReport(t.Context())
+
+	report := httpexpect.Default(t, server.URL).GET("/").Expect().Status(http.StatusOK).
+		JSON().Object()
+	report.Value("done_tasks_count").IsEqual(2)
+	// Both tasks are completed a day after the creation
+	report.Value("average_completion_time_in_days").IsEqual("1.00")
+	report.Value("amount_of_completed_tasks").IsEqual(1)
+}
@@ -29,19 +29,25 @@ func newAuthServer(t *testing.T) *httptest.Server {
 	})
 	pool := setupPgxPool(t, log.Logger)
 	app := fiber.New()
-	auth.NewController(
-		app,
+	service := auth.NewService(
 		log,
-		auth.NewService(
+		[]byte("secret"),
+		time.Hour,
+		auth.NewRepo(
 			log,
-			[]byte("secret"),
-			time.Hour,
-			auth.NewRepo(
-				log,
-				db.New(pool),
-			),
+			db.New(pool),
 		),
 	)
+	auth.NewController(
+		app,
+		log,
+		service,
+	)
+	auth.NewProfileController(
+		app.Group("/users").Use(authMiddleware()),
+		log,
+		service,
+	)
 	return httptest.NewServer(adaptor.FiberApp(app))
 }
 
@@ -129,3 +135,37 @@ func TestInvalidPassword(t *testing.T) {
 		Expect().
 		Status(http.StatusUnauthorized)
 }
+
+func TestProfileTimeZone(t *testing.T) {
+	server := newAuthServer(t)
+	defer server.Close()
+
+	httpexpect.Default(t, server.URL).POST("/register").
+		WithJSON(auth.Credentials{
+			Login:    "login",
+			Password: "password",
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/users/me").Expect().Status(http.StatusOK).
+		JSON().Object().IsEqual(auth.ProfileDTO{
+		Login:    "login",
+		TimeZone: "UTC",
+	})
+
+	e.PUT("/users/me").WithJSON(auth.UpdateProfileDTO{
+		TimeZone: "Mars/Olympus",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/users/me").WithJSON(auth.UpdateProfileDTO{
+		TimeZone: "Europe/Berlin",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/users/me").Expect().Status(http.StatusOK).
+		JSON().Object().Value("time_zone").IsEqual("Europe/Berlin")
+
+	newUserExpect(t, server.URL, "other").GET("/users/me").Expect().
+		Status(http.StatusNotFound)
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x0k/skillrock-tasks-service/internal/comments"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+func newCommentsServer(t *testing.T) (*httptest.Server, *pgxpool.Pool) {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(buf.String())
+		}
+	})
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	comments.NewController(
+		app,
+		log,
+		comments.NewService(
+			log,
+			comments.NewRepo(
+				log,
+				db.New(pool),
+			),
+			tasks.NewRepo(
+				log,
+				pool,
+				db.New(pool),
+			),
+		),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app)), pool
+}
+
+func TestTaskComments(t *testing.T) {
+	server, pool := newCommentsServer(t)
+	defer server.Close()
+
+	const taskPath = "/11111111-1111-1111-1111-111111111111/comments"
+	e := newUserExpect(t, server.URL, "login")
+	e.GET(taskPath).Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+
+	id := e.POST(taskPath).WithJSON(map[string]string{
+		"body": "first",
+	}).Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+
+	e.POST(taskPath).WithJSON(map[string]string{
+		"body": "",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT(taskPath + "/" + id).WithJSON(map[string]string{
+		"body": "edited",
+	}).Expect().Status(http.StatusNoContent)
+
+	list := e.GET(taskPath).Expect().Status(http.StatusOK).JSON().Array()
+	list.Length().IsEqual(1)
+	list.Value(0).Object().Value("body").String().IsEqual("edited")
+	list.Value(0).Object().Value("author").String().IsEqual("login")
+
+	other := newUserExpect(t, server.URL, "other")
+	other.GET(taskPath).Expect().Status(http.StatusNotFound)
+	other.POST(taskPath).WithJSON(map[string]string{
+		"body": "hello",
+	}).Expect().Status(http.StatusNotFound)
+
+	execSql(t, pool, `
+INSERT INTO project_member
+  (project_id, login, role)
+VALUES
+  ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'other', 'viewer');`)
+
+	other.GET(taskPath).Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+	other.PUT(taskPath + "/" + id).WithJSON(map[string]string{
+		"body": "hijacked",
+	}).Expect().Status(http.StatusForbidden)
+	other.DELETE(taskPath + "/" + id).Expect().Status(http.StatusForbidden)
+
+	e.DELETE(taskPath + "/" + id).Expect().Status(http.StatusNoContent)
+	e.DELETE(taskPath + "/" + id).Expect().Status(http.StatusNotFound)
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/x0k/skillrock-tasks-service/internal/labels"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+)
+
+func newLabelsServer(t *testing.T) *httptest.Server {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(buf.String())
+		}
+	})
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	labels.NewController(
+		app,
+		log,
+		labels.NewService(
+			log,
+			labels.NewRepo(
+				log,
+				db.New(pool),
+			),
+		),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app))
+}
+
+func TestLabelsCRUD(t *testing.T) {
+	server := newLabelsServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(2)
+
+	id := e.POST("/").WithJSON(map[string]string{
+		"name": "feature",
+	}).Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+
+	e.POST("/").WithJSON(map[string]string{
+		"name": "feature",
+	}).Expect().Status(http.StatusConflict)
+
+	e.PUT("/" + id).WithJSON(map[string]string{
+		"name": "enhancement",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/" + id).Expect().Status(http.StatusOK).
+		JSON().Object().Value("name").String().IsEqual("enhancement")
+
+	e.PUT("/" + id).WithJSON(map[string]string{
+		"name": "bug",
+	}).Expect().Status(http.StatusConflict)
+
+	other := newUserExpect(t, server.URL, "other")
+	other.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	other.GET("/" + id).Expect().Status(http.StatusNotFound)
+	other.POST("/").WithJSON(map[string]string{
+		"name": "bug",
+	}).Expect().Status(http.StatusCreated)
+
+	e.DELETE("/" + id).Expect().Status(http.StatusNoContent)
+	e.GET("/" + id).Expect().Status(http.StatusNotFound)
+}
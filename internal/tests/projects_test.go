@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+)
+
+func newProjectsServer(t *testing.T) *httptest.Server {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(buf.String())
+		}
+	})
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	projects.NewController(
+		app,
+		log,
+		projects.NewService(
+			log,
+			projects.NewRepo(
+				log,
+				pool,
+				db.New(pool),
+			),
+		),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app))
+}
+
+func TestProjectsCRUD(t *testing.T) {
+	server := newProjectsServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	id := e.POST("/").WithJSON(map[string]string{
+		"name": "Frontend",
+	}).Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+
+	e.GET("/" + id).Expect().Status(http.StatusOK).
+		JSON().Object().Value("name").String().IsEqual("Frontend")
+
+	e.PUT("/" + id).WithJSON(map[string]string{
+		"name": "Web",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/" + id).Expect().Status(http.StatusOK).
+		JSON().Object().Value("name").String().IsEqual("Web")
+
+	e.POST("/").WithJSON(map[string]string{}).
+		Expect().Status(http.StatusBadRequest)
+
+	other := newUserExpect(t, server.URL, "other")
+	other.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	other.GET("/" + id).Expect().Status(http.StatusNotFound)
+	other.DELETE("/" + id).Expect().Status(http.StatusNotFound)
+
+	e.DELETE("/" + id).Expect().Status(http.StatusNoContent)
+	e.GET("/" + id).Expect().Status(http.StatusNotFound)
+}
+
+func TestProjectMembers(t *testing.T) {
+	server := newProjectsServer(t)
+	defer server.Close()
+
+	const projectId = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	e := newUserExpect(t, server.URL, "login")
+	other := newUserExpect(t, server.URL, "other")
+
+	other.GET("/" + projectId).Expect().Status(http.StatusNotFound)
+
+	e.PUT("/" + projectId + "/members/other").WithJSON(map[string]string{
+		"role": "viewer",
+	}).Expect().Status(http.StatusNoContent)
+	e.GET("/" + projectId + "/members").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(2)
+
+	other.GET("/" + projectId).Expect().Status(http.StatusOK)
+	other.PUT("/" + projectId).WithJSON(map[string]string{
+		"name": "Web",
+	}).Expect().Status(http.StatusForbidden)
+
+	e.PUT("/" + projectId + "/members/other").WithJSON(map[string]string{
+		"role": "editor",
+	}).Expect().Status(http.StatusNoContent)
+	other.PUT("/" + projectId).WithJSON(map[string]string{
+		"name": "Web",
+	}).Expect().Status(http.StatusNoContent)
+	other.DELETE("/" + projectId).Expect().Status(http.StatusForbidden)
+	other.PUT("/" + projectId + "/members/other").WithJSON(map[string]string{
+		"role": "owner",
+	}).Expect().Status(http.StatusForbidden)
+
+	e.PUT("/" + projectId + "/members/login").WithJSON(map[string]string{
+		"role": "viewer",
+	}).Expect().Status(http.StatusBadRequest)
+	e.PUT("/" + projectId + "/members/unknown").WithJSON(map[string]string{
+		"role": "viewer",
+	}).Expect().Status(http.StatusBadRequest)
+	e.PUT("/" + projectId + "/members/other").WithJSON(map[string]string{
+		"role": "admin",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.DELETE("/" + projectId + "/members/other").Expect().Status(http.StatusNoContent)
+	e.DELETE("/" + projectId + "/members/other").Expect().Status(http.StatusNotFound)
+	other.GET("/" + projectId).Expect().Status(http.StatusNotFound)
+}
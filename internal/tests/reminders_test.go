@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/reminders"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []reminders.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification reminders.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func (n *recordingNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.notifications)
+}
+
+func newRemindersServer(t *testing.T, notifier reminders.Notifier) (*httptest.Server, *reminders.Controller, *pgxpool.Pool) {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(buf.String())
+		}
+	})
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	c := reminders.NewController(
+		app,
+		log,
+		reminders.NewService(
+			log,
+			reminders.NewRepo(
+				log,
+				db.New(pool),
+			),
+			tasks.NewRepo(
+				log,
+				pool,
+				db.New(pool),
+			),
+			notifier,
+		),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app)), c, pool
+}
+
+func TestTaskReminders(t *testing.T) {
+	notifier := &recordingNotifier{}
+	server, c, pool := newRemindersServer(t, notifier)
+	defer server.Close()
+
+	const taskPath = "/11111111-1111-1111-1111-111111111111/reminders"
+	e := newUserExpect(t, server.URL, "login")
+	e.POST(taskPath).WithJSON(map[string]string{
+		"before": "tomorrow",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST(taskPath).WithJSON(map[string]string{
+		"before": "-1h",
+	}).Expect().Status(http.StatusBadRequest)
+
+	id := e.POST(taskPath).WithJSON(map[string]string{
+		"before": "24h",
+	}).Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+
+	e.GET(taskPath).Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	other := newUserExpect(t, server.URL, "other")
+	other.GET(taskPath).Expect().Status(http.StatusNotFound)
+	other.POST(taskPath).WithJSON(map[string]string{
+		"before": "1h",
+	}).Expect().Status(http.StatusNotFound)
+
+	c.SendDueReminders(t.Context())
+	if n := notifier.count(); n != 1 {
+		t.Fatalf("expected one notification, got %d", n)
+	}
+	c.SendDueReminders(t.Context())
+	if n := notifier.count(); n != 1 {
+		t.Fatalf("expected the reminder to be sent once, got %d", n)
+	}
+
+	execSql(t, pool, `UPDATE task SET due_date = '2025-03-01' WHERE id = '11111111-1111-1111-1111-111111111111';`)
+	c.SendDueReminders(t.Context())
+	if n := notifier.count(); n != 2 {
+		t.Fatalf("expected the reminder to fire for the new due date, got %d", n)
+	}
+
+	e.DELETE(taskPath + "/" + id).Expect().Status(http.StatusNoContent)
+	e.DELETE(taskPath + "/" + id).Expect().Status(http.StatusNotFound)
+}
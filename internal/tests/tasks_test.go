@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -12,24 +13,99 @@ import (
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	"github.com/x0k/skillrock-tasks-service/internal/auth"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/blob"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
 	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
 	"github.com/x0k/skillrock-tasks-service/internal/tasks"
 	tasks_controller "github.com/x0k/skillrock-tasks-service/internal/tasks/controller"
 )
 
 const insertTasks = `
+INSERT INTO "user"
+  (login, password_hash)
+VALUES
+  ('login', ''),
+  ('other', '');
+
+INSERT INTO project
+  (id, name, description, owner, created_at, updated_at)
+VALUES
+  ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'Backend', NULL, 'login', '2025-02-01', '2025-02-01');
+
+INSERT INTO project_member
+  (project_id, login, role)
+VALUES
+  ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'login', 'owner');
+
 INSERT INTO task
-  (id, title, description, status, priority, due_date, created_at, updated_at)
+  (id, title, description, status, priority, due_date, created_at, updated_at, owner, project_id, position)
+VALUES
+	('11111111-1111-1111-1111-111111111111', 'Fix login bug',        'Investigate and fix login issue for users.', 'pending',     'high',   '2025-02-02', '2025-02-01', '2025-02-02', 'login', 'aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'a'),
+	('22222222-2222-2222-2222-222222222222', 'Refactor API',         NULL,                                         'in_progress', 'medium', '2025-02-03', '2025-02-02', '2025-02-03', 'login', 'aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'a'),
+  ('33333333-3333-3333-3333-333333333333', 'Write tests',          'Increase test coverage for task module.',    'pending',     'low',    '2025-02-04', '2025-02-03', '2025-02-04', 'login', NULL, 'b'),
+  ('44444444-4444-4444-4444-444444444444', 'Update documentation', 'Document new API endpoints.',                'done',        'low',    '2025-02-05', '2025-02-04', '2025-02-05', 'login', NULL, 'a'),
+  ('55555555-5555-5555-5555-555555555555', 'Deploy new release',   NULL,                                         'in_progress', 'high',   '2025-02-06', '2025-02-05', '2025-02-06', 'login', NULL, 'b');
+
+INSERT INTO label
+  (id, name, owner, created_at, updated_at)
+VALUES
+  ('bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb1', 'bug',    'login', '2025-02-01', '2025-02-01'),
+  ('bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb2', 'urgent', 'login', '2025-02-01', '2025-02-01');
+
+INSERT INTO task_label
+  (task_id, label_id)
 VALUES
-	('11111111-1111-1111-1111-111111111111', 'Fix login bug',        'Investigate and fix login issue for users.', 'pending',     'high',   '2025-02-02', '2025-02-01', '2025-02-02'),
-	('22222222-2222-2222-2222-222222222222', 'Refactor API',         NULL,                                         'in_progress', 'medium', '2025-02-03', '2025-02-02', '2025-02-03'),
-  ('33333333-3333-3333-3333-333333333333', 'Write tests',          'Increase test coverage for task module.',    'pending',     'low',    '2025-02-04', '2025-02-03', '2025-02-04'),
-  ('44444444-4444-4444-4444-444444444444', 'Update documentation', 'Document new API endpoints.',                'done',        'low',    '2025-02-05', '2025-02-04', '2025-02-05'),
-  ('55555555-5555-5555-5555-555555555555', 'Deploy new release',   NULL,                                         'in_progress', 'high',   '2025-02-06', '2025-02-05', '2025-02-06');
+  ('11111111-1111-1111-1111-111111111111', 'bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb1'),
+  ('11111111-1111-1111-1111-111111111111', 'bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb2'),
+  ('33333333-3333-3333-3333-333333333333', 'bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb1');
 `
 
 func newTasksServer(t *testing.T) (*httptest.Server, *tasks_controller.Controller) {
+	server, c, _ := newTasksServerWithPool(t)
+	return server, c
+}
+
+func newTasksServerWithPool(t *testing.T) (*httptest.Server, *tasks_controller.Controller, *pgxpool.Pool) {
+	return newTasksServerWithBlobs(t, t.TempDir())
+}
+
+func newTasksServerWithBlobs(t *testing.T, blobsPath string) (*httptest.Server, *tasks_controller.Controller, *pgxpool.Pool) {
+	log := newTestLogger(t)
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	c := tasks_controller.New(
+		app,
+		log,
+		newTasksService(log, pool, blobsPath, tasks.DefaultPrunePolicy()),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app)), c, pool
+}
+
+// newTasksAdminServer serves the admin endpoints under the `/admin`
+// prefix for the `admin` user
+func newTasksAdminServer(t *testing.T, prunePolicy tasks.PrunePolicy) (*httptest.Server, *tasks_controller.Controller) {
+	log := newTestLogger(t)
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	service := newTasksService(log, pool, t.TempDir(), prunePolicy)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	tasks_controller.NewAdmin(
+		app.Group("/admin").Use(fiber_adapter.RequireLogins([]string{"admin"})),
+		log,
+		service,
+	)
+	c := tasks_controller.New(app, log, service)
+	return httptest.NewServer(adaptor.FiberApp(app)), c
+}
+
+func newTestLogger(t *testing.T) *logger.Logger {
 	var buf bytes.Buffer
 	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
@@ -39,53 +115,637 @@ func newTasksServer(t *testing.T) (*httptest.Server, *tasks_controller.Controlle
 			t.Log(buf.String())
 		}
 	})
-	pool := setupPgxPool(t, log.Logger)
-	execSql(t, pool, insertTasks)
-	app := fiber.New()
-	c := tasks_controller.New(
-		app,
+	return log
+}
+
+func newTasksService(log *logger.Logger, pool *pgxpool.Pool, blobsPath string, prunePolicy tasks.PrunePolicy) *tasks.Service {
+	return tasks.NewService(
 		log,
-		tasks.NewService(
+		tasks.NewRepo(
+			log,
+			pool,
+			db.New(pool),
+		),
+		projects.NewRepo(
 			log,
-			tasks.NewRepo(
-				log,
-				pool,
-				db.New(pool),
-			),
+			pool,
+			db.New(pool),
 		),
+		auth.NewRepo(
+			log,
+			db.New(pool),
+		),
+		blob.NewFsStore(blobsPath),
+		tasks.DefaultWorkflow(),
+		prunePolicy,
+		0,
 	)
-	return httptest.NewServer(adaptor.FiberApp(app)), c
 }
 
 func TestFindTasks(t *testing.T) {
 	server, _ := newTasksServer(t)
 	defer server.Close()
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.GET("/").Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(5)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(5)
 
 	e.GET("/").WithQuery("status", "pending").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(2)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
 
 	e.GET("/").WithQuery("priority", "high").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(2)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
 
 	e.GET("/").WithQuery("due_before", "2025-02-04").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(2)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
 
 	e.GET("/").WithQuery("due_after", "2025-02-03").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(3)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(3)
 
 	e.GET("/").WithQuery("title", "re").
 		WithQuery("status", "in_progress").
 		WithQuery("due_after", "2025-02-02").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(2)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.GET("/").WithQuery("project_id", "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.GET("/").WithQuery("labels", "bug").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.GET("/").WithQuery("labels", "bug,urgent").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.GET("/").WithQuery("labels", "bug,urgent").
+		WithQuery("labels_match", "all").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+
+	e.GET("/").WithQuery("labels", "bug").
+		WithQuery("labels_match", "some").
+		Expect().Status(http.StatusBadRequest)
+
+	e.GET("/").WithQuery("project_id", "foo").
+		Expect().Status(http.StatusBadRequest)
+}
+
+func TestFindTasksPage(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	page := e.GET("/").WithQuery("sort", "-priority,due_date").
+		WithQuery("limit", 2).WithQuery("total", true).
+		Expect().Status(http.StatusOK).JSON().Object()
+	page.Value("total").IsEqual(5)
+	page.Value("tasks").Array().Path("$[*].id").IsEqual([]string{
+		"11111111-1111-1111-1111-111111111111",
+		"55555555-5555-5555-5555-555555555555",
+	})
+	cursor := page.Value("next_cursor").String().Raw()
+
+	page = e.GET("/").WithQuery("sort", "-priority,due_date").
+		WithQuery("limit", 2).WithQuery("cursor", cursor).
+		Expect().Status(http.StatusOK).JSON().Object()
+	page.NotContainsKey("total")
+	page.Value("tasks").Array().Path("$[*].id").IsEqual([]string{
+		"22222222-2222-2222-2222-222222222222",
+		"33333333-3333-3333-3333-333333333333",
+	})
+	cursor = page.Value("next_cursor").String().Raw()
+
+	page = e.GET("/").WithQuery("sort", "-priority,due_date").
+		WithQuery("limit", 2).WithQuery("cursor", cursor).
+		Expect().Status(http.StatusOK).JSON().Object()
+	page.Value("tasks").Array().Path("$[*].id").IsEqual([]string{
+		"44444444-4444-4444-4444-444444444444",
+	})
+	page.Value("next_cursor").IsNull()
+
+	e.GET("/").WithQuery("sort", "due_date").WithQuery("cursor", cursor).
+		Expect().Status(http.StatusBadRequest)
+
+	e.GET("/").WithQuery("cursor", "foo").Expect().Status(http.StatusBadRequest)
+
+	e.GET("/").WithQuery("sort", "description").Expect().Status(http.StatusBadRequest)
+
+	e.GET("/").WithQuery("limit", 0).Expect().Status(http.StatusBadRequest)
+}
+
+func TestSearchTasks(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	found := e.GET("/").WithQuery("q", "test coverage").
+		Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array()
+	found.Length().IsEqual(1)
+	task := found.Value(0).Object()
+	task.Value("id").IsEqual("33333333-3333-3333-3333-333333333333")
+	match := task.Value("match").Object()
+	match.Value("rank").Number().Gt(0)
+	match.Value("title").IsEqual("Write <mark>tests</mark>")
+	match.Value("description").String().Contains("<mark>test</mark> <mark>coverage</mark>")
+
+	e.GET("/").WithQuery("q", "tests OR documentation").WithQuery("total", true).
+		Expect().Status(http.StatusOK).JSON().Object().Value("total").IsEqual(2)
+
+	e.GET("/").WithQuery("q", "document").WithQuery("sort", "-rank,due_date").
+		Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().
+		Path("$[*].id").IsEqual([]string{"44444444-4444-4444-4444-444444444444"})
+
+	e.GET("/").WithQuery("sort", "-rank").Expect().Status(http.StatusBadRequest)
+
+	newUserExpect(t, server.URL, "other").GET("/").WithQuery("q", "tests").
+		Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().Length().IsEqual(0)
+}
+
+func TestFilterTasks(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	cases := []struct {
+		filter string
+		ids    []string
+	}{
+		{"priority>=medium", []string{
+			"11111111-1111-1111-1111-111111111111",
+			"22222222-2222-2222-2222-222222222222",
+			"55555555-5555-5555-5555-555555555555",
+		}},
+		{"-project:none", []string{
+			"11111111-1111-1111-1111-111111111111",
+			"22222222-2222-2222-2222-222222222222",
+		}},
+		{"status:pending OR status:done", []string{
+			"11111111-1111-1111-1111-111111111111",
+			"33333333-3333-3333-3333-333333333333",
+			"44444444-4444-4444-4444-444444444444",
+		}},
+		{"label:bug -label:urgent", []string{
+			"33333333-3333-3333-3333-333333333333",
+		}},
+		{`due<2025-02-04 -(priority:high title:"login bug")`, []string{
+			"22222222-2222-2222-2222-222222222222",
+		}},
+	}
+	for _, c := range cases {
+		e.GET("/").WithQuery("filter", c.filter).WithQuery("sort", "due_date").
+			Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().
+			Path("$[*].id").IsEqual(c.ids)
+	}
+
+	e.GET("/").WithQuery("filter", "priority>urgent").Expect().Status(http.StatusBadRequest)
+	e.GET("/").WithQuery("filter", "status:unknown").Expect().Status(http.StatusBadRequest)
+}
+
+func TestTasksIsolation(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "other")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(0)
+
+	e.GET("/export").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+
+	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-02",
+	}).Expect().Status(http.StatusNotFound)
+
+	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().
+		Status(http.StatusNotFound)
+
+	e.POST("/").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-02",
+	}).Expect().Status(http.StatusCreated)
+
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+
+	e.POST("/").WithJSON(map[string]string{
+		"title":      "foo",
+		"status":     "pending",
+		"priority":   "low",
+		"due_date":   "2025-04-02",
+		"project_id": "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+	}).Expect().Status(http.StatusBadRequest)
+
+	newUserExpect(t, server.URL, "login").GET("/").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(5)
+
+	httpexpect.Default(t, server.URL).GET("/").
+		Expect().Status(http.StatusBadRequest)
+}
+
+func TestProjectMemberTasks(t *testing.T) {
+	server, _, pool := newTasksServerWithPool(t)
+	defer server.Close()
+	execSql(t, pool, `
+INSERT INTO project_member
+  (project_id, login, role)
+VALUES
+  ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'other', 'viewer');`)
+
+	e := newUserExpect(t, server.URL, "other")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-02",
+	}).Expect().Status(http.StatusForbidden)
+
+	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().
+		Status(http.StatusForbidden)
+
+	execSql(t, pool, `
+UPDATE task SET assignee = 'other'
+WHERE id = '11111111-1111-1111-1111-111111111111';`)
+
+	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-02",
+	}).Expect().Status(http.StatusForbidden)
+
+	execSql(t, pool, `
+UPDATE project_member SET role = 'editor'
+WHERE project_id = 'aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa' AND login = 'other';`)
+
+	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-02",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().
+		Status(http.StatusNoContent)
+}
+
+func TestTaskAssignee(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithJSON(map[string]string{
+		"title":    "Write tests",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-02-04",
+		"assignee": "unknown",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithJSON(map[string]string{
+		"title":    "Write tests",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-02-04",
+		"assignee": "other",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/").WithQuery("assignee", "other").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+
+	e.GET("/").WithQuery("assignee", "me").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(0)
+
+	other := newUserExpect(t, server.URL, "other")
+	tasks := other.GET("/").WithQuery("assignee", "me").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array()
+	tasks.Length().IsEqual(1)
+	task := tasks.Value(0).Object()
+	task.Value("owner").IsEqual("login")
+	task.Value("assignee").IsEqual("other")
+
+	other.PUT("/33333333-3333-3333-3333-333333333333").WithJSON(map[string]string{
+		"title":    "Write tests",
+		"status":   "in_progress",
+		"priority": "low",
+		"due_date": "2025-02-04",
+		"assignee": "other",
+	}).Expect().Status(http.StatusNoContent)
+}
+
+func TestTaskLabels(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	const bug = "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb1"
+	e := newUserExpect(t, server.URL, "login")
+	e.POST("/").WithJSON(map[string]any{
+		"title":     "foo",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-02",
+		"label_ids": []string{bug},
+	}).Expect().Status(http.StatusCreated)
+
+	e.GET("/").WithQuery("labels", "bug").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(3)
+
+	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]any{
+		"title":     "foo",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-02",
+		"label_ids": []string{},
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/").WithQuery("labels", "urgent").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(0)
+
+	e.POST("/").WithJSON(map[string]any{
+		"title":     "foo",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-02",
+		"label_ids": []string{"bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb3"},
+	}).Expect().Status(http.StatusBadRequest)
+
+	newUserExpect(t, server.URL, "other").POST("/").WithJSON(map[string]any{
+		"title":     "foo",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-02",
+		"label_ids": []string{bug},
+	}).Expect().Status(http.StatusBadRequest)
+}
+
+func TestSubtasks(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	const parentId = "22222222-2222-2222-2222-222222222222"
+	const subtaskId = "33333333-3333-3333-3333-333333333333"
+	e := newUserExpect(t, server.URL, "login")
+	e.PUT("/" + subtaskId).WithJSON(map[string]string{
+		"title":     "Write tests",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-02-04",
+		"parent_id": parentId,
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/" + parentId + "/subtasks").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	e.GET("/").WithQuery("parent_id", parentId).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+
+	e.PUT("/" + parentId).WithJSON(map[string]string{
+		"title":     "Refactor API",
+		"status":    "in_progress",
+		"priority":  "medium",
+		"due_date":  "2025-02-03",
+		"parent_id": subtaskId,
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/" + parentId).WithJSON(map[string]string{
+		"title":    "Refactor API",
+		"status":   "done",
+		"priority": "medium",
+		"due_date": "2025-02-03",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/" + subtaskId).WithJSON(map[string]string{
+		"title":     "Write tests",
+		"status":    "done",
+		"priority":  "low",
+		"due_date":  "2025-02-04",
+		"parent_id": parentId,
+	}).Expect().Status(http.StatusNoContent)
+
+	e.PUT("/" + parentId).WithJSON(map[string]string{
+		"title":    "Refactor API",
+		"status":   "done",
+		"priority": "medium",
+		"due_date": "2025-02-03",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/11111111-1111-1111-1111-111111111112/subtasks").
+		Expect().Status(http.StatusNotFound)
+
+	newUserExpect(t, server.URL, "other").GET("/" + parentId + "/subtasks").
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestTaskBlockers(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	const taskId = "11111111-1111-1111-1111-111111111111"
+	const blockerId = "33333333-3333-3333-3333-333333333333"
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/").WithQuery("ready", "true").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(2)
+
+	e.PUT("/" + taskId + "/blocked_by/" + blockerId).
+		Expect().Status(http.StatusNoContent)
+
+	e.GET("/").WithQuery("ready", "true").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+
+	e.PUT("/" + blockerId + "/blocked_by/" + taskId).
+		Expect().Status(http.StatusConflict)
+	e.PUT("/" + taskId + "/blocked_by/" + taskId).
+		Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/" + taskId).WithJSON(map[string]string{
+		"title":    "Fix login bug",
+		"status":   "in_progress",
+		"priority": "high",
+		"due_date": "2025-02-02",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.DELETE("/" + taskId + "/blocked_by/" + blockerId).
+		Expect().Status(http.StatusNoContent)
+	e.DELETE("/" + taskId + "/blocked_by/" + blockerId).
+		Expect().Status(http.StatusNotFound)
+
+	e.PUT("/" + taskId).WithJSON(map[string]string{
+		"title":    "Fix login bug",
+		"status":   "in_progress",
+		"priority": "high",
+		"due_date": "2025-02-02",
+	}).Expect().Status(http.StatusNoContent)
+
+	newUserExpect(t, server.URL, "other").PUT("/" + taskId + "/blocked_by/" + blockerId).
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestTaskAttachments(t *testing.T) {
+	blobsPath := t.TempDir()
+	server, _, pool := newTasksServerWithBlobs(t, blobsPath)
+	defer server.Close()
+
+	const taskId = "33333333-3333-3333-3333-333333333333"
+	e := newUserExpect(t, server.URL, "login")
+	attachment := e.POST("/"+taskId+"/attachments").
+		WithMultipart().
+		WithFileBytes("file", "notes.txt", []byte("hello")).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+	attachment.Value("name").String().IsEqual("notes.txt")
+	attachment.Value("size").Number().IsEqual(5)
+	id := attachment.Value("id").String().Raw()
+
+	e.GET("/").WithQuery("title", "Write tests").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Value(0).Object().
+		Value("attachments").Array().Length().IsEqual(1)
+
+	e.GET("/" + taskId + "/attachments/" + id).
+		Expect().Status(http.StatusOK).
+		Body().IsEqual("hello")
+
+	e.POST("/" + taskId + "/attachments").
+		Expect().Status(http.StatusBadRequest)
+
+	newUserExpect(t, server.URL, "other").
+		GET("/" + taskId + "/attachments/" + id).
+		Expect().Status(http.StatusNotFound)
+
+	e.DELETE("/" + taskId + "/attachments/" + id).
+		Expect().Status(http.StatusNoContent)
+	e.GET("/" + taskId + "/attachments/" + id).
+		Expect().Status(http.StatusNotFound)
+
+	e.POST("/"+taskId+"/attachments").
+		WithMultipart().
+		WithFileBytes("file", "notes.txt", []byte("hello")).
+		Expect().Status(http.StatusCreated)
+	execSql(t, pool, `UPDATE task SET parent_id = '33333333-3333-3333-3333-333333333333' WHERE id = '55555555-5555-5555-5555-555555555555';`)
+	e.POST("/55555555-5555-5555-5555-555555555555/attachments").
+		WithMultipart().
+		WithFileBytes("file", "release.txt", []byte("release")).
+		Expect().Status(http.StatusCreated)
+
+	e.DELETE("/" + taskId).Expect().Status(http.StatusNoContent)
+	entries, err := os.ReadDir(blobsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected blobs to be removed, got %d", len(entries))
+	}
+}
+
+func TestTaskHistory(t *testing.T) {
+	server, _, pool := newTasksServerWithPool(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/33333333-3333-3333-3333-333333333333/history").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithJSON(map[string]string{
+		"title":    "Write more tests",
+		"status":   "in_progress",
+		"priority": "low",
+		"due_date": "2025-02-04",
+	}).Expect().Status(http.StatusNoContent)
+
+	events := e.GET("/33333333-3333-3333-3333-333333333333/history").Expect().Status(http.StatusOK).
+		JSON().Array()
+	events.Length().IsEqual(1)
+	event := events.Value(0).Object()
+	event.Value("actor").IsEqual("login")
+	event.Value("kind").IsEqual("updated")
+	event.Value("changes").Array().IsEqual([]map[string]any{
+		{"field": "title", "before": "Write tests", "after": "Write more tests"},
+		{"field": "status", "before": "pending", "after": "in_progress"},
+	})
+
+	newUserExpect(t, server.URL, "other").GET("/33333333-3333-3333-3333-333333333333/history").
+		Expect().Status(http.StatusNotFound)
+
+	e.DELETE("/33333333-3333-3333-3333-333333333333").Expect().Status(http.StatusNoContent)
+
+	var kind string
+	if err := pool.QueryRow(t.Context(), `
+SELECT kind FROM task_event
+WHERE task_id = '33333333-3333-3333-3333-333333333333'
+ORDER BY created_at DESC LIMIT 1;`).Scan(&kind); err != nil {
+		t.Fatal(err)
+	}
+	if kind != "deleted" {
+		t.Fatalf("expected deleted event, but got %q", kind)
+	}
+
+	if _, err := pool.Exec(t.Context(), "DELETE FROM task_event;"); err == nil {
+		t.Fatal("expected task history to be append-only")
+	}
+}
+
+func TestRecurringTask(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.POST("/").WithJSON(map[string]string{
+		"title":      "Weekly chores",
+		"status":     "pending",
+		"priority":   "low",
+		"due_date":   "2025-04-02",
+		"recurrence": "FREQ=YEARLY",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST("/").WithJSON(map[string]string{
+		"title":      "Weekly chores",
+		"status":     "pending",
+		"priority":   "low",
+		"due_date":   "2025-04-02",
+		"recurrence": "FREQ=WEEKLY;BYDAY=MO,WE",
+	}).Expect().Status(http.StatusCreated)
+
+	task := e.GET("/").WithQuery("title", "Weekly chores").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Value(0).Object()
+	task.Value("recurrence").String().IsEqual("FREQ=WEEKLY;BYDAY=MO,WE")
+	id := task.Value("id").String().Raw()
+
+	e.PUT("/" + id).WithJSON(map[string]string{
+		"title":      "Weekly chores",
+		"status":     "done",
+		"priority":   "low",
+		"due_date":   "2025-04-02",
+		"recurrence": "FREQ=WEEKLY;BYDAY=MO,WE",
+	}).Expect().Status(http.StatusNoContent)
+
+	next := e.GET("/").WithQuery("title", "Weekly chores").
+		WithQuery("status", "pending").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array()
+	next.Length().IsEqual(1)
+	next.Value(0).Object().Value("due_date").String().IsEqual("2025-04-07")
+	next.Value(0).Object().Value("recurrence").String().IsEqual("FREQ=WEEKLY;BYDAY=MO,WE")
 }
 
 func TestCreateTask(t *testing.T) {
@@ -95,7 +755,7 @@ func TestCreateTask(t *testing.T) {
 	now := time.Now()
 	dueDate := now.Add(24 * time.Hour).Format(time.DateOnly)
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.POST("/").WithJSON(map[string]string{
 		"title":    "foo",
 		"status":   "pending",
@@ -107,7 +767,56 @@ func TestCreateTask(t *testing.T) {
 		WithQuery("status", "pending").
 		WithQuery("priority", "low").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(1)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
+}
+
+func TestTaskDueTime(t *testing.T) {
+	server, _, pool := newTasksServerWithPool(t)
+	defer server.Close()
+	execSql(t, pool, `UPDATE "user" SET time_zone = 'Asia/Tokyo' WHERE login = 'login';`)
+
+	e := newUserExpect(t, server.URL, "login")
+	e.POST("/").WithJSON(map[string]string{
+		"title":     "berlin",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-04",
+		"due_time":  "17:00",
+		"time_zone": "Europe/Berlin",
+	}).Expect().Status(http.StatusCreated)
+	berlin := e.GET("/").WithQuery("title", "berlin").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Value(0).Object()
+	berlin.Value("due_date").IsEqual("2025-04-04")
+	berlin.Value("due_time").IsEqual("17:00")
+	berlin.Value("time_zone").IsEqual("Europe/Berlin")
+
+	e.POST("/").WithJSON(map[string]string{
+		"title":    "tokyo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-04",
+	}).Expect().Status(http.StatusCreated)
+	tokyo := e.GET("/").WithQuery("title", "tokyo").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Value(0).Object()
+	tokyo.NotContainsKey("due_time")
+	tokyo.Value("time_zone").IsEqual("Asia/Tokyo")
+
+	e.POST("/").WithJSON(map[string]string{
+		"title":     "mars",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-04-04",
+		"time_zone": "Mars/Olympus",
+	}).Expect().Status(http.StatusBadRequest)
+	e.POST("/").WithJSON(map[string]string{
+		"title":    "late",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-04-04",
+		"due_time": "25:00",
+	}).Expect().Status(http.StatusBadRequest)
 }
 
 func TestUpdateTask(t *testing.T) {
@@ -116,7 +825,7 @@ func TestUpdateTask(t *testing.T) {
 
 	dueDate := time.Date(2025, 04, 02, 0, 0, 0, 0, time.Local).Format(time.DateOnly)
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.PUT("/11111111-1111-1111-1111-111111111111").WithJSON(map[string]string{
 		"title":    "foo",
 		"status":   "pending",
@@ -128,7 +837,7 @@ func TestUpdateTask(t *testing.T) {
 		WithQuery("status", "pending").
 		WithQuery("priority", "low").
 		Expect().Status(http.StatusOK).
-		JSON().Array().Length().IsEqual(1)
+		JSON().Object().Value("tasks").Array().Length().IsEqual(1)
 
 	e.PUT("/11111111-1111-1111-1111-111111111112").WithJSON(map[string]string{
 		"title":    "foo",
@@ -142,26 +851,341 @@ func TestUpdateTask(t *testing.T) {
 		"status":   "pending",
 		"priority": "low",
 		"due_date": dueDate,
-	}).Expect().Status(http.StatusNotFound)
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.PUT("/44444444-4444-4444-4444-444444444444").WithJSON(map[string]string{
+		"title":    "foo",
+		"status":   "done",
+		"priority": "low",
+		"due_date": dueDate,
+	}).Expect().Status(http.StatusBadRequest).
+		Body().Contains("already been completed")
+}
+
+func TestTaskVersion(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/33333333-3333-3333-3333-333333333333").Expect().Status(http.StatusOK).
+		Header("ETag").IsEqual(`"1"`)
+
+	update := map[string]string{
+		"title":    "foo",
+		"status":   "pending",
+		"priority": "low",
+		"due_date": "2025-02-04",
+	}
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithHeader("If-Match", `"1"`).
+		WithJSON(update).Expect().Status(http.StatusNoContent)
+
+	task := e.GET("/33333333-3333-3333-3333-333333333333").Expect().Status(http.StatusOK)
+	task.Header("ETag").IsEqual(`"2"`)
+	task.JSON().Object().Value("version").IsEqual(2)
+
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithHeader("If-Match", `"1"`).
+		WithJSON(update).Expect().Status(http.StatusPreconditionFailed)
+
+	e.PUT("/33333333-3333-3333-3333-333333333333").WithHeader("If-Match", `W/"2"`).
+		WithJSON(update).Expect().Status(http.StatusPreconditionFailed)
+
+	e.DELETE("/33333333-3333-3333-3333-333333333333").WithHeader("If-Match", `"1"`).
+		Expect().Status(http.StatusPreconditionFailed)
+
+	e.DELETE("/33333333-3333-3333-3333-333333333333").WithHeader("If-Match", `"2"`).
+		Expect().Status(http.StatusNoContent)
+
+	e.GET("/33333333-3333-3333-3333-333333333333").Expect().Status(http.StatusNotFound)
+}
+
+func TestReopenTask(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.POST("/33333333-3333-3333-3333-333333333333/reopen").Expect().Status(http.StatusBadRequest)
+
+	newUserExpect(t, server.URL, "other").POST("/44444444-4444-4444-4444-444444444444/reopen").
+		Expect().Status(http.StatusNotFound)
+
+	e.POST("/44444444-4444-4444-4444-444444444444/reopen").WithHeader("If-Match", `"2"`).
+		Expect().Status(http.StatusPreconditionFailed)
+
+	e.POST("/44444444-4444-4444-4444-444444444444/reopen").
+		WithJSON(map[string]string{"reason": "closed by mistake"}).
+		Expect().Status(http.StatusNoContent)
+
+	task := e.GET("/44444444-4444-4444-4444-444444444444").Expect().Status(http.StatusOK)
+	task.Header("ETag").IsEqual(`"2"`)
+	task.JSON().Object().Value("status").IsEqual("in_progress")
+
+	events := e.GET("/44444444-4444-4444-4444-444444444444/history").Expect().Status(http.StatusOK).
+		JSON().Array()
+	events.Length().IsEqual(1)
+	event := events.Value(0).Object()
+	event.Value("actor").IsEqual("login")
+	event.Value("kind").IsEqual("reopened")
+	event.Value("reason").IsEqual("closed by mistake")
+	event.Value("changes").Array().IsEqual([]map[string]any{
+		{"field": "status", "before": "done", "after": "in_progress"},
+	})
+
+	e.POST("/44444444-4444-4444-4444-444444444444/reopen").Expect().Status(http.StatusBadRequest)
+}
+
+func TestMoveTask(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	column := func(status string) *httpexpect.Array {
+		return e.GET("/").WithQuery("status", status).WithQuery("sort", "position").
+			Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().Path("$[*].id").Array()
+	}
+
+	e.POST("/33333333-3333-3333-3333-333333333333/move").WithJSON(map[string]string{
+		"status": "pending",
+		"before": "11111111-1111-1111-1111-111111111111",
+	}).Expect().Status(http.StatusNoContent)
+	column("pending").IsEqual([]string{
+		"33333333-3333-3333-3333-333333333333",
+		"11111111-1111-1111-1111-111111111111",
+	})
+
+	e.POST("/11111111-1111-1111-1111-111111111111/move").WithJSON(map[string]string{
+		"status": "in_progress",
+		"after":  "22222222-2222-2222-2222-222222222222",
+	}).Expect().Status(http.StatusNoContent)
+	column("in_progress").IsEqual([]string{
+		"22222222-2222-2222-2222-222222222222",
+		"11111111-1111-1111-1111-111111111111",
+		"55555555-5555-5555-5555-555555555555",
+	})
+
+	e.POST("/22222222-2222-2222-2222-222222222222/move").WithJSON(map[string]string{
+		"status": "in_progress",
+	}).Expect().Status(http.StatusNoContent)
+	column("in_progress").IsEqual([]string{
+		"11111111-1111-1111-1111-111111111111",
+		"55555555-5555-5555-5555-555555555555",
+		"22222222-2222-2222-2222-222222222222",
+	})
+	e.GET("/22222222-2222-2222-2222-222222222222").Expect().Status(http.StatusOK).
+		JSON().Object().Value("updated_at").IsEqual("2025-02-03T00:00:00Z")
+
+	events := e.GET("/11111111-1111-1111-1111-111111111111/history").Expect().Status(http.StatusOK).
+		JSON().Array()
+	events.Length().IsEqual(1)
+	events.Value(0).Object().Value("changes").Array().IsEqual([]map[string]any{
+		{"field": "status", "before": "pending", "after": "in_progress"},
+	})
+
+	e.POST("/55555555-5555-5555-5555-555555555555/move").WithJSON(map[string]string{
+		"status": "in_progress",
+		"after":  "33333333-3333-3333-3333-333333333333",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST("/55555555-5555-5555-5555-555555555555/move").WithJSON(map[string]string{
+		"status": "in_progress",
+		"after":  "22222222-2222-2222-2222-222222222222",
+		"before": "11111111-1111-1111-1111-111111111111",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST("/44444444-4444-4444-4444-444444444444/move").WithJSON(map[string]string{
+		"status": "pending",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST("/55555555-5555-5555-5555-555555555555/move").WithHeader("If-Match", `"2"`).
+		WithJSON(map[string]string{"status": "in_progress"}).
+		Expect().Status(http.StatusPreconditionFailed)
+
+	newUserExpect(t, server.URL, "other").POST("/55555555-5555-5555-5555-555555555555/move").
+		WithJSON(map[string]string{"status": "in_progress"}).
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestMoveBetweenUpdatedTasks(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	column := func(status string) *httpexpect.Array {
+		return e.GET("/").WithQuery("status", status).WithQuery("sort", "position").
+			Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().Path("$[*].id").Array()
+	}
+	complete := func(id string, title string) {
+		e.PUT("/" + id).WithJSON(map[string]string{
+			"title":    title,
+			"status":   "done",
+			"priority": "low",
+			"due_date": "2025-02-04",
+		}).Expect().Status(http.StatusNoContent)
+	}
+
+	complete("33333333-3333-3333-3333-333333333333", "Write tests")
+	complete("11111111-1111-1111-1111-111111111111", "Fix login bug")
+	column("done").IsEqual([]string{
+		"44444444-4444-4444-4444-444444444444",
+		"33333333-3333-3333-3333-333333333333",
+		"11111111-1111-1111-1111-111111111111",
+	})
+
+	e.POST("/44444444-4444-4444-4444-444444444444/move").WithJSON(map[string]string{
+		"status": "done",
+		"after":  "33333333-3333-3333-3333-333333333333",
+		"before": "11111111-1111-1111-1111-111111111111",
+	}).Expect().Status(http.StatusNoContent)
+	column("done").IsEqual([]string{
+		"33333333-3333-3333-3333-333333333333",
+		"44444444-4444-4444-4444-444444444444",
+		"11111111-1111-1111-1111-111111111111",
+	})
+
+	e.POST("/33333333-3333-3333-3333-333333333333/reopen").
+		Expect().Status(http.StatusNoContent)
+	column("in_progress").IsEqual([]string{
+		"22222222-2222-2222-2222-222222222222",
+		"55555555-5555-5555-5555-555555555555",
+		"33333333-3333-3333-3333-333333333333",
+	})
+}
+
+func TestPatchTask(t *testing.T) {
+	server, _ := newTasksServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	patch := func(body string) *httpexpect.Request {
+		return e.PATCH("/33333333-3333-3333-3333-333333333333").
+			WithHeader("Content-Type", "application/merge-patch+json").
+			WithBytes([]byte(body))
+	}
+	patch(`{"status": "in_progress", "description": null}`).Expect().Status(http.StatusNoContent)
+
+	task := e.GET("/33333333-3333-3333-3333-333333333333").Expect().Status(http.StatusOK).
+		JSON().Object()
+	task.Value("title").IsEqual("Write tests")
+	task.Value("status").IsEqual("in_progress")
+	task.Value("priority").IsEqual("low")
+	task.NotContainsKey("description")
+	task.Value("labels").Array().Length().IsEqual(1)
+
+	patch(`{"priority": "urgent"}`).Expect().Status(http.StatusBadRequest)
+
+	patch(`{"title": null}`).Expect().Status(http.StatusBadRequest)
+
+	patch(`{"priority": "high"}`).WithHeader("If-Match", `"1"`).
+		Expect().Status(http.StatusPreconditionFailed)
+
+	patch(`{"priority": "high"}`).WithHeader("If-Match", `"2"`).
+		Expect().Status(http.StatusNoContent)
+
+	e.PATCH("/33333333-3333-3333-3333-333333333333").WithHeader("Content-Type", "text/plain").
+		WithBytes([]byte(`{"priority": "low"}`)).Expect().Status(http.StatusUnsupportedMediaType)
+
+	newUserExpect(t, server.URL, "other").PATCH("/33333333-3333-3333-3333-333333333333").
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"priority": "low"}`)).Expect().Status(http.StatusNotFound)
+	e.PATCH("/44444444-4444-4444-4444-444444444444").
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"title": "foo"}`)).Expect().Status(http.StatusBadRequest)
 }
 
 func TestDeleteTask(t *testing.T) {
 	server, _ := newTasksServer(t)
 	defer server.Close()
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().
 		Status(http.StatusNoContent)
 
 	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().
 		Status(http.StatusNotFound)
+
+	e.GET("/11111111-1111-1111-1111-111111111111").Expect().
+		Status(http.StatusNotFound)
+}
+
+func TestTrash(t *testing.T) {
+	server, c := newTasksServer(t)
+	defer server.Close()
+
+	const parentId = "22222222-2222-2222-2222-222222222222"
+	const subtaskId = "33333333-3333-3333-3333-333333333333"
+	e := newUserExpect(t, server.URL, "login")
+	e.PUT("/" + subtaskId).WithJSON(map[string]string{
+		"title":     "Write tests",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-02-04",
+		"parent_id": parentId,
+	}).Expect().Status(http.StatusNoContent)
+
+	e.DELETE("/" + parentId).Expect().Status(http.StatusNoContent)
+
+	trash := e.GET("/trash").Expect().Status(http.StatusOK).JSON().Array()
+	trash.Length().IsEqual(2)
+	trash.Value(0).Object().ContainsKey("deleted_at")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(3)
+
+	newUserExpect(t, server.URL, "other").GET("/trash").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	newUserExpect(t, server.URL, "other").POST("/" + parentId + "/restore").
+		Expect().Status(http.StatusNotFound)
+
+	e.POST("/" + subtaskId + "/restore").Expect().Status(http.StatusBadRequest)
+
+	e.POST("/" + parentId + "/restore").Expect().Status(http.StatusNoContent)
+	e.GET("/" + parentId + "/subtasks").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+	e.GET("/trash").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	e.POST("/" + parentId + "/restore").Expect().Status(http.StatusNotFound)
+
+	events := e.GET("/" + parentId + "/history").Expect().Status(http.StatusOK).JSON().Array()
+	events.Length().IsEqual(2)
+	events.Value(0).Object().Value("kind").IsEqual("deleted")
+	events.Value(1).Object().Value("kind").IsEqual("restored")
+
+	e.DELETE("/" + subtaskId).Expect().Status(http.StatusNoContent)
+	c.PurgeDeletedTasks(t.Context())
+	e.GET("/trash").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	e.POST("/" + subtaskId + "/restore").Expect().Status(http.StatusNotFound)
+	e.GET("/" + parentId + "/subtasks").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+}
+
+func TestPurgeKeepsArchivedSubtasks(t *testing.T) {
+	server, c, pool := newTasksServerWithPool(t)
+	defer server.Close()
+
+	const parentId = "22222222-2222-2222-2222-222222222222"
+	const subtaskId = "33333333-3333-3333-3333-333333333333"
+	e := newUserExpect(t, server.URL, "login")
+	e.PUT("/" + subtaskId).WithJSON(map[string]string{
+		"title":     "Write tests",
+		"status":    "pending",
+		"priority":  "low",
+		"due_date":  "2025-02-04",
+		"parent_id": parentId,
+	}).Expect().Status(http.StatusNoContent)
+	execSql(t, pool, `UPDATE task SET archived_at = now() WHERE id = '`+subtaskId+`'`)
+
+	e.DELETE("/" + parentId).Expect().Status(http.StatusNoContent)
+	c.PurgeDeletedTasks(t.Context())
+
+	e.POST("/" + parentId + "/restore").Expect().Status(http.StatusNotFound)
+	e.GET("/archive").Expect().Status(http.StatusOK).JSON().
+		Object().Path("$.tasks[*].id").Array().ContainsOnly(subtaskId)
 }
 
 func TestExportTasks(t *testing.T) {
 	server, _ := newTasksServer(t)
 	defer server.Close()
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.GET("/export").Expect().
 		Status(http.StatusOK).
 		JSON().Array().Length().IsEqual(5)
@@ -187,12 +1211,12 @@ func TestImportTasks(t *testing.T) {
 		}
 	}
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.POST("/import").WithJSON(dto).
 		Expect().Status(http.StatusCreated)
 
-	e.GET("/").Expect().JSON().
-		Array().Length().IsEqual(105)
+	e.GET("/").WithQuery("total", true).Expect().JSON().
+		Object().Value("total").IsEqual(105)
 
 	e.POST("/import").WithJSON(dto).
 		Expect().Status(http.StatusConflict)
@@ -204,9 +1228,11 @@ func TestPruneOverdueTasks(t *testing.T) {
 
 	c.PruneOverdueTasks(t.Context())
 
-	e := httpexpect.Default(t, server.URL)
+	e := newUserExpect(t, server.URL, "login")
 	e.GET("/").Expect().JSON().
-		Array().Length().IsEqual(1)
+		Object().Value("tasks").Array().Length().IsEqual(1)
+	e.GET("/trash").Expect().JSON().
+		Array().Length().IsEqual(4)
 
 	dto := make([]tasks_controller.TaskDTO, 10)
 	now := time.Now()
@@ -230,5 +1256,100 @@ func TestPruneOverdueTasks(t *testing.T) {
 	c.PruneOverdueTasks(t.Context())
 
 	e.GET("/").Expect().JSON().
-		Array().Length().IsEqual(9)
+		Object().Value("tasks").Array().Length().IsEqual(9)
+}
+
+func TestPruneCandidates(t *testing.T) {
+	policy, err := tasks.NewPrunePolicy(tasks.PruneToTrash, 7*24*time.Hour, []tasks.Priority{tasks.High}, false, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, c := newTasksAdminServer(t, policy)
+	defer server.Close()
+
+	newUserExpect(t, server.URL, "admin").GET("/admin/prune").Expect().Status(http.StatusOK).
+		JSON().Array().Path("$[*].id").Array().IsEqual([]string{
+		"55555555-5555-5555-5555-555555555555",
+	})
+	newUserExpect(t, server.URL, "login").GET("/admin/prune").Expect().
+		Status(http.StatusForbidden)
+
+	c.PruneOverdueTasks(t.Context())
+
+	newUserExpect(t, server.URL, "login").GET("/").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().Length().IsEqual(5)
+}
+
+func TestArchiveOverdueTasks(t *testing.T) {
+	policy := tasks.DefaultPrunePolicy()
+	policy.Mode = tasks.PruneToArchive
+	server, c := newTasksAdminServer(t, policy)
+	defer server.Close()
+
+	c.PruneOverdueTasks(t.Context())
+	c.PurgeDeletedTasks(t.Context())
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/").Expect().JSON().
+		Object().Value("tasks").Array().Length().IsEqual(1)
+	e.GET("/trash").Expect().JSON().
+		Array().Length().IsEqual(0)
+	archive := e.GET("/archive").WithQuery("total", true).
+		Expect().Status(http.StatusOK).JSON().Object()
+	archive.Value("total").IsEqual(4)
+	archive.Value("tasks").Array().Value(0).Object().ContainsKey("archived_at")
+	e.GET("/archive").WithQuery("priority", "high").
+		Expect().Status(http.StatusOK).JSON().
+		Object().Path("$.tasks[*].id").Array().ContainsOnly(
+		"11111111-1111-1111-1111-111111111111",
+		"55555555-5555-5555-5555-555555555555",
+	)
+	e.GET("/archive").WithQuery("labels", "bug").
+		Expect().Status(http.StatusOK).JSON().
+		Object().Path("$.tasks[*].id").Array().ContainsOnly(
+		"11111111-1111-1111-1111-111111111111",
+		"33333333-3333-3333-3333-333333333333",
+	)
+	newUserExpect(t, server.URL, "other").GET("/archive").
+		Expect().Status(http.StatusOK).JSON().
+		Object().Value("tasks").Array().Length().IsEqual(0)
+
+	e.GET("/11111111-1111-1111-1111-111111111111").Expect().Status(http.StatusNotFound)
+	e.DELETE("/11111111-1111-1111-1111-111111111111").Expect().Status(http.StatusNotFound)
+}
+
+func TestPruneOverdueTaskAttachments(t *testing.T) {
+	blobsPath := t.TempDir()
+	server, c, _ := newTasksServerWithBlobs(t, blobsPath)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.POST("/11111111-1111-1111-1111-111111111111/attachments").
+		WithMultipart().
+		WithFileBytes("file", "log.txt", []byte("log")).
+		Expect().Status(http.StatusCreated)
+	e.POST("/44444444-4444-4444-4444-444444444444/attachments").
+		WithMultipart().
+		WithFileBytes("file", "docs.txt", []byte("docs")).
+		Expect().Status(http.StatusCreated)
+
+	c.PruneOverdueTasks(t.Context())
+
+	entries, err := os.ReadDir(blobsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected blobs to be kept in the trash, got %d", len(entries))
+	}
+
+	c.PurgeDeletedTasks(t.Context())
+
+	entries, err = os.ReadDir(blobsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one blob to be kept, got %d", len(entries))
+	}
 }
@@ -4,7 +4,12 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gavv/httpexpect/v2"
+	jwtware "github.com/gofiber/contrib/jwt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"github.com/testcontainers/testcontainers-go"
@@ -89,3 +94,24 @@ func setupRedisClient(t testing.TB, log *slog.Logger) *redis.Client {
 	})
 	return client
 }
+
+const authSecret = "secret"
+
+func authMiddleware() fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		SigningKey: jwtware.SigningKey{Key: []byte(authSecret)},
+	})
+}
+
+func newUserExpect(t *testing.T, url string, login string) *httpexpect.Expect {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": login,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(authSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httpexpect.Default(t, url).Builder(func(r *httpexpect.Request) {
+		r.WithHeader("Authorization", "Bearer "+token)
+	})
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/x0k/skillrock-tasks-service/internal/auth"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/blob"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+	"github.com/x0k/skillrock-tasks-service/internal/views"
+)
+
+func newViewsServer(t *testing.T) *httptest.Server {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(buf.String())
+		}
+	})
+	pool := setupPgxPool(t, log.Logger)
+	execSql(t, pool, insertTasks)
+	app := fiber.New()
+	app.Use(authMiddleware())
+	views.NewController(
+		app,
+		log,
+		views.NewService(
+			log,
+			views.NewRepo(
+				log,
+				db.New(pool),
+			),
+			tasks.NewService(
+				log,
+				tasks.NewRepo(
+					log,
+					pool,
+					db.New(pool),
+				),
+				projects.NewRepo(
+					log,
+					pool,
+					db.New(pool),
+				),
+				auth.NewRepo(
+					log,
+					db.New(pool),
+				),
+				blob.NewFsStore(t.TempDir()),
+				tasks.DefaultWorkflow(),
+				tasks.DefaultPrunePolicy(),
+				0,
+			),
+		),
+	)
+	return httptest.NewServer(adaptor.FiberApp(app))
+}
+
+func TestViewsCRUD(t *testing.T) {
+	server := newViewsServer(t)
+	defer server.Close()
+
+	e := newUserExpect(t, server.URL, "login")
+	e.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+
+	view := e.POST("/").WithJSON(map[string]any{
+		"name": "morning",
+		"filter": map[string]any{
+			"project_id": "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			"filter":     "priority>=medium -label:urgent",
+		},
+		"sort": "-priority,due_date",
+	}).Expect().Status(http.StatusCreated).JSON().Object()
+	id := view.Value("id").String().Raw()
+	view.Value("filter").Object().Value("filter").IsEqual("priority>=medium -label:urgent")
+
+	e.POST("/").WithJSON(map[string]any{
+		"name": "morning",
+	}).Expect().Status(http.StatusConflict)
+
+	e.POST("/").WithJSON(map[string]any{
+		"name": "by rank",
+		"sort": "-rank",
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.POST("/").WithJSON(map[string]any{
+		"name":   "broken",
+		"filter": map[string]any{"filter": "priority>urgent"},
+	}).Expect().Status(http.StatusBadRequest)
+
+	e.GET("/" + id + "/tasks").Expect().Status(http.StatusOK).
+		JSON().Object().Value("tasks").Array().
+		Path("$[*].id").IsEqual([]string{"22222222-2222-2222-2222-222222222222"})
+
+	e.PUT("/" + id).WithJSON(map[string]any{
+		"name":   "pending",
+		"filter": map[string]any{"status": "pending"},
+		"sort":   "-due_date",
+	}).Expect().Status(http.StatusNoContent)
+
+	e.GET("/" + id).Expect().Status(http.StatusOK).
+		JSON().Object().Value("sort").IsEqual("-due_date")
+
+	page := e.GET("/"+id+"/tasks").WithQuery("limit", 1).WithQuery("total", true).
+		Expect().Status(http.StatusOK).JSON().Object()
+	page.Value("total").IsEqual(2)
+	page.Value("tasks").Array().Path("$[*].id").
+		IsEqual([]string{"33333333-3333-3333-3333-333333333333"})
+	e.GET("/"+id+"/tasks").WithQuery("cursor", page.Value("next_cursor").String().Raw()).
+		Expect().Status(http.StatusOK).JSON().Object().Value("tasks").Array().
+		Path("$[*].id").IsEqual([]string{"11111111-1111-1111-1111-111111111111"})
+
+	other := newUserExpect(t, server.URL, "other")
+	other.GET("/").Expect().Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+	other.GET("/" + id).Expect().Status(http.StatusNotFound)
+	other.GET("/" + id + "/tasks").Expect().Status(http.StatusNotFound)
+	other.DELETE("/" + id).Expect().Status(http.StatusNotFound)
+
+	e.DELETE("/" + id).Expect().Status(http.StatusNoContent)
+	e.GET("/" + id).Expect().Status(http.StatusNotFound)
+}
@@ -0,0 +1,334 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	fiber_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/fiber"
+	logger_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/logger"
+	validator_adapter "github.com/x0k/skillrock-tasks-service/internal/adapters/validator"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger/sl"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+	tasks_controller "github.com/x0k/skillrock-tasks-service/internal/tasks/controller"
+)
+
+type ViewsService interface {
+	CreateView(ctx context.Context, owner string, params ViewParams) (View, *shared.ServiceError)
+	Views(ctx context.Context, owner string) ([]View, *shared.ServiceError)
+	ViewById(ctx context.Context, owner string, id ViewId) (View, *shared.ServiceError)
+	UpdateViewById(ctx context.Context, owner string, id ViewId, params ViewParams) *shared.ServiceError
+	RemoveViewById(ctx context.Context, owner string, id ViewId) *shared.ServiceError
+	ViewTasks(ctx context.Context, owner string, id ViewId, page tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)
+}
+
+type Controller struct {
+	log          *logger.Logger
+	viewsService ViewsService
+}
+
+func NewController(
+	router fiber.Router,
+	log *logger.Logger,
+	viewsService ViewsService,
+) *Controller {
+	c := &Controller{log, viewsService}
+	router.Get("/", c.views)
+	router.Post("/", c.createView)
+	router.Get("/:id", c.viewById)
+	router.Put("/:id", c.updateViewById)
+	router.Delete("/:id", c.removeViewById)
+	router.Get("/:id/tasks", c.viewTasks)
+	return c
+}
+
+// ViewFilterDTO contains the query parameters of the tasks search
+type ViewFilterDTO struct {
+	Title       string   `json:"title,omitempty"`
+	Query       string   `json:"q,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	DueBefore   string   `json:"due_before,omitempty"`
+	DueAfter    string   `json:"due_after,omitempty"`
+	ProjectId   string   `json:"project_id,omitempty"`
+	ParentId    string   `json:"parent_id,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	LabelsMatch string   `json:"labels_match,omitempty"`
+	Ready       bool     `json:"ready,omitempty"`
+	Filter      string   `json:"filter,omitempty"`
+}
+
+type CreateViewDTO struct {
+	Name   string        `json:"name" validate:"required"`
+	Filter ViewFilterDTO `json:"filter"`
+	Sort   string        `json:"sort"`
+}
+
+type ViewDTO struct {
+	Id        string        `json:"id"`
+	Name      string        `json:"name"`
+	Filter    ViewFilterDTO `json:"filter"`
+	Sort      string        `json:"sort"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+}
+
+func viewFilterToDTO(f tasks.TasksFilter) ViewFilterDTO {
+	dto := ViewFilterDTO{
+		Labels:      f.Labels,
+		LabelsMatch: f.LabelsMatch.String(),
+		Ready:       f.ReadyToStart,
+	}
+	if f.Title != nil {
+		dto.Title = *f.Title
+	}
+	if f.Query != nil {
+		dto.Query = *f.Query
+	}
+	if f.Status != nil {
+		dto.Status = f.Status.String()
+	}
+	if f.Priority != nil {
+		dto.Priority = f.Priority.String()
+	}
+	if f.DueBefore != nil {
+		dto.DueBefore = f.DueBefore.Format(time.DateOnly)
+	}
+	if f.DueAfter != nil {
+		dto.DueAfter = f.DueAfter.Format(time.DateOnly)
+	}
+	if f.ProjectId != nil {
+		dto.ProjectId = f.ProjectId.String()
+	}
+	if f.ParentId != nil {
+		dto.ParentId = f.ParentId.String()
+	}
+	if f.Assignee != nil {
+		dto.Assignee = *f.Assignee
+	}
+	if f.Expr != nil {
+		dto.Filter = tasks.FormatFilterExpr(f.Expr)
+	}
+	return dto
+}
+
+func viewToDTO(v View) ViewDTO {
+	return ViewDTO{
+		Id:        v.Id.String(),
+		Name:      v.Name,
+		Filter:    viewFilterToDTO(v.Filter),
+		Sort:      v.Sort.String(),
+		CreatedAt: v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: v.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (vc *Controller) views(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	views, sErr := vc.viewsService.Views(c.Context(), login)
+	if sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		return fiber_adapter.ServiceError(sErr)
+	}
+	viewsDto := make([]ViewDTO, len(views))
+	for i, v := range views {
+		viewsDto[i] = viewToDTO(v)
+	}
+	return c.JSON(viewsDto)
+}
+
+func (vc *Controller) createView(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	params, err := vc.viewParams(c, login)
+	if err != nil {
+		return err
+	}
+	view, sErr := vc.viewsService.CreateView(c.Context(), login, params)
+	if sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrViewNameConflict) {
+			return fiber_adapter.SpecificServiceError(sErr, fiber.StatusConflict)
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.Status(fiber.StatusCreated).JSON(viewToDTO(view))
+}
+
+func (vc *Controller) viewById(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	viewId, err := vc.viewId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	view, sErr := vc.viewsService.ViewById(c.Context(), login, viewId)
+	if sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrViewNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(viewToDTO(view))
+}
+
+func (vc *Controller) updateViewById(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	viewId, err := vc.viewId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	params, err := vc.viewParams(c, login)
+	if err != nil {
+		return err
+	}
+	if sErr := vc.viewsService.UpdateViewById(c.Context(), login, viewId, params); sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrViewNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(sErr.Err, ErrViewNameConflict) {
+			return fiber_adapter.SpecificServiceError(sErr, fiber.StatusConflict)
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (vc *Controller) removeViewById(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	viewId, err := vc.viewId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if sErr := vc.viewsService.RemoveViewById(c.Context(), login, viewId); sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrViewNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (vc *Controller) viewTasks(c *fiber.Ctx) error {
+	login, err := vc.login(c)
+	if err != nil {
+		return err
+	}
+	viewId, err := vc.viewId(c, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	page, err := tasks_controller.ParsePageParams(c, vc.log, func(key string) string {
+		return c.Query(key)
+	})
+	if err != nil {
+		return err
+	}
+	result, sErr := vc.viewsService.ViewTasks(c.Context(), login, viewId, page)
+	if sErr != nil {
+		logger_adapter.LogServiceError(vc.log, c, sErr)
+		if errors.Is(sErr.Err, ErrViewNotFound) {
+			return fiber.ErrNotFound
+		}
+		return fiber_adapter.ServiceError(sErr)
+	}
+	return c.JSON(tasks_controller.TasksPageToDTO(result))
+}
+
+func (vc *Controller) login(c *fiber.Ctx) (string, error) {
+	login, err := fiber_adapter.UserLogin(c)
+	if err != nil {
+		vc.log.Debug(c.Context(), "failed to extract user login", sl.Err(err))
+		return login, err
+	}
+	return login, nil
+}
+
+func (vc *Controller) viewParams(c *fiber.Ctx, login string) (ViewParams, error) {
+	var dto CreateViewDTO
+	if err := c.BodyParser(&dto); err != nil {
+		vc.log.Debug(c.Context(), "failed to decode body")
+		return ViewParams{}, err
+	}
+	if err := validator_adapter.ValidateStruct(&dto); err != nil {
+		vc.log.Debug(c.Context(), "invalid create view dto struct", sl.Err(err))
+		return ViewParams{}, fiber_adapter.BadRequest(err)
+	}
+	params := ViewParams{
+		Name: dto.Name,
+	}
+	var err error
+	if params.Filter, err = vc.viewFilter(c, login, dto.Filter); err != nil {
+		return params, err
+	}
+	if dto.Sort != "" {
+		if params.Sort, err = tasks.ParseSort(dto.Sort); err != nil {
+			vc.log.Debug(c.Context(), "invalid sort value", slog.String("sort", dto.Sort))
+			return params, fiber_adapter.BadRequest(err)
+		}
+	}
+	return params, nil
+}
+
+// values returns the filter as the query parameters of the tasks search
+func (dto ViewFilterDTO) values() map[string]string {
+	values := map[string]string{
+		"title":        dto.Title,
+		"q":            dto.Query,
+		"status":       dto.Status,
+		"priority":     dto.Priority,
+		"due_before":   dto.DueBefore,
+		"due_after":    dto.DueAfter,
+		"project_id":   dto.ProjectId,
+		"parent_id":    dto.ParentId,
+		"assignee":     dto.Assignee,
+		"labels":       strings.Join(dto.Labels, ","),
+		"labels_match": dto.LabelsMatch,
+		"filter":       dto.Filter,
+	}
+	if dto.Ready {
+		values["ready"] = strconv.FormatBool(dto.Ready)
+	}
+	return values
+}
+
+// viewFilter builds the tasks filter the same way as the tasks search
+// builds it from the query parameters
+func (vc *Controller) viewFilter(c *fiber.Ctx, login string, dto ViewFilterDTO) (tasks.TasksFilter, error) {
+	values := dto.values()
+	return tasks_controller.ParseTasksFilter(c, vc.log, login, func(key string) string {
+		return values[key]
+	})
+}
+
+func (vc *Controller) viewId(c *fiber.Ctx, value string) (ViewId, error) {
+	viewId, err := ParseViewId(value)
+	if err != nil {
+		vc.log.Debug(c.Context(), "invalid view id value", slog.String("view_id", value))
+		return viewId, fiber_adapter.BadRequest(err)
+	}
+	return viewId, nil
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package views
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	shared "github.com/x0k/skillrock-tasks-service/internal/shared"
+
+	tasks "github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+// MockTasksService is an autogenerated mock type for the TasksService type
+type MockTasksService struct {
+	mock.Mock
+}
+
+type MockTasksService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTasksService) EXPECT() *MockTasksService_Expecter {
+	return &MockTasksService_Expecter{mock: &_m.Mock}
+}
+
+// CheckTasksQuery provides a mock function with given fields: filter, sort
+func (_m *MockTasksService) CheckTasksQuery(filter tasks.TasksFilter, sort tasks.Sort) *shared.ServiceError {
+	ret := _m.Called(filter, sort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckTasksQuery")
+	}
+
+	var r0 *shared.ServiceError
+	if rf, ok := ret.Get(0).(func(tasks.TasksFilter, tasks.Sort) *shared.ServiceError); ok {
+		r0 = rf(filter, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ServiceError)
+		}
+	}
+
+	return r0
+}
+
+// MockTasksService_CheckTasksQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckTasksQuery'
+type MockTasksService_CheckTasksQuery_Call struct {
+	*mock.Call
+}
+
+// CheckTasksQuery is a helper method to define mock.On call
+//   - filter tasks.TasksFilter
+//   - sort tasks.Sort
+func (_e *MockTasksService_Expecter) CheckTasksQuery(filter interface{}, sort interface{}) *MockTasksService_CheckTasksQuery_Call {
+	return &MockTasksService_CheckTasksQuery_Call{Call: _e.mock.On("CheckTasksQuery", filter, sort)}
+}
+
+func (_c *MockTasksService_CheckTasksQuery_Call) Run(run func(filter tasks.TasksFilter, sort tasks.Sort)) *MockTasksService_CheckTasksQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(tasks.TasksFilter), args[1].(tasks.Sort))
+	})
+	return _c
+}
+
+func (_c *MockTasksService_CheckTasksQuery_Call) Return(_a0 *shared.ServiceError) *MockTasksService_CheckTasksQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTasksService_CheckTasksQuery_Call) RunAndReturn(run func(tasks.TasksFilter, tasks.Sort) *shared.ServiceError) *MockTasksService_CheckTasksQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTasks provides a mock function with given fields: ctx, login, filter, page
+func (_m *MockTasksService) FindTasks(ctx context.Context, login string, filter tasks.TasksFilter, page tasks.PageParams) (tasks.TasksPage, *shared.ServiceError) {
+	ret := _m.Called(ctx, login, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTasks")
+	}
+
+	var r0 tasks.TasksPage
+	var r1 *shared.ServiceError
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TasksFilter, tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)); ok {
+		return rf(ctx, login, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, tasks.TasksFilter, tasks.PageParams) tasks.TasksPage); ok {
+		r0 = rf(ctx, login, filter, page)
+	} else {
+		r0 = ret.Get(0).(tasks.TasksPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, tasks.TasksFilter, tasks.PageParams) *shared.ServiceError); ok {
+		r1 = rf(ctx, login, filter, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shared.ServiceError)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockTasksService_FindTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTasks'
+type MockTasksService_FindTasks_Call struct {
+	*mock.Call
+}
+
+// FindTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - filter tasks.TasksFilter
+//   - page tasks.PageParams
+func (_e *MockTasksService_Expecter) FindTasks(ctx interface{}, login interface{}, filter interface{}, page interface{}) *MockTasksService_FindTasks_Call {
+	return &MockTasksService_FindTasks_Call{Call: _e.mock.On("FindTasks", ctx, login, filter, page)}
+}
+
+func (_c *MockTasksService_FindTasks_Call) Run(run func(ctx context.Context, login string, filter tasks.TasksFilter, page tasks.PageParams)) *MockTasksService_FindTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(tasks.TasksFilter), args[3].(tasks.PageParams))
+	})
+	return _c
+}
+
+func (_c *MockTasksService_FindTasks_Call) Return(_a0 tasks.TasksPage, _a1 *shared.ServiceError) *MockTasksService_FindTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTasksService_FindTasks_Call) RunAndReturn(run func(context.Context, string, tasks.TasksFilter, tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)) *MockTasksService_FindTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTasksService creates a new instance of MockTasksService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTasksService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTasksService {
+	mock := &MockTasksService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,299 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package views
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockViewsRepo is an autogenerated mock type for the ViewsRepo type
+type MockViewsRepo struct {
+	mock.Mock
+}
+
+type MockViewsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockViewsRepo) EXPECT() *MockViewsRepo_Expecter {
+	return &MockViewsRepo_Expecter{mock: &_m.Mock}
+}
+
+// RemoveViewById provides a mock function with given fields: ctx, owner, id
+func (_m *MockViewsRepo) RemoveViewById(ctx context.Context, owner string, id ViewId) error {
+	ret := _m.Called(ctx, owner, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveViewById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ViewId) error); ok {
+		r0 = rf(ctx, owner, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockViewsRepo_RemoveViewById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveViewById'
+type MockViewsRepo_RemoveViewById_Call struct {
+	*mock.Call
+}
+
+// RemoveViewById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id ViewId
+func (_e *MockViewsRepo_Expecter) RemoveViewById(ctx interface{}, owner interface{}, id interface{}) *MockViewsRepo_RemoveViewById_Call {
+	return &MockViewsRepo_RemoveViewById_Call{Call: _e.mock.On("RemoveViewById", ctx, owner, id)}
+}
+
+func (_c *MockViewsRepo_RemoveViewById_Call) Run(run func(ctx context.Context, owner string, id ViewId)) *MockViewsRepo_RemoveViewById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ViewId))
+	})
+	return _c
+}
+
+func (_c *MockViewsRepo_RemoveViewById_Call) Return(_a0 error) *MockViewsRepo_RemoveViewById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockViewsRepo_RemoveViewById_Call) RunAndReturn(run func(context.Context, string, ViewId) error) *MockViewsRepo_RemoveViewById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveView provides a mock function with given fields: ctx, view
+func (_m *MockViewsRepo) SaveView(ctx context.Context, view View) error {
+	ret := _m.Called(ctx, view)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, View) error); ok {
+		r0 = rf(ctx, view)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockViewsRepo_SaveView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveView'
+type MockViewsRepo_SaveView_Call struct {
+	*mock.Call
+}
+
+// SaveView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - view View
+func (_e *MockViewsRepo_Expecter) SaveView(ctx interface{}, view interface{}) *MockViewsRepo_SaveView_Call {
+	return &MockViewsRepo_SaveView_Call{Call: _e.mock.On("SaveView", ctx, view)}
+}
+
+func (_c *MockViewsRepo_SaveView_Call) Run(run func(ctx context.Context, view View)) *MockViewsRepo_SaveView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(View))
+	})
+	return _c
+}
+
+func (_c *MockViewsRepo_SaveView_Call) Return(_a0 error) *MockViewsRepo_SaveView_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockViewsRepo_SaveView_Call) RunAndReturn(run func(context.Context, View) error) *MockViewsRepo_SaveView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateViewById provides a mock function with given fields: ctx, owner, id, params, updatedAt
+func (_m *MockViewsRepo) UpdateViewById(ctx context.Context, owner string, id ViewId, params ViewParams, updatedAt time.Time) error {
+	ret := _m.Called(ctx, owner, id, params, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateViewById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ViewId, ViewParams, time.Time) error); ok {
+		r0 = rf(ctx, owner, id, params, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockViewsRepo_UpdateViewById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateViewById'
+type MockViewsRepo_UpdateViewById_Call struct {
+	*mock.Call
+}
+
+// UpdateViewById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id ViewId
+//   - params ViewParams
+//   - updatedAt time.Time
+func (_e *MockViewsRepo_Expecter) UpdateViewById(ctx interface{}, owner interface{}, id interface{}, params interface{}, updatedAt interface{}) *MockViewsRepo_UpdateViewById_Call {
+	return &MockViewsRepo_UpdateViewById_Call{Call: _e.mock.On("UpdateViewById", ctx, owner, id, params, updatedAt)}
+}
+
+func (_c *MockViewsRepo_UpdateViewById_Call) Run(run func(ctx context.Context, owner string, id ViewId, params ViewParams, updatedAt time.Time)) *MockViewsRepo_UpdateViewById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ViewId), args[3].(ViewParams), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockViewsRepo_UpdateViewById_Call) Return(_a0 error) *MockViewsRepo_UpdateViewById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockViewsRepo_UpdateViewById_Call) RunAndReturn(run func(context.Context, string, ViewId, ViewParams, time.Time) error) *MockViewsRepo_UpdateViewById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewById provides a mock function with given fields: ctx, owner, id
+func (_m *MockViewsRepo) ViewById(ctx context.Context, owner string, id ViewId) (View, error) {
+	ret := _m.Called(ctx, owner, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewById")
+	}
+
+	var r0 View
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ViewId) (View, error)); ok {
+		return rf(ctx, owner, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ViewId) View); ok {
+		r0 = rf(ctx, owner, id)
+	} else {
+		r0 = ret.Get(0).(View)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ViewId) error); ok {
+		r1 = rf(ctx, owner, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockViewsRepo_ViewById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewById'
+type MockViewsRepo_ViewById_Call struct {
+	*mock.Call
+}
+
+// ViewById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - id ViewId
+func (_e *MockViewsRepo_Expecter) ViewById(ctx interface{}, owner interface{}, id interface{}) *MockViewsRepo_ViewById_Call {
+	return &MockViewsRepo_ViewById_Call{Call: _e.mock.On("ViewById", ctx, owner, id)}
+}
+
+func (_c *MockViewsRepo_ViewById_Call) Run(run func(ctx context.Context, owner string, id ViewId)) *MockViewsRepo_ViewById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ViewId))
+	})
+	return _c
+}
+
+func (_c *MockViewsRepo_ViewById_Call) Return(_a0 View, _a1 error) *MockViewsRepo_ViewById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockViewsRepo_ViewById_Call) RunAndReturn(run func(context.Context, string, ViewId) (View, error)) *MockViewsRepo_ViewById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewsByOwner provides a mock function with given fields: ctx, owner
+func (_m *MockViewsRepo) ViewsByOwner(ctx context.Context, owner string) ([]View, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewsByOwner")
+	}
+
+	var r0 []View
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]View, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []View); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]View)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockViewsRepo_ViewsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewsByOwner'
+type MockViewsRepo_ViewsByOwner_Call struct {
+	*mock.Call
+}
+
+// ViewsByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *MockViewsRepo_Expecter) ViewsByOwner(ctx interface{}, owner interface{}) *MockViewsRepo_ViewsByOwner_Call {
+	return &MockViewsRepo_ViewsByOwner_Call{Call: _e.mock.On("ViewsByOwner", ctx, owner)}
+}
+
+func (_c *MockViewsRepo_ViewsByOwner_Call) Run(run func(ctx context.Context, owner string)) *MockViewsRepo_ViewsByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockViewsRepo_ViewsByOwner_Call) Return(_a0 []View, _a1 error) *MockViewsRepo_ViewsByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockViewsRepo_ViewsByOwner_Call) RunAndReturn(run func(context.Context, string) ([]View, error)) *MockViewsRepo_ViewsByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockViewsRepo creates a new instance of MockViewsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockViewsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockViewsRepo {
+	mock := &MockViewsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
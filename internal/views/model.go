@@ -0,0 +1,72 @@
+package views
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+var ErrViewNotFound = errors.New("view not found")
+var ErrInvalidViewName = errors.New("invalid view name")
+var ErrViewNameConflict = errors.New("view name conflict")
+
+type ViewId uuid.UUID
+
+func (id ViewId) String() string {
+	return uuid.UUID(id).String()
+}
+
+func NewViewId() ViewId {
+	return ViewId(uuid.New())
+}
+
+func ParseViewId(id string) (ViewId, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return ViewId(uuid.Nil), err
+	}
+	return ViewId(uid), nil
+}
+
+// View is the named tasks filter saved by the user,
+// an empty sort means the default sort of the tasks search
+type View struct {
+	Id        ViewId
+	Name      string
+	Owner     string
+	Filter    tasks.TasksFilter
+	Sort      tasks.Sort
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ViewParams struct {
+	Name   string
+	Filter tasks.TasksFilter
+	Sort   tasks.Sort
+}
+
+func NewView(
+	viewId ViewId,
+	name string,
+	owner string,
+	filter tasks.TasksFilter,
+	sort tasks.Sort,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (View, error) {
+	if len(name) == 0 {
+		return View{}, ErrInvalidViewName
+	}
+	return View{
+		Id:        viewId,
+		Name:      name,
+		Owner:     owner,
+		Filter:    filter,
+		Sort:      sort,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
@@ -0,0 +1,269 @@
+package views
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/db"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/projects"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type Repo struct {
+	log     *logger.Logger
+	queries *db.Queries
+}
+
+func NewRepo(
+	log *logger.Logger,
+	queries *db.Queries,
+) *Repo {
+	return &Repo{log, queries}
+}
+
+func (r *Repo) SaveView(ctx context.Context, view View) error {
+	filter, err := r.filterToPg(view.Filter)
+	if err != nil {
+		return err
+	}
+	err = r.queries.InsertTaskView(ctx, db.InsertTaskViewParams{
+		ID:     r.viewIdToPg(view.Id),
+		Name:   view.Name,
+		Owner:  view.Owner,
+		Filter: filter,
+		Sort:   view.Sort.String(),
+		CreatedAt: pgtype.Timestamp{
+			Time:  view.CreatedAt.UTC(),
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamp{
+			Time:  view.UpdatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if isUniqueViolation(err) {
+		return ErrViewNameConflict
+	}
+	return err
+}
+
+func (r *Repo) ViewById(ctx context.Context, owner string, id ViewId) (View, error) {
+	row, err := r.queries.TaskViewById(ctx, db.TaskViewByIdParams{
+		ID:    r.viewIdToPg(id),
+		Owner: owner,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return View{}, ErrViewNotFound
+	}
+	if err != nil {
+		return View{}, err
+	}
+	return r.viewFromPg(row)
+}
+
+func (r *Repo) ViewsByOwner(ctx context.Context, owner string) ([]View, error) {
+	rows, err := r.queries.TaskViewsByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]View, len(rows))
+	for i, row := range rows {
+		if views[i], err = r.viewFromPg(row); err != nil {
+			return nil, err
+		}
+	}
+	return views, nil
+}
+
+func (r *Repo) UpdateViewById(
+	ctx context.Context,
+	owner string,
+	id ViewId,
+	params ViewParams,
+	updatedAt time.Time,
+) error {
+	filter, err := r.filterToPg(params.Filter)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := r.queries.UpdateTaskView(ctx, db.UpdateTaskViewParams{
+		ID:     r.viewIdToPg(id),
+		Owner:  owner,
+		Name:   params.Name,
+		Filter: filter,
+		Sort:   params.Sort.String(),
+		UpdatedAt: pgtype.Timestamp{
+			Time:  updatedAt.UTC(),
+			Valid: true,
+		},
+	})
+	if isUniqueViolation(err) {
+		return ErrViewNameConflict
+	}
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrViewNotFound
+	}
+	return nil
+}
+
+func (r *Repo) RemoveViewById(ctx context.Context, owner string, id ViewId) error {
+	rowsAffected, err := r.queries.DeleteTaskView(ctx, db.DeleteTaskViewParams{
+		ID:    r.viewIdToPg(id),
+		Owner: owner,
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrViewNotFound
+	}
+	return nil
+}
+
+func (r *Repo) viewIdToPg(id ViewId) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func (r *Repo) viewFromPg(row db.TaskView) (View, error) {
+	filter, err := r.filterFromPg(row.Filter)
+	if err != nil {
+		return View{}, err
+	}
+	var sort tasks.Sort
+	if row.Sort != "" {
+		if sort, err = tasks.ParseSort(row.Sort); err != nil {
+			return View{}, err
+		}
+	}
+	return NewView(
+		row.ID.Bytes,
+		row.Name,
+		row.Owner,
+		filter,
+		sort,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	)
+}
+
+// storedFilter is the JSON representation of the tasks filter,
+// the filter expression is stored in the source form
+type storedFilter struct {
+	Title       *string           `json:"title,omitempty"`
+	Query       *string           `json:"q,omitempty"`
+	Status      *tasks.Status     `json:"status,omitempty"`
+	Priority    *tasks.Priority   `json:"priority,omitempty"`
+	DueBefore   *string           `json:"due_before,omitempty"`
+	DueAfter    *string           `json:"due_after,omitempty"`
+	ProjectId   *string           `json:"project_id,omitempty"`
+	ParentId    *string           `json:"parent_id,omitempty"`
+	Assignee    *string           `json:"assignee,omitempty"`
+	Labels      []string          `json:"labels,omitempty"`
+	LabelsMatch tasks.LabelsMatch `json:"labels_match,omitempty"`
+	Ready       bool              `json:"ready,omitempty"`
+	Expr        *string           `json:"filter,omitempty"`
+}
+
+func (r *Repo) filterToPg(filter tasks.TasksFilter) ([]byte, error) {
+	stored := storedFilter{
+		Title:       filter.Title,
+		Query:       filter.Query,
+		Status:      filter.Status,
+		Priority:    filter.Priority,
+		Assignee:    filter.Assignee,
+		Labels:      filter.Labels,
+		LabelsMatch: filter.LabelsMatch,
+		Ready:       filter.ReadyToStart,
+	}
+	if filter.DueBefore != nil {
+		dueBefore := filter.DueBefore.Format(time.DateOnly)
+		stored.DueBefore = &dueBefore
+	}
+	if filter.DueAfter != nil {
+		dueAfter := filter.DueAfter.Format(time.DateOnly)
+		stored.DueAfter = &dueAfter
+	}
+	if filter.ProjectId != nil {
+		projectId := filter.ProjectId.String()
+		stored.ProjectId = &projectId
+	}
+	if filter.ParentId != nil {
+		parentId := filter.ParentId.String()
+		stored.ParentId = &parentId
+	}
+	if filter.Expr != nil {
+		expr := tasks.FormatFilterExpr(filter.Expr)
+		stored.Expr = &expr
+	}
+	return json.Marshal(stored)
+}
+
+func (r *Repo) filterFromPg(data []byte) (tasks.TasksFilter, error) {
+	var stored storedFilter
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return tasks.TasksFilter{}, err
+	}
+	filter := tasks.TasksFilter{
+		Title:        stored.Title,
+		Query:        stored.Query,
+		Status:       stored.Status,
+		Priority:     stored.Priority,
+		Assignee:     stored.Assignee,
+		Labels:       stored.Labels,
+		LabelsMatch:  stored.LabelsMatch,
+		ReadyToStart: stored.Ready,
+	}
+	if stored.DueBefore != nil {
+		dueBefore, err := time.Parse(time.DateOnly, *stored.DueBefore)
+		if err != nil {
+			return filter, err
+		}
+		filter.DueBefore = &dueBefore
+	}
+	if stored.DueAfter != nil {
+		dueAfter, err := time.Parse(time.DateOnly, *stored.DueAfter)
+		if err != nil {
+			return filter, err
+		}
+		filter.DueAfter = &dueAfter
+	}
+	if stored.ProjectId != nil {
+		projectId, err := projects.ParseProjectId(*stored.ProjectId)
+		if err != nil {
+			return filter, err
+		}
+		filter.ProjectId = &projectId
+	}
+	if stored.ParentId != nil {
+		parentId, err := tasks.ParseTaskId(*stored.ParentId)
+		if err != nil {
+			return filter, err
+		}
+		filter.ParentId = &parentId
+	}
+	if stored.Expr != nil {
+		expr, err := tasks.ParseFilterExpr(*stored.Expr)
+		if err != nil {
+			return filter, err
+		}
+		filter.Expr = expr
+	}
+	return filter, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
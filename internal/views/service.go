@@ -0,0 +1,132 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+)
+
+type ViewsRepo interface {
+	SaveView(ctx context.Context, view View) error
+	ViewById(ctx context.Context, owner string, id ViewId) (View, error)
+	ViewsByOwner(ctx context.Context, owner string) ([]View, error)
+	UpdateViewById(ctx context.Context, owner string, id ViewId, params ViewParams, updatedAt time.Time) error
+	RemoveViewById(ctx context.Context, owner string, id ViewId) error
+}
+
+type TasksService interface {
+	CheckTasksQuery(filter tasks.TasksFilter, sort tasks.Sort) *shared.ServiceError
+	FindTasks(ctx context.Context, login string, filter tasks.TasksFilter, page tasks.PageParams) (tasks.TasksPage, *shared.ServiceError)
+}
+
+type Service struct {
+	log          *logger.Logger
+	viewsRepo    ViewsRepo
+	tasksService TasksService
+}
+
+func NewService(
+	log *logger.Logger,
+	viewsRepo ViewsRepo,
+	tasksService TasksService,
+) *Service {
+	return &Service{log, viewsRepo, tasksService}
+}
+
+func (s *Service) CreateView(ctx context.Context, owner string, params ViewParams) (View, *shared.ServiceError) {
+	now := time.Now()
+	view, err := NewView(
+		NewViewId(),
+		params.Name,
+		owner,
+		params.Filter,
+		params.Sort,
+		now,
+		now,
+	)
+	if err != nil {
+		return view, shared.NewServiceError(err, "failed to create view")
+	}
+	if sErr := s.tasksService.CheckTasksQuery(view.Filter, view.Sort); sErr != nil {
+		return view, sErr
+	}
+	err = s.viewsRepo.SaveView(ctx, view)
+	if errors.Is(err, ErrViewNameConflict) {
+		return view, shared.NewServiceError(err, fmt.Sprintf("view with name %q already exists", view.Name))
+	}
+	if err != nil {
+		return view, shared.NewUnexpectedError(err, "failed to save view")
+	}
+	return view, nil
+}
+
+func (s *Service) Views(ctx context.Context, owner string) ([]View, *shared.ServiceError) {
+	views, err := s.viewsRepo.ViewsByOwner(ctx, owner)
+	if err != nil {
+		return views, shared.NewUnexpectedError(err, "failed to load views")
+	}
+	return views, nil
+}
+
+func (s *Service) ViewById(ctx context.Context, owner string, id ViewId) (View, *shared.ServiceError) {
+	view, err := s.viewsRepo.ViewById(ctx, owner, id)
+	if errors.Is(err, ErrViewNotFound) {
+		return view, shared.NewServiceError(err, fmt.Sprintf("view with id %q not found", id.String()))
+	}
+	if err != nil {
+		return view, shared.NewUnexpectedError(err, "failed to load view")
+	}
+	return view, nil
+}
+
+func (s *Service) UpdateViewById(ctx context.Context, owner string, id ViewId, params ViewParams) *shared.ServiceError {
+	if len(params.Name) == 0 {
+		return shared.NewServiceError(ErrInvalidViewName, "failed to update view")
+	}
+	if sErr := s.tasksService.CheckTasksQuery(params.Filter, params.Sort); sErr != nil {
+		return sErr
+	}
+	err := s.viewsRepo.UpdateViewById(ctx, owner, id, params, time.Now())
+	if errors.Is(err, ErrViewNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("view with id %q not found", id.String()))
+	}
+	if errors.Is(err, ErrViewNameConflict) {
+		return shared.NewServiceError(err, fmt.Sprintf("view with name %q already exists", params.Name))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to update view")
+	}
+	return nil
+}
+
+func (s *Service) RemoveViewById(ctx context.Context, owner string, id ViewId) *shared.ServiceError {
+	err := s.viewsRepo.RemoveViewById(ctx, owner, id)
+	if errors.Is(err, ErrViewNotFound) {
+		return shared.NewServiceError(err, fmt.Sprintf("view with id %q not found", id.String()))
+	}
+	if err != nil {
+		return shared.NewUnexpectedError(err, "failed to remove view")
+	}
+	return nil
+}
+
+// ViewTasks finds tasks with the filter and the sort of the view,
+// the sort of the page params is ignored
+func (s *Service) ViewTasks(
+	ctx context.Context,
+	owner string,
+	id ViewId,
+	page tasks.PageParams,
+) (tasks.TasksPage, *shared.ServiceError) {
+	view, sErr := s.ViewById(ctx, owner, id)
+	if sErr != nil {
+		return tasks.TasksPage{}, sErr
+	}
+	page.Sort = view.Sort
+	return s.tasksService.FindTasks(ctx, owner, view.Filter, page)
+}
@@ -0,0 +1,248 @@
+package views_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/x0k/skillrock-tasks-service/internal/lib/logger"
+	"github.com/x0k/skillrock-tasks-service/internal/shared"
+	"github.com/x0k/skillrock-tasks-service/internal/tasks"
+	"github.com/x0k/skillrock-tasks-service/internal/views"
+)
+
+const owner = "owner"
+
+type serviceMocks struct {
+	viewsRepo    *views.MockViewsRepo
+	tasksService *views.MockTasksService
+}
+
+func newTestService(t *testing.T, setup func(sm serviceMocks)) *views.Service {
+	var buf bytes.Buffer
+	log := logger.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	viewsRepo := views.NewMockViewsRepo(t)
+	tasksService := views.NewMockTasksService(t)
+	if setup != nil {
+		setup(serviceMocks{
+			viewsRepo:    viewsRepo,
+			tasksService: tasksService,
+		})
+	}
+	return views.NewService(
+		log,
+		viewsRepo,
+		tasksService,
+	)
+}
+
+func TestServiceCreateView(t *testing.T) {
+	status := tasks.InProgress
+	params := views.ViewParams{
+		Name:   "in progress",
+		Filter: tasks.TasksFilter{Status: &status},
+		Sort:   tasks.Sort{{Field: tasks.SortByPriority, Desc: true}},
+	}
+	invalidSortErr := shared.NewServiceError(tasks.ErrInvalidSort, "")
+	unexpectedErr := errors.New("unexpected err")
+	cases := []struct {
+		name    string
+		service *views.Service
+		params  views.ViewParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "valid params",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(params.Filter, params.Sort).Return(nil)
+				viewMatcher := mock.MatchedBy(func(v views.View) bool {
+					return v.Name == params.Name && v.Owner == owner &&
+						reflect.DeepEqual(v.Filter, params.Filter) && reflect.DeepEqual(v.Sort, params.Sort)
+				})
+				sm.viewsRepo.EXPECT().SaveView(mock.Anything, viewMatcher).Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  views.ViewParams{},
+			err:     shared.NewServiceError(views.ErrInvalidViewName, ""),
+		},
+		{
+			name: "invalid tasks query",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(mock.Anything, mock.Anything).Return(invalidSortErr)
+			}),
+			params: views.ViewParams{Name: "by rank", Sort: tasks.DefaultSearchSort},
+			err:    invalidSortErr,
+		},
+		{
+			name: "name conflict",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(mock.Anything, mock.Anything).Return(nil)
+				sm.viewsRepo.EXPECT().SaveView(mock.Anything, mock.Anything).Return(views.ErrViewNameConflict)
+			}),
+			params: params,
+			err:    shared.NewServiceError(views.ErrViewNameConflict, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(mock.Anything, mock.Anything).Return(nil)
+				sm.viewsRepo.EXPECT().SaveView(mock.Anything, mock.Anything).Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := c.service.CreateView(t.Context(), owner, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceUpdateViewById(t *testing.T) {
+	viewId := views.NewViewId()
+	params := views.ViewParams{
+		Name: "all",
+	}
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *views.Service
+		params  views.ViewParams
+		err     *shared.ServiceError
+	}{
+		{
+			name: "happy path",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(params.Filter, params.Sort).Return(nil)
+				sm.viewsRepo.EXPECT().
+					UpdateViewById(mock.Anything, owner, viewId, params, mock.AnythingOfType("time.Time")).
+					Return(nil)
+			}),
+			params: params,
+		},
+		{
+			name:    "invalid name",
+			service: newTestService(t, nil),
+			params:  views.ViewParams{},
+			err:     shared.NewServiceError(views.ErrInvalidViewName, ""),
+		},
+		{
+			name: "view not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(mock.Anything, mock.Anything).Return(nil)
+				sm.viewsRepo.EXPECT().
+					UpdateViewById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(views.ErrViewNotFound)
+			}),
+			params: params,
+			err:    shared.NewServiceError(views.ErrViewNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.tasksService.EXPECT().CheckTasksQuery(mock.Anything, mock.Anything).Return(nil)
+				sm.viewsRepo.EXPECT().
+					UpdateViewById(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(unexpectedErr)
+			}),
+			params: params,
+			err:    shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.service.UpdateViewById(t.Context(), owner, viewId, c.params); err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+		})
+	}
+}
+
+func TestServiceViewTasks(t *testing.T) {
+	priority := tasks.High
+	view := views.View{
+		Id:     views.NewViewId(),
+		Name:   "urgent",
+		Owner:  owner,
+		Filter: tasks.TasksFilter{Priority: &priority},
+		Sort:   tasks.Sort{{Field: tasks.SortByDueDate}},
+	}
+	found := tasks.TasksPage{Tasks: []tasks.Task{{Id: tasks.NewTaskId()}}}
+	unexpectedErr := errors.New("unexpected error")
+	cases := []struct {
+		name    string
+		service *views.Service
+		page    tasks.PageParams
+		result  tasks.TasksPage
+		err     *shared.ServiceError
+	}{
+		{
+			name: "view sort",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.viewsRepo.EXPECT().ViewById(mock.Anything, owner, view.Id).Return(view, nil)
+				sm.tasksService.EXPECT().FindTasks(mock.Anything, owner, view.Filter, tasks.PageParams{
+					Sort:      view.Sort,
+					Limit:     10,
+					WithTotal: true,
+				}).Return(found, nil)
+			}),
+			page:   tasks.PageParams{Sort: tasks.DefaultSort, Limit: 10, WithTotal: true},
+			result: found,
+		},
+		{
+			name: "view not found",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.viewsRepo.EXPECT().ViewById(mock.Anything, mock.Anything, mock.Anything).Return(views.View{}, views.ErrViewNotFound)
+			}),
+			err: shared.NewServiceError(views.ErrViewNotFound, ""),
+		},
+		{
+			name: "unexpected error",
+			service: newTestService(t, func(sm serviceMocks) {
+				sm.viewsRepo.EXPECT().ViewById(mock.Anything, mock.Anything, mock.Anything).Return(views.View{}, unexpectedErr)
+			}),
+			err: shared.NewUnexpectedError(unexpectedErr, ""),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := c.service.ViewTasks(t.Context(), owner, view.Id, c.page)
+			if err != nil {
+				if c.err == nil ||
+					!errors.Is(err.Err, c.err.Err) ||
+					err.Expected != c.err.Expected ||
+					(c.err.Msg != "" && err.Msg != c.err.Msg) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(c.result, result) {
+				t.Fatalf("expected page %v, but got %v", c.result, result)
+			}
+		})
+	}
+}